@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test that every import of this repository's own packages uses the module
+// path from go.mod, so a stale path is caught without a full CI build.
+func TestInternalImportsUseModulePath(t *testing.T) {
+	root := filepath.Join("..", "..")
+	module := modulePath(t, filepath.Join(root, "go.mod"))
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if strings.HasPrefix(info.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, imp := range file.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			if strings.Contains(importPath, "/internal/") && !strings.HasPrefix(importPath, module+"/") {
+				t.Errorf("%s imports %q, want a path under %s", path, importPath, module)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to scan sources: %v", err)
+	}
+}
+
+func modulePath(t *testing.T, goMod string) string {
+	f, err := os.Open(goMod)
+	if err != nil {
+		t.Fatalf("failed to open go.mod: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+			return fields[1]
+		}
+	}
+	t.Fatal("go.mod has no module line")
+	return ""
+}
@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/app"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
 )
 
@@ -36,12 +37,20 @@ func printUsage() {
 	fmt.Println("kube-wizard - interactive kubectl command wizard")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  kube-wizard [--version] [--config PATH]")
+	fmt.Println("  kube-wizard [--version] [--log-path] [--verbose] [--config PATH] [--goto RESOURCE/ACTION] [--no-alt-screen]")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -h, --help       Show this help message and exit")
-	fmt.Println("      --version    Print the version and exit")
-	fmt.Println("      --config     Path to optional configuration file (not yet used)")
+	fmt.Println("      --version    Print the version and log file path and exit")
+	fmt.Println("      --log-path   Print the log file path and exit")
+	fmt.Println("      --verbose    Write debug messages to the log file")
+	fmt.Println("      --config     Path to optional JSON configuration file")
+	fmt.Println("                   (default: ~/kube-wizard-config.json)")
+	fmt.Println("      --goto       Start at an action instead of the main menu,")
+	fmt.Println("                   e.g. pods/logs or deployments/view-yaml")
+	fmt.Println("      --no-alt-screen")
+	fmt.Println("                   Draw in the normal screen so the last view stays")
+	fmt.Println("                   in the terminal scrollback after quitting")
 }
 
 func main() {
@@ -51,9 +60,6 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Warning: failed to initialize logger: %v\n", err)
 	} else {
 		defer logger.Close()
-		// We could print the log path if we wanted to be helpful, 
-		// but let's keep it quiet for now unless it's needed.
-		_ = logPath
 	}
 
 	// Minimal hand-rolled flag parsing to keep behaviour explicit and avoid
@@ -61,7 +67,11 @@ func main() {
 	args := os.Args[1:]
 	showHelp := false
 	showVersion := false
+	showLogPath := false
+	verbose := false
 	configPath := ""
+	gotoTarget := ""
+	noAltScreen := false
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
@@ -70,6 +80,10 @@ func main() {
 			showHelp = true
 		case arg == "--version":
 			showVersion = true
+		case arg == "--log-path":
+			showLogPath = true
+		case arg == "--verbose":
+			verbose = true
 		case arg == "--config":
 			if i+1 >= len(args) {
 				fmt.Fprintln(os.Stderr, "Error: --config flag requires a path argument")
@@ -81,6 +95,19 @@ func main() {
 			i++
 		case strings.HasPrefix(arg, "--config="):
 			configPath = strings.TrimPrefix(arg, "--config=")
+		case arg == "--goto":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "Error: --goto flag requires a RESOURCE/ACTION argument")
+				fmt.Fprintln(os.Stderr)
+				printUsage()
+				os.Exit(2)
+			}
+			gotoTarget = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--goto="):
+			gotoTarget = strings.TrimPrefix(arg, "--goto=")
+		case arg == "--no-alt-screen":
+			noAltScreen = true
 		default:
 			fmt.Fprintf(os.Stderr, "Error: unknown flag or argument %q\n\n", arg)
 			printUsage()
@@ -93,16 +120,43 @@ func main() {
 		return
 	}
 
+	if logPath == "" {
+		logPath = logger.DefaultPath()
+	}
+
 	if showVersion {
 		fmt.Printf("kube-wizard version %s\n", getDetailedVersion())
+		fmt.Printf("log file: %s\n", logPath)
 		return
 	}
 
-	// For now, the config path is parsed but not yet wired into the app.
-	_ = configPath
+	if showLogPath {
+		fmt.Println(logPath)
+		return
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// --verbose always wins over the configured log level
+	logLevel, err := logger.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid config: %v\n", err)
+		os.Exit(1)
+	}
+	if verbose {
+		logLevel = logger.LevelDebug
+	}
+	logger.SetLevel(logLevel)
+
+	// Build the model once; the preflight checks use its kubectl client
+	model := app.NewModel(cfg).WithLogPath(logPath).WithConfigPath(configPath)
 
 	// Check if kubectl is installed
-	kubectlClient := app.NewModel().GetKubectlClient()
+	kubectlClient := model.GetKubectlClient()
 	if err := kubectlClient.CheckKubectlInstalled(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -118,15 +172,30 @@ func main() {
 		}
 	}
 
+	if gotoTarget != "" {
+		if model, err = model.WithGoto(gotoTarget); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
 	// Initialize the Bubble Tea program with our app model
-	p := tea.NewProgram(
-		app.NewModel(),
-		tea.WithAltScreen(),       // Use alternate screen buffer
+	opts := []tea.ProgramOption{
 		tea.WithMouseCellMotion(), // Enable mouse support
-	)
+	}
+	// The alternate screen buffer is left on quit, taking the last view with it
+	if !noAltScreen && !cfg.NoAltScreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	// Port-forwards started in the background must not outlive the wizard
+	if m, ok := final.(app.Model); ok {
+		m.StopPortForwards()
+	}
+	if err != nil {
 		fmt.Printf("Error running application: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxFieldPaths caps how many field paths are offered for one resource so
+// large objects (e.g. nodes with many images) stay browsable.
+const maxFieldPaths = 500
+
+// flattenFieldPaths returns the paths of all leaf values in a decoded JSON
+// object, e.g. "spec.containers[0].image". Object keys are sorted and keys
+// containing dots are escaped so every path can be used in a JSONPath
+// expression as "{." + path + "}". metadata.managedFields is skipped as it
+// is bookkeeping nobody wants to extract.
+func flattenFieldPaths(v interface{}) []string {
+	var paths []string
+	collectFieldPaths(v, "", &paths)
+	return paths
+}
+
+func collectFieldPaths(v interface{}, prefix string, paths *[]string) {
+	if len(*paths) >= maxFieldPaths {
+		return
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			path := strings.ReplaceAll(k, ".", `\.`)
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			if path == "metadata.managedFields" {
+				continue
+			}
+			collectFieldPaths(val[k], path, paths)
+		}
+	case []interface{}:
+		for i, item := range val {
+			collectFieldPaths(item, fmt.Sprintf("%s[%d]", prefix, i), paths)
+		}
+	default:
+		if prefix != "" {
+			*paths = append(*paths, prefix)
+		}
+	}
+}
+
+// jsonPathExpr wraps a field path from flattenFieldPaths as a JSONPath
+// expression, quoted for the shell.
+func jsonPathExpr(path string) string {
+	return "'{." + strings.ReplaceAll(path, "'", `'\''`) + "}'"
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+func TestFlattenFieldPaths(t *testing.T) {
+	var obj interface{}
+	raw := `{
+		"kind": "Pod",
+		"metadata": {
+			"name": "web",
+			"labels": {"app.kubernetes.io/name": "web"},
+			"managedFields": [{"manager": "kubectl"}]
+		},
+		"spec": {"containers": [{"name": "app", "image": "nginx"}]}
+	}`
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		t.Fatalf("failed to parse test JSON: %v", err)
+	}
+
+	got := flattenFieldPaths(obj)
+	want := []string{
+		"kind",
+		`metadata.labels.app\.kubernetes\.io/name`,
+		"metadata.name",
+		"spec.containers[0].image",
+		"spec.containers[0].name",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenFieldPaths() = %v, want %v", got, want)
+	}
+}
+
+// Test that picking a field of a non-secret resource builds a jsonpath command.
+func TestFieldSelectionBuildsJSONPathCommand(t *testing.T) {
+	m := Model{
+		selectedResource:     ResourcePods,
+		selectedAction:       ActionExtractField,
+		selectedResourceName: "web/frontend",
+	}
+	m = m.navigateToFieldSelection([]string{"spec.containers[0].image"})
+	if m.list.Items()[2].(ui.SimpleItem).Title() != "spec.containers[0].image" {
+		t.Fatalf("unexpected field list items: %v", m.list.Items())
+	}
+	m.list.Select(2)
+
+	updated, _ := m.handleFieldSelection()
+	m = updated.(Model)
+
+	want := "kubectl get pod frontend -o jsonpath='{.spec.containers[0].image}' -n web"
+	if m.currentCommand != want {
+		t.Errorf("expected command %q, got %q", want, m.currentCommand)
+	}
+}
+
+func TestCustomColumnsFlag(t *testing.T) {
+	got := customColumnsFlag([]string{
+		"metadata.name",
+		"spec.containers[0].image",
+		`metadata.labels.app\.kubernetes\.io/name`,
+		"status.containerStatuses[0].name",
+	})
+	want := `-o custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[0].image,` +
+		`APP_KUBERNETES_IO_NAME:.metadata.labels.app\.kubernetes\.io/name,NAME_2:.status.containerStatuses[0].name`
+	if got != want {
+		t.Errorf("customColumnsFlag() = %q, want %q", got, want)
+	}
+}
+
+// Test that the custom columns builder returns to the flags list with the
+// assembled flag ticked, in the order the fields were picked.
+func TestCustomColumnsBuilderAddsFlag(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionGet}
+	m = m.navigateToFlagsSelection()
+	m = m.navigateToCustomColumns([]string{"metadata.name", "spec.nodeName"})
+
+	for _, idx := range []int{3, 2} {
+		m.list.Select(idx)
+		m = m.toggleCustomColumn()
+	}
+	m.list.Select(0)
+	updated, _ := m.handleCustomColumnsSelection()
+	m = updated.(Model)
+
+	flag := "-o custom-columns=NODENAME:.spec.nodeName,NAME:.metadata.name"
+	if m.currentScreen != FlagsSelectionScreen {
+		t.Fatalf("expected flags selection screen, got %s", m.currentScreen)
+	}
+	if len(m.selectedFlags) != 1 || m.selectedFlags[0] != flag {
+		t.Errorf("expected selected flags [%s], got %v", flag, m.selectedFlags)
+	}
+	items := m.list.Items()
+	if last := items[len(items)-1].(ui.SimpleItem).Title(); last != "[x] "+flag {
+		t.Errorf("expected ticked flag item, got %q", last)
+	}
+}
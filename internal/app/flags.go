@@ -0,0 +1,114 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+// flagDescriptions explains flags in plain English. It backs the
+// descriptions in the flags selection and the flag summary on the preview.
+var flagDescriptions = map[string]string{
+	"-o wide":                "Show additional columns",
+	"-o yaml":                "Output the full YAML",
+	"-o json":                "Output the full JSON",
+	"-o name":                "Print only resource/name",
+	"--show-labels":          "Show labels",
+	"-A":                     "Across all namespaces",
+	"--all-namespaces":       "Across all namespaces",
+	"--show-events=true":     "Show events",
+	"-f":                     "Follow log output",
+	"--follow":               "Follow log output",
+	"--tail=100":             "Show last 100 lines",
+	"--tail=50":              "Show last 50 lines",
+	"--since=1h":             "Show logs from last hour",
+	"--since=5m":             "Show logs from last 5 minutes",
+	"--previous":             "Show logs from previous container",
+	"--use-protocol-buffers": "Use protocol buffers for communication",
+	"--sort-by=cpu":          "Sort by CPU usage, highest first",
+	"--sort-by=memory":       "Sort by memory usage, highest first",
+	"--ignore-daemonsets":    "Skip pods managed by DaemonSets",
+	"--delete-emptydir-data": "Evict pods using emptyDir volumes (their data is lost)",
+	"--force":                "Skip the usual safety checks",
+	"--no-headers":           "Leave out the column headers",
+	"-it":                    "Attach an interactive terminal",
+	"-w":                     "Keep watching for changes",
+	"--watch":                "Keep watching for changes",
+	"--dry-run=server":       "Validate on the server without changing anything",
+	"--dry-run=client":       "Only print what would be sent, without changing anything",
+}
+
+// valueFlags take their value as the next argument, e.g. "-o yaml".
+var valueFlags = map[string]bool{
+	"-o": true, "--output": true, "-n": true, "--namespace": true, "-c": true, "--container": true,
+	"-l": true, "--selector": true, "--context": true,
+}
+
+// flagItem is a flags selection entry for flag, described from flagDescriptions.
+func flagItem(flag string) ui.SimpleItem {
+	return ui.NewSimpleItem("[ ] "+flag, flagDescriptions[flag])
+}
+
+// explainFlags lists the flags of cmd as "flag: description" lines. Flags
+// without a known description are left out, as is everything after "--".
+func explainFlags(cmd string) []string {
+	var lines []string
+	args := strings.Fields(cmd)
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag == "--" {
+			break
+		}
+		if !strings.HasPrefix(flag, "-") {
+			continue
+		}
+		if valueFlags[flag] && i+1 < len(args) {
+			i++
+			flag += " " + args[i]
+		}
+		if desc := describeFlag(flag); desc != "" {
+			lines = append(lines, flag+": "+desc)
+		}
+	}
+	return lines
+}
+
+// describeFlag explains one flag, with its value if it takes one.
+func describeFlag(flag string) string {
+	if desc, ok := flagDescriptions[flag]; ok {
+		return desc
+	}
+
+	name, value := flag, ""
+	if i := strings.IndexAny(flag, " ="); i >= 0 {
+		name, value = flag[:i], flag[i+1:]
+	}
+	switch name {
+	case "-n", "--namespace":
+		return "In namespace " + value
+	case "-c", "--container":
+		return "In container " + value
+	case "-l", "--selector":
+		return "Only resources labelled " + value
+	case "--context":
+		return "Against kube context " + value
+	case "--tail":
+		return "Show last " + value + " lines"
+	case "--since":
+		return "Show logs from last " + value
+	case "--timeout":
+		return "Give up after " + value
+	case "--for":
+		if cond := strings.TrimPrefix(value, "condition="); cond != value {
+			return "Until the condition " + cond + " is met"
+		}
+	case "-o", "--output":
+		switch {
+		case strings.HasPrefix(value, "custom-columns="):
+			return "Show only the picked columns"
+		case strings.HasPrefix(value, "jsonpath="), strings.HasPrefix(value, "go-template="):
+			return "Print only the selected fields"
+		}
+	}
+	return ""
+}
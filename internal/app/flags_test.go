@@ -0,0 +1,25 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainFlags(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want []string
+	}{
+		{"kubectl get pods", nil},
+		{"kubectl get pods -A -o yaml", []string{"-A: Across all namespaces", "-o yaml: Output the full YAML"}},
+		{"kubectl logs web --tail=20 -n prod", []string{"--tail=20: Show last 20 lines", "-n prod: In namespace prod"}},
+		{"kubectl get pods --unknown-flag -o custom-columns=NAME:.metadata.name", []string{"-o custom-columns=NAME:.metadata.name: Show only the picked columns"}},
+		{"kubectl exec -it web -- ls -A", []string{"-it: Attach an interactive terminal"}},
+	}
+
+	for _, tt := range tests {
+		if got := explainFlags(tt.cmd); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("explainFlags(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
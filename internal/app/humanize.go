@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// now is the clock used by humanizeSince and command timings; tests
+// replace it.
+var now = time.Now
+
+// humanizeSince describes how long ago t was, e.g. "just now", "2m ago",
+// "3h ago", "yesterday", "4 days ago", falling back to the date for anything
+// older than a week.
+func humanizeSince(t time.Time) string {
+	current := now()
+	d := current.Sub(t)
+
+	switch {
+	case d < 10*time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+
+	// Beyond a day, count calendar days in local time
+	y1, m1, d1 := t.In(current.Location()).Date()
+	y2, m2, d2 := current.Date()
+	days := int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+
+	switch {
+	case days <= 1:
+		return "yesterday"
+	case days < 7:
+		return fmt.Sprintf("%d days ago", days)
+	case y1 == y2:
+		return t.In(current.Location()).Format("Jan 2")
+	default:
+		return t.In(current.Location()).Format("Jan 2, 2006")
+	}
+}
+
+// humanizeDuration formats how long a command ran, e.g. "0.4s", "3.2s",
+// "2m05s" or "1h02m".
+func humanizeDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d/time.Minute), int(d%time.Minute/time.Second))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeSince(t *testing.T) {
+	current := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return current }
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "just now"},
+		{-5 * time.Second, "just now"},
+		{45 * time.Second, "45s ago"},
+		{2 * time.Minute, "2m ago"},
+		{59 * time.Minute, "59m ago"},
+		{3 * time.Hour, "3h ago"},
+		{23 * time.Hour, "23h ago"},
+		{26 * time.Hour, "yesterday"},
+		{4 * 24 * time.Hour, "4 days ago"},
+		{10 * 24 * time.Hour, "Mar 5"},
+		{400 * 24 * time.Hour, "Feb 9, 2023"},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeSince(current.Add(-tt.ago)); got != tt.want {
+			t.Errorf("humanizeSince(now - %v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		400 * time.Millisecond:                    "0.4s",
+		3200 * time.Millisecond:                   "3.2s",
+		125 * time.Second:                         "2m05s",
+		time.Hour + 2*time.Minute + 9*time.Second: "1h02m",
+	}
+	for d, want := range tests {
+		if got := humanizeDuration(d); got != want {
+			t.Errorf("humanizeDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
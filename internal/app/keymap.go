@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the key bindings handled by handleKeyPress. Defaults come from
+// defaultKeyMap and can be overridden through the "keys" section of the
+// config file.
+type keyMap struct {
+	Cancel         key.Binding
+	Quit           key.Binding
+	Back           key.Binding
+	Select         key.Binding
+	Toggle         key.Binding
+	Prev           key.Binding
+	Next           key.Binding
+	Delete         key.Binding
+	Save           key.Binding
+	Rename         key.Binding
+	Refresh        key.Binding
+	BindHotkey     key.Binding
+	CheckContexts  key.Binding
+	CompareLive    key.Binding
+	AppendScript   key.Binding
+	ExportScript   key.Binding
+	Pin            key.Binding
+	AllNamespaces  key.Binding
+	ToggleTimes    key.Binding
+	Theme          key.Binding
+	RememberFlags  key.Binding
+	Rerun          key.Binding
+	Apply          key.Binding
+	RecentContexts key.Binding
+	Retry          key.Binding
+	EditRetry      key.Binding
+	Upload         key.Binding
+	Jump           key.Binding
+}
+
+// defaultKeyMap returns the built-in key bindings.
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Cancel:         key.NewBinding(key.WithKeys("ctrl+x"), key.WithHelp("ctrl+x", "cancel command")),
+		Quit:           key.NewBinding(key.WithKeys("ctrl+c", "q"), key.WithHelp("q", "quit / main menu")),
+		Back:           key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Select:         key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		Toggle:         key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")),
+		Prev:           key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "previous version")),
+		Next:           key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "next version")),
+		Delete:         key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+		Save:           key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save")),
+		Rename:         key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rename")),
+		Refresh:        key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		BindHotkey:     key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "bind hotkey")),
+		CheckContexts:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "check contexts")),
+		CompareLive:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "compare with live")),
+		AppendScript:   key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "append to script")),
+		ExportScript:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "export script")),
+		Pin:            key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pin")),
+		AllNamespaces:  key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "all namespaces")),
+		ToggleTimes:    key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "relative/absolute times")),
+		Theme:          key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "toggle theme")),
+		RememberFlags:  key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "save flags as default")),
+		Rerun:          key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "re-run last command")),
+		Apply:          key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "apply edited YAML")),
+		RecentContexts: key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "recent contexts")),
+		Retry:          key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "retry failed command")),
+		EditRetry:      key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit & retry failed command")),
+		Upload:         key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "upload output")),
+		Jump:           key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "jump to name")),
+	}
+}
+
+// bindings maps the action names used in the config file to their bindings.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"cancel":          &k.Cancel,
+		"quit":            &k.Quit,
+		"back":            &k.Back,
+		"select":          &k.Select,
+		"toggle":          &k.Toggle,
+		"prev":            &k.Prev,
+		"next":            &k.Next,
+		"delete":          &k.Delete,
+		"save":            &k.Save,
+		"rename":          &k.Rename,
+		"refresh":         &k.Refresh,
+		"bind_hotkey":     &k.BindHotkey,
+		"check_contexts":  &k.CheckContexts,
+		"compare_live":    &k.CompareLive,
+		"append_script":   &k.AppendScript,
+		"export_script":   &k.ExportScript,
+		"pin":             &k.Pin,
+		"all_namespaces":  &k.AllNamespaces,
+		"toggle_times":    &k.ToggleTimes,
+		"theme":           &k.Theme,
+		"remember_flags":  &k.RememberFlags,
+		"rerun":           &k.Rerun,
+		"apply":           &k.Apply,
+		"recent_contexts": &k.RecentContexts,
+		"retry":           &k.Retry,
+		"edit_retry":      &k.EditRetry,
+		"upload":          &k.Upload,
+		"jump":            &k.Jump,
+	}
+}
+
+// newKeyMap returns the default key bindings with overrides applied. Each
+// override maps an action name to one or more comma-separated keys, e.g.
+// {"back": "esc,ctrl+["}. Unknown action names are reported as an error and
+// the defaults are kept for them.
+func newKeyMap(overrides map[string]string) (keyMap, error) {
+	km := defaultKeyMap()
+	bindings := km.bindings()
+
+	var unknown []string
+	for name, value := range overrides {
+		binding, ok := bindings[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		var keys []string
+		for _, k := range strings.Split(value, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		if len(value) > 0 && len(keys) == 0 {
+			// A lone space or comma is a key in its own right
+			keys = []string{value}
+		}
+		if len(keys) == 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		binding.SetKeys(keys...)
+		binding.SetHelp(keys[0], binding.Help().Desc)
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return km, fmt.Errorf("invalid key bindings in config: %s", strings.Join(unknown, ", "))
+	}
+	return km, nil
+}
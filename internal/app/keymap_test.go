@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewKeyMapAppliesOverrides(t *testing.T) {
+	km, err := newKeyMap(map[string]string{"back": "esc, ctrl+[", "delete": "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+	if !key.Matches(esc, km.Back) {
+		t.Errorf("expected esc to still match back")
+	}
+	if got := km.Back.Keys(); len(got) != 2 || got[1] != "ctrl+[" {
+		t.Errorf("expected back keys [esc ctrl+[], got %v", got)
+	}
+
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	d := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}
+	if !key.Matches(x, km.Delete) || key.Matches(d, km.Delete) {
+		t.Errorf("expected delete to be remapped from d to x")
+	}
+
+	// Untouched bindings keep their defaults
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}, km.Save) {
+		t.Errorf("expected save to keep its default key")
+	}
+}
+
+func TestNewKeyMapRejectsUnknownActions(t *testing.T) {
+	km, err := newKeyMap(map[string]string{"launch": "l"})
+	if err == nil {
+		t.Fatalf("expected an error for an unknown action")
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyEsc}, km.Back) {
+		t.Errorf("expected defaults to be kept when overrides are invalid")
+	}
+}
@@ -1,6 +1,11 @@
 package app
 
-import "github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+import (
+	"context"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/diff"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+)
 
 // clearErrorMsg is sent to clear the error message after a delay
 type clearErrorMsg struct{}
@@ -9,14 +14,29 @@ type clearErrorMsg struct{}
 
 // resourceNamesLoadedMsg is sent when resource names have been fetched for selection
 type resourceNamesLoadedMsg struct {
-	names []string
-	err   error
+	names     []string
+	namespace string // Namespace that was listed; only looked up when names is empty
+	err       error
+}
+
+// loadingStartedMsg is sent right before a slow kubectl call starts so the
+// spinner can be shown with a label
+type loadingStartedMsg struct {
+	label string
+}
+
+// commandStartedMsg is sent right before a kubectl command starts running and
+// carries the function that cancels it and the command as it is run
+type commandStartedMsg struct {
+	cancel  context.CancelFunc
+	command string
 }
 
 // commandExecutedMsg is sent when a kubectl command has been executed
 type commandExecutedMsg struct {
-	result kubectl.CommandResult
-	err    error
+	result  kubectl.CommandResult
+	context string // Kube context the command ran against
+	err     error
 }
 
 type commandHelpLoadedMsg struct {
@@ -24,17 +44,105 @@ type commandHelpLoadedMsg struct {
 	err    error
 }
 
+// podRestartsCheckedMsg is sent when the restart count of the pod in a
+// "logs --previous" command has been fetched
+type podRestartsCheckedMsg struct {
+	command   string
+	container string // The container counted, or "" for all of them
+	restarts  int
+	err       error
+}
+
+// scriptWrittenMsg is sent when a command was appended to the session script
+// or the history was exported as a script
+type scriptWrittenMsg struct {
+	path     string
+	appended bool
+	err      error
+}
+
+// logsLoadedMsg is sent when the tail of the log file has been read
+type logsLoadedMsg struct {
+	content   string
+	truncated bool
+	err       error
+}
+
+// dryRunLoadedMsg is sent when a dry run of the previewed command has finished
+type dryRunLoadedMsg struct {
+	command string
+	result  kubectl.CommandResult
+	err     error
+}
+
 type clusterConnectivityCheckedMsg struct {
-	result kubectl.CommandResult
-	err    error
+	result  kubectl.CommandResult
+	summary *kubectl.ClusterSummary // nil when the cluster is unreachable or the summary failed
+	err     error
+}
+
+// currentContextCheckedMsg reports whether the current context's cluster
+// responded, checked at startup and after switching context
+type currentContextCheckedMsg struct {
+	context  string
+	status   kubectl.ContextStatus
+	err      error // No current context could be read
+	switchID int   // contextSwitchID when the check started
+}
+
+// connectivityRecheckMsg is sent when it is time to check the current
+// context's cluster again from the main menu
+type connectivityRecheckMsg struct {
+	id int
+}
+
+// nodeUsageSampledMsg carries one "kubectl top nodes" sample taken for the
+// Cluster Info visit identified by id
+type nodeUsageSampledMsg struct {
+	id    int
+	usage []kubectl.NodeUsage
+	err   error
+}
+
+// nodeUsagePollMsg is sent when it is time to sample node usage again
+type nodeUsagePollMsg struct {
+	id int
+}
+
+// permissionsCheckedMsg carries "kubectl auth can-i" answers for the actions
+// of a resource, keyed by permissionKey
+type permissionsCheckedMsg struct {
+	context string
+	results map[string]bool
+	err     error
+}
+
+// contextConfirmationNeededMsg is sent instead of running a command when the
+// current context is protected and has not been confirmed yet
+type contextConfirmationNeededMsg struct {
+	context string
 }
 
 // contextSwitchedMsg is sent after attempting to switch kube context
 type contextSwitchedMsg struct {
 	newContext string
+	missing    bool // The context was no longer in the kubeconfig
 	err        error
 }
 
+// namespaceChangedMsg is sent after attempting to create or delete a namespace
+type namespaceChangedMsg struct {
+	name    string
+	deleted bool
+	err     error
+}
+
+// contextsProbedMsg is sent when the reachability of kube contexts has been checked
+type contextsProbedMsg struct {
+	statuses map[string]kubectl.ContextStatus
+	err      error
+}
+
 // favouriteSavedMsg is sent when a favourite has been saved
 type favouriteSavedMsg struct {
 	err error
@@ -66,14 +174,144 @@ type savedOutputsLoadedMsg struct {
 	err   error
 }
 
-// secretKeysLoadedMsg is sent when secret keys have been fetched for selection
-type secretKeysLoadedMsg struct {
+// fieldKeysLoadedMsg is sent when the fields of a resource have been fetched for selection
+type fieldKeysLoadedMsg struct {
 	keys []string
 	err  error
 }
 
+// podDebugLoadedMsg carries the get and describe output for the pod debug view
+type podDebugLoadedMsg struct {
+	get         kubectl.CommandResult
+	getErr      error
+	describe    kubectl.CommandResult
+	describeErr error
+}
+
+// yamlForEditLoadedMsg carries a resource's YAML for the in-app editor
+type yamlForEditLoadedMsg struct {
+	yaml string
+	err  error
+}
+
+// podInfoLoadedMsg is sent when a pod's details have been fetched for the
+// quick actions menu
+type podInfoLoadedMsg struct {
+	name string
+	info kubectl.PodInfo
+	err  error
+}
+
+// deploymentPodsLoadedMsg is sent when the pods matching a deployment's
+// selector have been listed for Show Pods
+type deploymentPodsLoadedMsg struct {
+	deployment string
+	selector   string
+	pods       []string
+	err        error
+}
+
+// podContainersLoadedMsg is sent when the containers of the pod selected for
+// Logs have been fetched
+type podContainersLoadedMsg struct {
+	containers []string
+	err        error
+}
+
+// ownersLoadedMsg is sent when a pod's owner references have been followed.
+// chain holds the owners found before err, if any.
+type ownersLoadedMsg struct {
+	chain     []ownerLink
+	namespace string
+	err       error
+}
+
+// columnFieldsLoadedMsg is sent when the fields of a sample resource have
+// been fetched for the custom columns builder
+type columnFieldsLoadedMsg struct {
+	keys []string
+	err  error
+}
+
+// eventsStreamStartedMsg is sent once the events watch process is running
+type eventsStreamStartedMsg struct {
+	lines  <-chan string
+	errc   <-chan error
+	cancel context.CancelFunc
+}
+
+// eventLinesMsg carries lines read from the events watch
+type eventLinesMsg struct {
+	source <-chan string // Stream the lines came from, to drop lines of a stopped stream
+	lines  []string
+}
+
+// portForwardStartedMsg is sent once a saved port-forward's process is running
+type portForwardStartedMsg struct {
+	name   string
+	lines  <-chan string
+	errc   <-chan error
+	cancel context.CancelFunc
+}
+
+// portForwardEndedMsg is sent when a port-forward's process exits, or could
+// not be started. output is its last line of output.
+type portForwardEndedMsg struct {
+	name   string
+	done   chan struct{} // Identifies the run that ended; nil when it never started
+	output string
+	err    error
+}
+
+// eventsStreamEndedMsg is sent when the events watch process exits
+type eventsStreamEndedMsg struct {
+	lines <-chan string
+	err   error
+}
+
+// completionNamesLoadedMsg is sent when resource names for custom command
+// completion have been fetched
+type completionNamesLoadedMsg struct {
+	resource string
+	names    []string
+	err      error
+}
+
+// liveComparedMsg is sent when the command of a saved output has been re-run
+// and its output diffed against the saved one
+type liveComparedMsg struct {
+	command string
+	lines   []diff.Line
+	err     error
+}
+
+// pluginsLoadedMsg is sent when the installed kubectl plugins have been listed
+type pluginsLoadedMsg struct {
+	plugins []string
+	// warnings is kubectl's complaint about plugins it found but cannot
+	// run, e.g. ones that are not executable
+	warnings string
+	err      error
+}
+
+// commandVocabularyLoadedMsg is sent when the plugins and resource types a
+// favourite's command is checked against have been looked up
+type commandVocabularyLoadedMsg struct {
+	vocabulary commandVocabulary
+}
+
 // clusterInfoLoadedMsg is sent when cluster information has been fetched
 type clusterInfoLoadedMsg struct {
 	info *kubectl.ClusterInfo
 	err  error
 }
+
+// outputUploadedMsg is sent when an output has been uploaded to the paste
+// service. When the upload failed, an unsaved output is saved locally
+// instead, to savedAs or failing with saveErr.
+type outputUploadedMsg struct {
+	url     string
+	err     error
+	savedAs string
+	saveErr error
+}
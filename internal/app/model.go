@@ -1,13 +1,27 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/favourites"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/history"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/hotkeys"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/paste"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/portforwards"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/prefs"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 )
 
@@ -18,6 +32,16 @@ type Model struct {
 	favStore      *favourites.Store
 	hotkeyStore   *hotkeys.Store
 	historyStore  *history.Store
+	prefsStore    *prefs.Store
+
+	// Saved port-forwards, and those running in the background by name
+	portForwardStore *portforwards.Store
+	runningForwards  map[string]runningForward
+
+	// Paste service outputs are uploaded to; nil without paste_url. The
+	// output screen an upload is confirmed from is returned to afterwards
+	pasteClient        *paste.Client
+	uploadReturnScreen Screen
 
 	// Current screen and navigation state
 	currentScreen  Screen
@@ -25,14 +49,36 @@ type Model struct {
 
 	// User selections throughout the wizard
 	selectedResource              ResourceType
+	selectedResources             []ResourceType // Kinds ticked for a combined get, in the order they were ticked
 	selectedAction                Action
 	selectedResourceName          string
+	noResourceNames               bool // Resource name list only holds the "No <resource> found" placeholder
+	allNamespaces                 bool // List resource names across all namespaces as "namespace/name"
+	bulkSelected                  map[string]bool
 	selectedFlags                 []string // Selected command flags
+	waitCondition                 string   // Condition chosen for Wait, e.g. "Ready"
+	logsContainer                 string   // Container chosen for Logs, allContainersTitle, or "" for kubectl's default
+	followedDeployment            string   // Deployment whose pods Show Pods lists, as selected
+	followedSelector              string   // Its label selector, e.g. "app=web"
+	followedPods                  []string // Pods matching the selector, as "namespace/name" for a deployment listed that way
+	followedPod                   string   // Pod picked from followedPods to act on; its name list is skipped
+	customColumns                 []string // Field paths picked in the custom columns builder, in order
+	flagsList                     list.Model
 	customNamespace               string   // Custom namespace value
 	needsNamespaceInput           bool     // Whether namespace input is needed
+	implicitNamespaceCommand      string   // Command the default namespace was added to rather than chosen for
 	currentCommand                string
+	lastDryRunCommand             string // Last dry-run variant of currentCommand that was executed
+	dryRunOutput                  string // Result of lastDryRunCommand, kept apart from currentOutputContent
+	previewWarning                string // Warning shown on the preview screen for previewWarningCommand
+	previewWarningCommand         string
+	saveAfterRun                  bool // Save the output of the running command as soon as it finishes
 	renamingFavouriteIdx          int    // Index of favourite being renamed
+	favouriteGroup                string // Group open in the favourites list, "" for the group list
+	favouriteIndices              []int  // Store index of each favourite in the favourites list
+	favouriteWarned               bool   // The command being saved as a favourite was reported as possibly invalid
 	currentOutputContent          string // Current output content to be saved
+	currentOutputContext          string // Kube context the current output was produced against
 	selectedSavedOutput           string // Selected saved output filename
 	renamingSavedOutput           string // Saved output being renamed
 	renamingSavedOutputIsGroup    bool
@@ -42,6 +88,19 @@ type Model struct {
 	savedOutputsReturnScreen      Screen
 	savedOutputsReturnBase        string
 	savedOutputsReturnVersionIdx  int
+	deletingSavedOutputBase       string // Saved output group awaiting delete confirmation
+	deletingNamespace             string // Namespace awaiting delete confirmation
+	ownerChain                    []ownerLink // Pod and its owners shown on the owners screen
+	ownerNamespace                string
+	outputFilter                  string // Regular expression output lines must match; empty shows everything
+	outputFilterSummary           string // e.g. "(filtered: 3 of 40 lines)" for the last output
+	currentOutputSize             string // outputSize of the last output and error, measured before they are labelled
+	currentOutputView             string // currentOutputContent as shown in the viewport, e.g. with tables drawn
+	pinnedOutputs                 []pinnedOutput // Outputs pinned this session, oldest first
+	viewingPinnedOutput           int
+	liveComparison                string // Summary of the diff against live output shown instead of the saved output
+	liveComparisonDiff            string // The rendered diff shown with liveComparison
+	maxSavedVersions              int    // Versions kept per saved output; 0 disables pruning
 
 	hotkeyBindingPending   bool
 	hotkeyBindingFavourite favourites.Favourite
@@ -50,6 +109,30 @@ type Model struct {
 	list      list.Model
 	viewport  viewport.Model
 	textInput textinput.Model
+	spinner   spinner.Model
+
+	// In-app YAML editor, the YAML as fetched to tell whether it was edited,
+	// and whether leaving with unapplied edits was warned about
+	yamlEditor         textarea.Model
+	yamlEditorOriginal string
+	yamlEditorWarned   bool
+
+	// The edited YAML and the command it is piped to: "kubectl apply -f -",
+	// with -n added in strict namespace mode
+	commandStdin string
+	stdinCommand string
+
+	// Loading state shown with the spinner while kubectl calls are in flight
+	loading      bool
+	loadingLabel string
+
+	// When the running command started, for the live elapsed time, and how
+	// long the last one took; zero for interactive commands
+	commandStarted  time.Time
+	commandDuration time.Duration
+
+	// Whether the last command failed, offering to retry it from its output
+	lastRunFailed bool
 
 	// Terminal dimensions
 	width  int
@@ -58,20 +141,144 @@ type Model struct {
 	// Error state
 	err error
 
+	// Cancels the kubectl command that is currently running, if any
+	cancelCommand context.CancelFunc
+
 	// Default namespace applied to commands when no explicit namespace flag is chosen
 	defaultNamespace string
 
+	// Output format ticked for Get when no default flags are saved, e.g. "wide"
+	defaultGetOutput string
+
+	// Pin every command, including typed ones, to defaultNamespace
+	strictNamespace bool
+
+	// Contexts that must be confirmed by typing their name, those confirmed
+	// this session, and the one being confirmed; confirmingRun runs the
+	// current command afterwards, and confirmingAction runs a change such as
+	// deleting a namespace, instead of switching context
+	protectedContexts []*regexp.Regexp
+	confirmedContexts map[string]bool
+	confirmingContext string
+	confirmingRun     bool
+	confirmingAction  tea.Cmd
+
+	// Hide actions that change the cluster and refuse mutating commands
+	readOnly bool
+
+	// Run deletes, restarts and drains without the confirmation screen
+	skipConfirmations bool
+
+	// Config the model was built from and the file it was loaded from (empty
+	// for the default), edited and saved back by the Settings screen
+	config     config.Config
+	configPath string
+	// Setting being edited through the text input
+	editingSetting string
+
+	// Check actions with "kubectl auth can-i", caching the answers per context
+	// and namespace; permissionsContext is the context the menu was checked for
+	checkPermissions   bool
+	permissions        map[string]map[string]bool
+	permissionsContext string
+
+	// Main menu banner set when the current context's cluster did not respond;
+	// contextSwitchID counts context switches so that checks started before
+	// the latest one are ignored
+	clusterWarning  string
+	contextSwitchID int
+
+	// How often the cluster is rechecked while the main menu is shown (zero
+	// disables it); recheckID identifies the latest wait so older ones are
+	// ignored, and recheckPending is set while a wait or check is under way
+	connectivityRecheck time.Duration
+	recheckID           int
+	recheckPending      bool
+
+	// Reachability of kube contexts from the last on-demand health check
+	contextHealth map[string]kubectl.ContextStatus
+
+	// Namespaces shown in the namespaces list, kept so pinning can re-sort without refetching
+	namespaces []kubectl.NamespaceInfo
+
+	// Whether a name is being typed to jump to in the contexts or namespaces
+	// list, and the characters typed so far
+	jumping    bool
+	jumpPrefix string
+
+	// Installed kubectl plugins and the one being run
+	plugins        []string
+	selectedPlugin string
+	// Plugins and cluster types a favourite's command is checked against,
+	// looked up the first time the built-in lists find a problem
+	vocabulary *commandVocabulary
+
+	// Resource names fetched for custom command completion, by resource
+	// type, and the types whose names are being fetched
+	completionNames    map[string][]string
+	completionFetching map[string]bool
+
+	// Key bindings matched by handleKeyPress
+	keys keyMap
+
+	// Show absolute timestamps in the command history instead of "2m ago"
+	historyAbsoluteTime bool
+
+	// Command template being filled in, one {placeholder} at a time
+	templateCommand      string
+	templatePlaceholders []string
+	templateValues       map[string]string
+	templateReturnScreen Screen
+
+	// Command returned by Init, e.g. the name fetch of a --goto deep link
+	initCmd tea.Cmd
+
 	// Ready indicates if the TUI is initialized with terminal dimensions
 	ready bool
 	
 	// Theme controls the color scheme (dark or light)
 	theme Theme
+
+	// Path of the log file shown by the log viewer
+	logPath string
+
+	// When this session started; names the session script commands are appended to
+	sessionStarted time.Time
+
+	// Last loaded cluster info, kept so it can be reformatted on resize
+	clusterInfo *kubectl.ClusterInfo
+
+	// Node usage sampled while Cluster Info is shown; nodeUsagePollID
+	// identifies the current visit so samples from an earlier one are dropped
+	nodeUsage       map[string]nodeUsageHistory
+	nodeUsagePollID int
+	// Sampling is paused with Space; the trend so far is kept
+	nodeUsagePaused bool
+
+	// Last output of each get by context and command, to report what changed
+	// when it runs again; lastGetOrder lists the keys, least recently run first
+	lastGetOutputs map[string]string
+	lastGetOrder   []string
+
+	// Live events watch: received lines, the stream being read and how to stop it
+	eventLines   []string
+	eventsSource <-chan string
+	eventsErrc   <-chan error
+	stopEvents   context.CancelFunc
+
+	// While the events watch is paused with Space, lines are still read but
+	// held back from the screen
+	eventsPaused   bool
+	heldEventLines []string
 }
 
 // NewModel creates and initializes a new application model.
-func NewModel() Model {
+func NewModel(cfg config.Config) Model {
 	// Initialize kubectl client
 	kubectlClient := kubectl.NewClient()
+	kubectlClient.Retries = cfg.Retries
+	kubectlClient.AllowedEnv = cfg.ExpandEnv
+	kubeconfigErr := kubectlClient.SetKubeconfig(cfg.Kubeconfig)
 
 	// Initialize favourites store
 	favStore, err := favourites.NewStore()
@@ -80,6 +287,9 @@ func NewModel() Model {
 		// The error will be shown in the UI
 		favStore = nil
 	}
+	if err == nil && kubeconfigErr != nil {
+		err = kubeconfigErr
+	}
 
 	// Initialize hotkey store
 	hotkeyStore, hotkeyErr := hotkeys.NewStore()
@@ -91,7 +301,7 @@ func NewModel() Model {
 	}
 
 	// Initialize history store
-	historyStore, historyErr := history.NewStore()
+	historyStore, historyErr := history.NewStore(cfg.HistorySize)
 	if historyErr != nil {
 		historyStore = nil
 		if err == nil {
@@ -99,38 +309,107 @@ func NewModel() Model {
 		}
 	}
 
-	// Create initial list for main menu
-	mainMenuItems := []list.Item{
-		ui.NewSimpleItem("Run Command", "Execute kubectl commands"),
-		ui.NewSimpleItem("Favourites", "View and run saved commands"),
-		ui.NewSimpleItem("Command History", "View and re-run previous commands"),
-		ui.NewSimpleItem("Saved Outputs", "View previously saved outputs"),
-		ui.NewSimpleItem("Hotkeys", "Manage hotkey bindings"),
-		ui.NewSimpleItem("Check Cluster Connectivity", "Verify connection to Kubernetes cluster"),
-		ui.NewSimpleItem("Exit", "Quit the application"),
+	// Initialize prefs store
+	prefsStore, prefsErr := prefs.NewStore()
+	if prefsErr != nil {
+		prefsStore = nil
+		if err == nil {
+			err = prefsErr
+		}
 	}
 
-	initialList := ui.NewList(mainMenuItems, "Kubernetes Wizard", 0, 0)
+	// Entries left out of the stores as invalid are logged, and the first is
+	// shown in the UI
+	var skipped []string
+	if favStore != nil {
+		skipped = append(skipped, favStore.Skipped()...)
+	}
+	if hotkeyStore != nil {
+		skipped = append(skipped, hotkeyStore.Skipped()...)
+	}
+	if historyStore != nil {
+		skipped = append(skipped, historyStore.Skipped()...)
+	}
+	for _, s := range skipped {
+		logger.Error("Skipped invalid entry in %s", s)
+	}
+	if len(skipped) > 0 && err == nil {
+		err = fmt.Errorf("skipped %d invalid entries, first in %s", len(skipped), skipped[0])
+	}
+
+	// Initialize port-forwards store
+	portForwardStore, portForwardErr := portforwards.NewStore()
+	if portForwardErr != nil {
+		portForwardStore = nil
+		if err == nil {
+			err = portForwardErr
+		}
+	}
+
+	// Key bindings, with overrides from the config file
+	keys, keysErr := newKeyMap(cfg.Keys)
+	if keysErr != nil && err == nil {
+		err = keysErr
+	}
+
+	protected, protectedErr := compileProtectedContexts(cfg.ProtectedContexts)
+	if protectedErr != nil && err == nil {
+		err = protectedErr
+	}
+
+	var pasteClient *paste.Client
+	if cfg.PasteURL != "" {
+		pasteClient = paste.NewClient(cfg.PasteURL)
+	}
 
 	// Create text input for naming favourites
 	ti := textinput.New()
 	ti.Placeholder = "Enter favourite name"
 	ti.CharLimit = 50
 
-	return Model{
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+
+	m := Model{
 		kubectlClient: kubectlClient,
 		favStore:      favStore,
 		hotkeyStore:   hotkeyStore,
 		historyStore:  historyStore,
+		prefsStore:    prefsStore,
+
+		portForwardStore: portForwardStore,
+		pasteClient:      pasteClient,
 		currentScreen: MainMenuScreen,
-		list:          initialList,
 		textInput:     ti,
+		spinner:       sp,
 		viewport:      ui.NewViewport(0, 0),
 		err:           err,
-		theme:         ThemeDark, // Default to dark theme
+		theme:         themeFromConfig(cfg.Theme),
+		keys:          keys,
+
+		maxSavedVersions: cfg.MaxSavedVersions,
+		defaultNamespace: strings.TrimSpace(cfg.DefaultNamespace),
+		defaultGetOutput: cfg.DefaultGetOutput,
+		strictNamespace:  cfg.StrictNamespace,
+		checkPermissions: cfg.CheckPermissions,
+		readOnly:         cfg.ReadOnly,
+
+		skipConfirmations: cfg.SkipConfirmations,
+		config:            cfg,
+
+		connectivityRecheck: time.Duration(cfg.ConnectivityRecheckSeconds) * time.Second,
+
+		protectedContexts: protected,
+		sessionStarted:   time.Now(),
 	}
+	// Same menu as navigateToMainMenu; it is sized on the first WindowSizeMsg
+	m.list = ui.NewList(m.mainMenuItems(), "Kubernetes Wizard", 0, 0)
+	return m
 }
-// GetKubectlClient returns the internal kubectl client.
+
+// GetKubectlClient returns the kubectl client the model runs commands with,
+// configured from the config file, so callers such as main's preflight
+// checks use the same kubeconfig and settings.
 func (m Model) GetKubectlClient() *kubectl.Client {
 	return m.kubectlClient
 }
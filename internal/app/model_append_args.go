@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navigateToAppendArgs prompts for extra arguments to add to the previewed
+// command, for flags the wizard does not offer.
+func (m Model) navigateToAppendArgs() Model {
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = "Extra args (e.g. --sort-by=.metadata.name)"
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = AppendArgsScreen
+	return m
+}
+
+// handleAppendArgsInput appends the typed args to the current command and
+// returns to its preview.
+func (m Model) handleAppendArgsInput() (tea.Model, tea.Cmd) {
+	input := strings.TrimSpace(m.textInput.Value())
+	if input == "" {
+		return m, nil
+	}
+	if err := validateAppendedArgs(input); err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	m.err = nil
+	m.textInput.Blur()
+	m.currentCommand = strings.TrimSpace(m.currentCommand) + " " + input
+	return m.navigateToCommandPreview(), nil
+}
+
+// validateAppendedArgs rejects shell metacharacters and args that cannot be
+// tokenized, such as an unclosed quote. Unlike SanitizeInput it reports the
+// character instead of dropping it, so the command is never changed silently.
+// "$" is let through: commands run without a shell and only expand the $NAME
+// or ${NAME} of variables listed in expand_env, while others, such as a
+// go-template's $c, reach kubectl as typed.
+func validateAppendedArgs(input string) error {
+	for _, char := range shellMetacharacters {
+		if char != "$" && strings.Contains(input, char) {
+			return fmt.Errorf("args cannot contain %q", char)
+		}
+	}
+	if _, err := kubectl.SplitArgs(input); err != nil {
+		return fmt.Errorf("invalid args: %v", err)
+	}
+	return nil
+}
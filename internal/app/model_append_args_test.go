@@ -0,0 +1,57 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that Append Args adds the typed args to the previewed command and
+// refuses shell metacharacters and unbalanced quotes.
+func TestAppendArgs(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		currentCommand: "kubectl get pods"}
+	m = m.navigateToCommandPreview()
+	for i, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "Append Args" {
+			m.list.Select(i)
+		}
+	}
+
+	updated, _ := m.handleCommandPreviewSelection()
+	if m = updated.(Model); m.currentScreen != AppendArgsScreen {
+		t.Fatalf("expected the args prompt, got %s", m.currentScreen)
+	}
+
+	for _, bad := range []string{"--selector=app; rm -rf /", "-l 'app=web"} {
+		m.textInput.SetValue(bad)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if m = updated.(Model); m.currentScreen != AppendArgsScreen || m.err == nil {
+			t.Fatalf("expected %q to be refused, got %s (err %v)", bad, m.currentScreen, m.err)
+		}
+	}
+	if !strings.Contains(m.err.Error(), "invalid args") {
+		t.Errorf("expected the tokenizer error, got %v", m.err)
+	}
+
+	m.textInput.SetValue("--sort-by=.metadata.name -l 'app=web'")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != CommandPreviewScreen || m.err != nil {
+		t.Fatalf("expected the preview, got %s (err %v)", m.currentScreen, m.err)
+	}
+	if want := "kubectl get pods --sort-by=.metadata.name -l 'app=web'"; m.currentCommand != want {
+		t.Errorf("got %q, want %q", m.currentCommand, want)
+	}
+
+	// Variables are expanded by the wizard rather than a shell, so $ is allowed
+	m = m.navigateToAppendArgs()
+	m.textInput.SetValue("--context ${CONTEXT} -n $NS")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = updated.(Model); m.currentScreen != CommandPreviewScreen || m.err != nil {
+		t.Errorf("expected variables to be accepted, got %s (err %v)", m.currentScreen, m.err)
+	}
+}
@@ -1,13 +1,21 @@
 package app
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"runtime"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/history"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
 )
 
 // Command execution and kubectl helpers.
@@ -26,18 +34,202 @@ func (m Model) loadCommandHelp() tea.Cmd {
 	}
 }
 
+// dryRunVerbs lists the kubectl verbs that accept --dry-run=server.
+var dryRunVerbs = map[string]bool{
+	"annotate":  true,
+	"apply":     true,
+	"autoscale": true,
+	"cordon":    true,
+	"create":    true,
+	"delete":    true,
+	"drain":     true,
+	"expose":    true,
+	"label":     true,
+	"patch":     true,
+	"replace":   true,
+	"run":       true,
+	"scale":     true,
+	"set":       true,
+	"taint":     true,
+	"uncordon":  true,
+}
+
+// dryRunYAMLVerbs lists the dry-run verbs whose result is best shown as the
+// object kubectl would send to the API server.
+var dryRunYAMLVerbs = map[string]bool{
+	"annotate": true,
+	"apply":    true,
+	"create":   true,
+	"expose":   true,
+	"label":    true,
+	"patch":    true,
+	"replace":  true,
+	"run":      true,
+	"set":      true,
+}
+
+// leadingValueFlags are the global kubectl flags that may come before the
+// verb and take the next argument as their value unless written as
+// --flag=value.
+var leadingValueFlags = map[string]bool{
+	"-n": true, "--namespace": true,
+	"--context": true, "--cluster": true, "--user": true,
+	"--kubeconfig": true, "--cache-dir": true,
+	"-s": true, "--server": true, "--token": true,
+	"--as": true, "--as-group": true, "--as-uid": true,
+	"--certificate-authority": true, "--client-certificate": true, "--client-key": true,
+	"--tls-server-name": true, "--request-timeout": true,
+	"-l": true, "--selector": true,
+	"-v": true, "--v": true,
+}
+
+// commandArgs returns the arguments of a command string from its verb on,
+// leaving out "kubectl" and any flags given before the verb, e.g.
+// ["delete", "pod", "web"] for "kubectl -n prod delete pod web".
+func commandArgs(cmd string) []string {
+	fields, err := kubectl.SplitArgs(strings.TrimSpace(cmd))
+	if err != nil {
+		fields = strings.Fields(cmd)
+	}
+	if len(fields) > 0 && fields[0] == "kubectl" {
+		fields = fields[1:]
+	}
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "-") {
+		if leadingValueFlags[fields[0]] && len(fields) > 1 {
+			fields = fields[1:]
+		}
+		fields = fields[1:]
+	}
+	return fields
+}
+
+// commandVerb returns the kubectl verb of a command string, e.g. "get" for
+// "kubectl get pods" or "kubectl -n prod get pods". It returns an empty
+// string for an empty command.
+func commandVerb(cmd string) string {
+	args := commandArgs(cmd)
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// commandContextFlag returns the context a command names with --context, or
+// "" when it runs in the current context. Arguments after "--" are not
+// kubectl's and are ignored.
+func commandContextFlag(cmd string) string {
+	fields, err := kubectl.SplitArgs(strings.TrimSpace(cmd))
+	if err != nil {
+		fields = strings.Fields(cmd)
+	}
+	for i, field := range fields {
+		if field == "--" {
+			break
+		}
+		if field == "--context" && i+1 < len(fields) {
+			return fields[i+1]
+		}
+		if value, ok := strings.CutPrefix(field, "--context="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// commandRunContext returns the context cmd runs in: the one it names with
+// --context, or else the current context. It is "" when neither is known.
+func (m Model) commandRunContext(cmd string) string {
+	if name := commandContextFlag(cmd); name != "" {
+		return name
+	}
+	current, _ := m.kubectlClient.GetCurrentContext()
+	return current
+}
+
+// dryRunCommand returns the server-side dry-run variant of cmd. The second
+// return value is false for read-only or otherwise unsupported verbs.
+func dryRunCommand(cmd string) (string, bool) {
+	cmd = strings.TrimSpace(cmd)
+	verb := commandVerb(cmd)
+	if !dryRunVerbs[verb] {
+		return "", false
+	}
+	if strings.Contains(cmd, "--dry-run") {
+		return cmd, true
+	}
+
+	cmd += " --dry-run=server"
+	if dryRunYAMLVerbs[verb] && !strings.Contains(cmd, " -o ") && !strings.Contains(cmd, " -o=") && !strings.Contains(cmd, "--output") {
+		cmd += " -o yaml"
+	}
+	return cmd, true
+}
+
+func (m Model) loadDryRun() tea.Cmd {
+	return func() tea.Msg {
+		dryRunCmd, ok := dryRunCommand(m.currentCommand)
+		if !ok {
+			return dryRunLoadedMsg{err: fmt.Errorf("dry run is not supported for '%s'", commandVerb(m.currentCommand))}
+		}
+		result, err := m.kubectlClient.ExecuteRaw(dryRunCmd)
+		return dryRunLoadedMsg{command: dryRunCmd, result: result, err: err}
+	}
+}
+
 func (m Model) checkClusterConnectivity() tea.Cmd {
 	return func() tea.Msg {
 		result, err := m.kubectlClient.ExecuteRaw("kubectl cluster-info")
-		return clusterConnectivityCheckedMsg{result: result, err: err}
+		if err != nil || result.Error != "" {
+			return clusterConnectivityCheckedMsg{result: result, err: err}
+		}
+		// The summary is best effort; connectivity is still reported without it
+		summary, _ := m.kubectlClient.GetClusterSummary()
+		return clusterConnectivityCheckedMsg{result: result, summary: summary, err: err}
 	}
 }
 
-func (m Model) loadClusterInfo() tea.Cmd {
+// checkCurrentContext probes the current context's API server in the
+// background, so an unreachable cluster is flagged on the main menu before
+// any command is run.
+func (m Model) checkCurrentContext() tea.Cmd {
+	id := m.contextSwitchID
 	return func() tea.Msg {
+		name, err := m.kubectlClient.GetCurrentContext()
+		if err != nil {
+			return currentContextCheckedMsg{err: err, switchID: id}
+		}
+		return currentContextCheckedMsg{context: name, status: m.kubectlClient.ProbeContext(name), switchID: id}
+	}
+}
+
+// clusterWarningFor returns the main menu banner for a context check, or ""
+// when the cluster responded.
+func clusterWarningFor(msg currentContextCheckedMsg) string {
+	switch {
+	case msg.err != nil:
+		return fmt.Sprintf("⚠️  %v", msg.err)
+	case msg.status == kubectl.ContextTimeout:
+		return fmt.Sprintf("⚠️  Cluster for context %s is not responding (timed out); commands are likely to fail", msg.context)
+	case msg.status == kubectl.ContextUnreachable:
+		return fmt.Sprintf("⚠️  Cluster for context %s is unreachable; commands are likely to fail", msg.context)
+	}
+	return ""
+}
+
+// withSpinner shows the loading spinner with label until cmd's result arrives.
+// The result handler is responsible for clearing m.loading.
+func withSpinner(label string, cmd tea.Cmd) tea.Cmd {
+	started := func() tea.Msg {
+		return loadingStartedMsg{label: label}
+	}
+	return tea.Sequence(started, cmd)
+}
+
+func (m Model) loadClusterInfo() tea.Cmd {
+	return withSpinner("Loading cluster info…", func() tea.Msg {
 		info, err := m.kubectlClient.GetClusterInfo()
 		return clusterInfoLoadedMsg{info: info, err: err}
-	}
+	})
 }
 
 func (m Model) fetchPodNames() tea.Cmd {
@@ -48,51 +240,72 @@ func (m Model) fetchPodNames() tea.Cmd {
 }
 
 func (m Model) fetchResourceNames() tea.Cmd {
-	return func() tea.Msg {
+	if m.followedPod != "" && m.selectedResource == ResourcePods {
+		// The pod was already picked from its deployment's pods
+		return func() tea.Msg {
+			return resourceNamesLoadedMsg{names: []string{m.followedPod}}
+		}
+	}
+	label := fmt.Sprintf("Fetching %s…", strings.ToLower(m.selectedResource.String()))
+	return withSpinner(label, func() tea.Msg {
 		var (
 			names []string
 			err   error
 		)
 
-		switch m.selectedResource {
-		case ResourcePods:
+		switch {
+		case m.listsAllNamespaces():
+			names, err = m.kubectlClient.ListNamesAllNamespaces(getResourceShortName(m.selectedResource))
+		case m.selectedResource == ResourcePods:
 			names, err = m.kubectlClient.ListPodNames()
-		case ResourceDeployments:
+		case m.selectedResource == ResourceDeployments:
 			names, err = m.kubectlClient.ListDeploymentNames()
-		case ResourceServices:
+		case m.selectedResource == ResourceServices:
 			names, err = m.kubectlClient.ListServiceNames()
-		case ResourceNodes:
+		case m.selectedResource == ResourceNodes:
 			names, err = m.kubectlClient.ListNodeNames()
-		case ResourceConfigMaps:
+		case m.selectedResource == ResourceConfigMaps:
 			names, err = m.kubectlClient.ListConfigMapNames()
-		case ResourceSecrets:
+		case m.selectedResource == ResourceSecrets:
 			names, err = m.kubectlClient.ListSecretNames()
-		case ResourceIngress:
+		case m.selectedResource == ResourceIngress:
 			names, err = m.kubectlClient.ListIngressNames()
 		default:
 			err = fmt.Errorf("unsupported resource type: %s", m.selectedResource.String())
 		}
 
-		return resourceNamesLoadedMsg{names: names, err: err}
-	}
+		// Resolve the namespace only for the empty-list placeholder
+		namespace := ""
+		if err == nil && len(names) == 0 && m.selectedResource != ResourceNodes && !m.allNamespaces {
+			namespace, _ = m.kubectlClient.GetCurrentNamespace()
+		}
+
+		return resourceNamesLoadedMsg{names: names, namespace: namespace, err: err}
+	})
 }
 
-func (m Model) fetchSecretKeys() tea.Cmd {
+func (m Model) fetchFieldKeys() tea.Cmd {
 	return func() tea.Msg {
-		// Get the secret as JSON to extract keys
-		cmd := fmt.Sprintf("kubectl get secret %s -o json", m.selectedResourceName)
-		if m.customNamespace != "" {
-			cmd += " -n " + m.customNamespace
-		} else if m.defaultNamespace != "" && !m.hasExplicitNamespaceFlag() {
-			cmd += " -n " + m.defaultNamespace
-		}
+		// Get the resource as JSON to extract keys
+		namespace, name := splitNamespacedName(m.selectedResourceName)
+		kind := getResourceShortName(m.selectedResource)
+		cmd := fmt.Sprintf("kubectl get %s %s -o json", kind, name) + m.fieldNamespaceFlag(namespace)
 
 		result, err := m.kubectlClient.ExecuteRaw(cmd)
 		if err != nil {
-			return secretKeysLoadedMsg{err: err}
+			return fieldKeysLoadedMsg{err: err}
 		}
 		if result.Error != "" {
-			return secretKeysLoadedMsg{err: fmt.Errorf(result.Error)}
+			return fieldKeysLoadedMsg{err: fmt.Errorf(result.Error)}
+		}
+
+		// Anything but a secret is offered as plain JSONPath leaf fields
+		if m.selectedResource != ResourceSecrets {
+			var obj interface{}
+			if err := json.Unmarshal([]byte(result.Output), &obj); err != nil {
+				return fieldKeysLoadedMsg{err: fmt.Errorf("failed to parse %s JSON: %v", kind, err)}
+			}
+			return fieldKeysLoadedMsg{keys: flattenFieldPaths(obj)}
 		}
 
 		var secretData struct {
@@ -107,7 +320,7 @@ func (m Model) fetchSecretKeys() tea.Cmd {
 			Type       string                 `json:"type"`
 		}
 		if err := json.Unmarshal([]byte(result.Output), &secretData); err != nil {
-			return secretKeysLoadedMsg{err: fmt.Errorf("failed to parse secret JSON: %v", err)}
+			return fieldKeysLoadedMsg{err: fmt.Errorf("failed to parse secret JSON: %v", err)}
 		}
 
 		// Collect all available fields
@@ -136,32 +349,172 @@ func (m Model) fetchSecretKeys() tea.Cmd {
 			keys = append(keys, fmt.Sprintf("stringData.%s", k))
 		}
 
-		return secretKeysLoadedMsg{keys: keys}
+		return fieldKeysLoadedMsg{keys: keys}
+	}
+}
+
+// fieldNamespaceFlag returns the " -n <namespace>" suffix for field
+// extraction commands: the namespace of an all-namespaces entry, the custom
+// namespace or the default one, in that order. Nodes get none.
+func (m Model) fieldNamespaceFlag(namespace string) string {
+	switch {
+	case m.selectedResource == ResourceNodes:
+		return ""
+	case namespace != "":
+		return " -n " + namespace
+	case m.customNamespace != "":
+		return " -n " + m.customNamespace
+	case m.defaultNamespace != "" && !m.hasExplicitNamespaceFlag():
+		return " -n " + m.defaultNamespace
 	}
+	return ""
 }
 
 func (m Model) executeCommand() tea.Cmd {
+	stdin := m.fromYAMLEditor()
+	// Strict namespace mode holds however the command came about: built by
+	// the wizard, typed, or run from favourites, hotkeys or history
+	if m.strictNamespace {
+		m.currentCommand, _ = strictNamespaceCommand(m.currentCommand, m.defaultNamespace)
+	}
+	// The edited YAML goes with the command however it was rewritten
+	if stdin {
+		m.stdinCommand = m.currentCommand
+	}
+	// The checks see the command as it runs, with allowed variables such as
+	// --context=${CTX} expanded; the command itself is expanded by the client
+	expanded := m.kubectlClient.ExpandEnvCommand(m.currentCommand)
+	if name := m.protectedCommandContext(expanded); name != "" {
+		return func() tea.Msg {
+			return contextConfirmationNeededMsg{context: name}
+		}
+	}
+	if m.readOnly && isMutatingCommand(expanded) {
+		err := readOnlyError(m.currentCommand)
+		logger.Info("Refused in read-only mode: %s", strings.Join(kubectl.RedactArgs(strings.Fields(m.currentCommand)), " "))
+		return func() tea.Msg {
+			return commandExecutedMsg{result: kubectl.CommandResult{Command: m.currentCommand, Error: err.Error()}, err: err}
+		}
+	}
 	if isInteractiveCommand(m.currentCommand) {
 		// For interactive commands, we use tea.ExecProcess
-		args := strings.Fields(strings.TrimPrefix(m.currentCommand, "kubectl "))
-		c := exec.Command("kubectl", args...)
+		args, err := kubectl.SplitArgs(strings.TrimPrefix(m.currentCommand, "kubectl "))
+		if err != nil {
+			return func() tea.Msg {
+				return commandExecutedMsg{result: kubectl.CommandResult{Command: m.currentCommand, Error: err.Error()}, err: err}
+			}
+		}
+		c := exec.Command("kubectl", m.kubectlClient.BuildArgs(m.kubectlClient.ExpandEnvArgs(args)...)...)
+		if len(args) > 0 && args[0] == "edit" {
+			env, err := editorEnv(os.Environ(), exec.LookPath)
+			if err != nil {
+				return func() tea.Msg {
+					return commandExecutedMsg{result: kubectl.CommandResult{Command: m.currentCommand, Error: err.Error()}, err: err}
+				}
+			}
+			c.Env = env
+		}
+		// kubectl's errors are kept to be shown once the wizard is back
+		var stderr bytes.Buffer
+		c.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+		logStr := "kubectl " + strings.Join(kubectl.RedactArgs(args), " ")
+		logger.Info("Executing interactive command: %s", logStr)
+		start := time.Now()
 		return tea.ExecProcess(c, func(err error) tea.Msg {
+			logger.Info("Interactive command finished: %s (duration: %v, error: %t)", logStr, time.Since(start).Round(time.Millisecond), err != nil)
+			kubeContext := m.commandRunContext(expanded)
 			if err != nil {
-				return commandExecutedMsg{err: err}
+				logger.Error("Interactive command failed: %s, error: %v", logStr, err)
+				result := kubectl.CommandResult{Command: m.currentCommand, Error: strings.TrimSpace(stderr.String())}
+				if result.Error == "" {
+					result.Error = err.Error()
+				}
+				return commandExecutedMsg{result: result, context: kubeContext, err: err}
 			}
-			return commandExecutedMsg{result: kubectl.CommandResult{Output: "Interactive command completed"}}
+			return commandExecutedMsg{result: kubectl.CommandResult{Output: "Interactive command completed"}, context: kubeContext}
 		})
 	}
 
-	return func() tea.Msg {
-		// Add to history
-		if m.historyStore != nil && strings.TrimSpace(m.currentCommand) != "" {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Hand the cancel func to Update first so ctrl+x can stop the command
+	started := func() tea.Msg {
+		return commandStartedMsg{cancel: cancel, command: m.currentCommand}
+	}
+	run := func() tea.Msg {
+		defer cancel()
+		// Add to history; piped input is not kept, so such commands are left out
+		if m.historyStore != nil && strings.TrimSpace(m.currentCommand) != "" && !m.fromYAMLEditor() {
 			_ = m.historyStore.Add(m.currentCommand)
 		}
-		// Use the ExecuteRaw method which validates cluster context and runs the command
-		result, err := m.kubectlClient.ExecuteRaw(m.currentCommand)
-		return commandExecutedMsg{result: result, err: err}
+		// Capture the context up front so the output shows where the command ran
+		kubeContext := m.commandRunContext(expanded)
+		// Use the ExecuteRawContext method which validates cluster context and runs the command
+		var result kubectl.CommandResult
+		var err error
+		if m.fromYAMLEditor() {
+			result, err = m.kubectlClient.ExecuteRawWithStdin(ctx, m.currentCommand, m.commandStdin)
+		} else {
+			result, err = m.kubectlClient.ExecuteRawContext(ctx, m.currentCommand)
+		}
+		return commandExecutedMsg{result: result, context: kubeContext, err: err}
 	}
+	return tea.Sequence(started, run)
+}
+
+// rerunLastCommand runs the newest history entry again from any screen but
+// text inputs.
+// Commands that change the cluster are opened in the preview instead, so
+// they are only run again after confirming there.
+func (m Model) rerunLastCommand() (tea.Model, tea.Cmd) {
+	if m.loading || m.cancelCommand != nil {
+		m.err = fmt.Errorf("A command is still running")
+		return m, nil
+	}
+	var entry history.Entry
+	ok := false
+	if m.historyStore != nil {
+		entry, ok = m.historyStore.Get(0)
+	}
+	if !ok {
+		m.err = fmt.Errorf("No command in history to re-run")
+		return m, nil
+	}
+
+	m.textInput.Blur()
+	m.currentCommand = entry.Command
+	if isMutatingCommand(entry.Command) || isInteractiveCommand(entry.Command) {
+		m = m.navigateToCommandPreview()
+		m.err = fmt.Errorf("Last command can change the cluster: select Execute to run it again")
+		return m, nil
+	}
+	return m.runCommand()
+}
+
+// fallbackEditors are tried, in order, when neither $KUBE_EDITOR nor $EDITOR
+// is set.
+var fallbackEditors = []string{"nano", "vim", "vi"}
+
+// editorEnv returns the environment for "kubectl edit". kubectl falls back to
+// vi without telling anyone when no editor is configured, so an installed
+// editor is picked explicitly, or an error explains how to set one.
+func editorEnv(environ []string, lookPath func(string) (string, error)) ([]string, error) {
+	for _, kv := range environ {
+		if (strings.HasPrefix(kv, "KUBE_EDITOR=") || strings.HasPrefix(kv, "EDITOR=")) && !strings.HasSuffix(kv, "=") {
+			return environ, nil
+		}
+	}
+	// kubectl uses notepad on Windows, which is always there
+	if runtime.GOOS == "windows" {
+		return environ, nil
+	}
+
+	for _, editor := range fallbackEditors {
+		if _, err := lookPath(editor); err == nil {
+			return append(environ, "KUBE_EDITOR="+editor), nil
+		}
+	}
+	return nil, fmt.Errorf("no editor found for kubectl edit: set $EDITOR, e.g. export EDITOR=nano, and restart the wizard")
 }
 
 func isInteractiveCommand(cmd string) bool {
@@ -177,3 +530,62 @@ func isInteractiveCommand(cmd string) bool {
 	}
 	return false
 }
+
+// metricsUnavailableMessage replaces kubectl's error when "kubectl top"
+// finds no metrics API.
+const metricsUnavailableMessage = `Metrics are not available: kubectl top needs metrics-server running in the cluster.
+Install it with:
+  kubectl apply -f https://github.com/kubernetes-sigs/metrics-server/releases/latest/download/components.yaml
+If it was just installed, wait a minute for the first metrics to be collected.`
+
+// explainTopError rewrites the error of a "kubectl top" command that failed
+// because metrics-server is missing or not ready. Other errors are returned
+// unchanged.
+func explainTopError(cmd, stderr string) string {
+	if commandVerb(cmd) != "top" {
+		return stderr
+	}
+	lower := strings.ToLower(stderr)
+	if strings.Contains(lower, "metrics api not available") || strings.Contains(lower, "metrics not available") ||
+		strings.Contains(lower, "metrics.k8s.io") {
+		return metricsUnavailableMessage + "\n\nkubectl reported: " + strings.TrimSpace(stderr)
+	}
+	return stderr
+}
+
+// checkPreviousLogs looks up the restart count of the selected pod when
+// "logs --previous" is being built, since kubectl fails with a cryptic error
+// when there is no previous container to read logs from.
+func (m Model) checkPreviousLogs() tea.Cmd {
+	if m.selectedAction != ActionLogs || m.selectedResource != ResourcePods || m.selectedResourceName == "" {
+		return nil
+	}
+	hasPrevious := false
+	namespace := ""
+	for _, f := range m.selectedFlags {
+		if f == "--previous" {
+			hasPrevious = true
+		}
+		if strings.HasPrefix(f, "-n ") {
+			namespace = strings.TrimSpace(strings.TrimPrefix(f, "-n "))
+		}
+	}
+	if !hasPrevious {
+		return nil
+	}
+
+	command := m.currentCommand
+	podNamespace, podName := splitNamespacedName(m.selectedResourceName)
+	if podNamespace != "" {
+		namespace = podNamespace
+	}
+	// Only the chosen container's restarts leave it a previous log
+	container := m.logsContainer
+	if container == allContainersTitle {
+		container = ""
+	}
+	return func() tea.Msg {
+		restarts, err := m.kubectlClient.GetPodRestartCount(podName, namespace, container)
+		return podRestartsCheckedMsg{command: command, container: container, restarts: restarts, err: err}
+	}
+}
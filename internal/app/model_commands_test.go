@@ -0,0 +1,181 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/history"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditorEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("kubectl falls back to notepad on Windows")
+	}
+	onlyVim := func(name string) (string, error) {
+		if name == "vim" {
+			return "/usr/bin/vim", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	env, err := editorEnv([]string{"HOME=/root", "EDITOR=code -w"}, onlyVim)
+	if err != nil || !reflect.DeepEqual(env, []string{"HOME=/root", "EDITOR=code -w"}) {
+		t.Errorf("expected a configured editor to be kept, got %v, %v", env, err)
+	}
+
+	env, err = editorEnv([]string{"HOME=/root", "EDITOR="}, onlyVim)
+	if err != nil || env[len(env)-1] != "KUBE_EDITOR=vim" {
+		t.Errorf("expected the installed vim to be picked, got %v, %v", env, err)
+	}
+
+	if _, err := editorEnv([]string{"HOME=/root"}, func(string) (string, error) { return "", errors.New("not found") }); err == nil {
+		t.Errorf("expected an error when no editor is installed")
+	}
+}
+
+// Test that Edit goes from the name list straight to the preview instead of
+// an empty flags list.
+func TestEditSkipsFlagsSelection(t *testing.T) {
+	m := Model{selectedResource: ResourceDeployments, selectedAction: ActionEdit, defaultNamespace: "team", width: 80, height: 40}
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"web"}})
+	m = updated.(Model)
+
+	updated, _ = m.handleResourceNameSelection()
+	m = updated.(Model)
+	if m.currentScreen != CommandPreviewScreen || m.currentCommand != "kubectl edit deployment web -n team" {
+		t.Errorf("expected a preview of the edit, got %q on %s", m.currentCommand, m.currentScreen)
+	}
+}
+
+func TestExplainTopError(t *testing.T) {
+	for _, stderr := range []string{
+		"error: Metrics API not available",
+		"error: metrics not available yet",
+		`Error from server (NotFound): the server could not find the requested resource (get pods.metrics.k8s.io)`,
+	} {
+		got := explainTopError("kubectl top pod -n shop", stderr)
+		if !strings.HasPrefix(got, metricsUnavailableMessage) || !strings.Contains(got, stderr) {
+			t.Errorf("explainTopError(%q) = %q", stderr, got)
+		}
+	}
+
+	if got := explainTopError("kubectl top node", "error: You must be logged in to the server"); got != "error: You must be logged in to the server" {
+		t.Errorf("expected unrelated errors to be unchanged, got %q", got)
+	}
+	if got := explainTopError("kubectl get apiservices", "v1beta1.metrics.k8s.io not found"); got != "v1beta1.metrics.k8s.io not found" {
+		t.Errorf("expected errors of other commands to be unchanged, got %q", got)
+	}
+}
+
+// Test that ctrl+r re-runs the newest history entry, but only previews
+// commands that change the cluster.
+func TestRerunLastCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	store, err := history.NewStore(0)
+	if err != nil {
+		t.Fatalf("history.NewStore() error = %v", err)
+	}
+	// History is written with its timestamps rather than added, so the
+	// newest entry does not depend on the clock
+	writeHistory := func(entries string) {
+		if err := os.WriteFile(filepath.Join(home, "kube-wizard-history.json"), []byte(entries), 0644); err != nil {
+			t.Fatalf("failed to write history: %v", err)
+		}
+		if err := store.Load(); err != nil {
+			t.Fatalf("store.Load() error = %v", err)
+		}
+	}
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, historyStore: store, currentScreen: NamespacesListScreen}
+	ctrlR := tea.KeyMsg{Type: tea.KeyCtrlR}
+
+	updated, cmd := m.Update(ctrlR)
+	if m = updated.(Model); cmd != nil || m.err == nil || !strings.Contains(m.err.Error(), "No command") {
+		t.Fatalf("expected an empty history to be reported, got %v", m.err)
+	}
+
+	writeHistory(`[{"command": "kubectl delete pod web", "timestamp": "2024-01-01T10:00:00Z"}]`)
+	updated, cmd = m.Update(ctrlR)
+	m = updated.(Model)
+	if cmd != nil || m.currentScreen != CommandPreviewScreen || m.currentCommand != "kubectl delete pod web" {
+		t.Errorf("expected a delete to open the preview, got %v with %q", m.currentScreen, m.currentCommand)
+	}
+
+	writeHistory(`[
+		{"command": "kubectl delete pod web", "timestamp": "2024-01-01T10:00:00Z"},
+		{"command": "kubectl get pods -n team", "timestamp": "2024-01-01T10:05:00Z"}
+	]`)
+	m.currentScreen = CustomCommandScreen
+	if updated, cmd = m.Update(ctrlR); cmd != nil || updated.(Model).currentScreen != CustomCommandScreen {
+		t.Errorf("expected ctrl+r to be left to the text input")
+	}
+	m.currentScreen = MainMenuScreen
+	updated, cmd = m.Update(ctrlR)
+	m = updated.(Model)
+	if cmd == nil || m.currentCommand != "kubectl get pods -n team" {
+		t.Errorf("expected the get to run straight away, got %q", m.currentCommand)
+	}
+}
+
+// Test that a running command shows its elapsed time and the output header
+// how long it took.
+func TestCommandElapsedTime(t *testing.T) {
+	start := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+	current := start
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return current }
+
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		viewport: ui.NewViewport(80, 30), currentCommand: "kubectl get pods -A"}
+	updated, _ := m.Update(commandStartedMsg{cancel: func() {}})
+	m = updated.(Model)
+
+	current = start.Add(1500 * time.Millisecond)
+	if view := m.View(); !strings.Contains(view, "Running… 1.5s") {
+		t.Errorf("expected the elapsed time while running, got:\n%s", view)
+	}
+
+	current = start.Add(3200 * time.Millisecond)
+	updated, _ = m.Update(commandExecutedMsg{result: kubectl.CommandResult{Output: "No resources found\n"}})
+	m = updated.(Model)
+	if view := m.View(); !strings.Contains(view, "Took 3.2s") {
+		t.Errorf("expected the duration in the output header, got:\n%s", view)
+	}
+}
+
+// Test that a command naming a context with --context is shown as run there
+// rather than in the current context.
+func TestCommandRunContext(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	m := Model{kubectlClient: kubectl.NewClient()}
+	tests := map[string]string{
+		"kubectl get pods":                           "dev",
+		"kubectl get pods --context=prod":            "prod",
+		"kubectl --context prod get pods":            "prod",
+		"kubectl exec -it web -- env --context=prod": "dev",
+	}
+	for cmd, want := range tests {
+		if got := m.commandRunContext(cmd); got != want {
+			t.Errorf("commandRunContext(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compactHeight is the terminal height below which screens are drawn
+// compact: without separators and blank spacer lines, and with help
+// footers cut to one line.
+const compactHeight = 20
+
+// compact reports whether the terminal is too short for the full layout.
+func (m Model) compact() bool {
+	return m.height > 0 && m.height < compactHeight
+}
+
+// listHeight is the height lists are given, leaving room for the help
+// footer below them.
+func (m Model) listHeight() int {
+	if m.compact() {
+		return m.height - 2
+	}
+	return m.height - 4
+}
+
+// viewportHeight is the height of scrollable outputs, leaving room for the
+// header, the command and the help below them.
+func (m Model) viewportHeight() int {
+	if m.compact() {
+		return m.height - 5
+	}
+	return m.height - 6
+}
+
+// rule returns a line of char across the screen to go below a title, or
+// nothing in compact mode.
+func (m Model) rule(char string) string {
+	if m.compact() {
+		return ""
+	}
+	return strings.Repeat(char, m.width) + "\n"
+}
+
+// borderRule is rule in the theme's border colour.
+func (m Model) borderRule() string {
+	if m.compact() {
+		return ""
+	}
+	return m.GetBorderStyle().Render(strings.Repeat("─", m.width)) + "\n"
+}
+
+// blankLine returns the empty line between sections, left out in compact
+// mode.
+func (m Model) blankLine() string {
+	if m.compact() {
+		return ""
+	}
+	return "\n"
+}
+
+// shortHelp returns help as is, or in compact mode without "Press" and cut
+// to fit on one line.
+func (m Model) shortHelp(help string) string {
+	if !m.compact() {
+		return help
+	}
+	help = strings.ReplaceAll(help, "Press ", "")
+	if m.width <= 0 || lipgloss.Width(help) <= m.width {
+		return help
+	}
+	runes := []rune(help)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > m.width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " |") + "…"
+}
+
+// helpFooter returns help to go below a screen's content, after a blank
+// line unless in compact mode.
+func (m Model) helpFooter(help string) string {
+	return "\n" + m.blankLine() + m.shortHelp(help)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that a short terminal drops separators and blank lines so the
+// output screen fits, while a tall one keeps the full layout.
+func TestCompactLayoutFitsShortTerminal(t *testing.T) {
+	resize := func(height int) Model {
+		m := Model{keys: defaultKeyMap(), textInput: textinput.New(), ready: true,
+			viewport: ui.NewViewport(80, 30), list: ui.NewList(nil, "", 80, 30), currentScreen: CommandOutputScreen,
+			currentCommand: "kubectl get pods"}
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: height})
+		m = updated.(Model)
+		m.viewport.SetContent(strings.Repeat("line\n", 50))
+		return m
+	}
+
+	m := resize(18)
+	view := m.View()
+	if strings.Contains(view, "───") {
+		t.Errorf("expected no separator in compact mode, got:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 18 {
+		t.Errorf("expected the view to fit in 18 lines, got %d:\n%s", lines, view)
+	}
+	if strings.Contains(view, "Press ") {
+		t.Errorf("expected shortened help in compact mode, got:\n%s", view)
+	}
+
+	m = resize(40)
+	if view := m.View(); !strings.Contains(view, "───") || !strings.Contains(view, "Press 's' to save output") {
+		t.Errorf("expected the full layout on a tall terminal, got:\n%s", view)
+	}
+}
+
+func TestShortHelp(t *testing.T) {
+	m := Model{width: 20, height: 18}
+	if got := m.shortHelp("Press 'Esc' to go back | ↑↓ to scroll"); got != "'Esc' to go back…" {
+		t.Errorf("shortHelp = %q", got)
+	}
+	m.height = 40
+	if got := m.shortHelp("Press 'Esc' to go back"); got != "Press 'Esc' to go back" {
+		t.Errorf("shortHelp = %q, want it unchanged", got)
+	}
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// completionVerbs are offered for the first word of a custom command.
+var completionVerbs = []string{
+	"annotate", "apply", "cordon", "delete", "describe", "drain", "edit", "exec", "explain",
+	"get", "label", "logs", "port-forward", "rollout", "scale", "top", "uncordon",
+}
+
+// completionResources are offered after a verb that takes a resource type.
+var completionResources = []string{
+	"configmaps", "cronjobs", "daemonsets", "deployments", "events", "ingress", "jobs",
+	"namespaces", "nodes", "persistentvolumeclaims", "pods", "replicasets", "secrets",
+	"services", "statefulsets",
+}
+
+// resourceAliases maps short and singular resource names to the plural form
+// used to fetch and cache names.
+var resourceAliases = map[string]string{
+	"po": "pods", "pod": "pods",
+	"deploy": "deployments", "deployment": "deployments",
+	"svc": "services", "service": "services",
+	"no": "nodes", "node": "nodes",
+	"cm": "configmaps", "configmap": "configmaps",
+	"ing": "ingress", "ingresses": "ingress",
+	"ns": "namespaces", "namespace": "namespaces",
+	"secret": "secrets",
+	"ds":     "daemonsets", "daemonset": "daemonsets",
+	"sts": "statefulsets", "statefulset": "statefulsets",
+	"rs": "replicasets", "replicaset": "replicasets",
+	"job": "jobs",
+	"cj":  "cronjobs", "cronjob": "cronjobs",
+	"pvc": "persistentvolumeclaims", "persistentvolumeclaim": "persistentvolumeclaims",
+}
+
+// resourceTypeVerbs take a resource type followed by resource names.
+var resourceTypeVerbs = map[string]bool{
+	"annotate": true, "delete": true, "describe": true, "edit": true, "get": true, "label": true,
+}
+
+// podNameVerbs take a pod name directly.
+var podNameVerbs = map[string]bool{"exec": true, "logs": true}
+
+// commandCompletions returns whole-line suggestions for the word being typed
+// in a custom command: a verb, then a resource type, then a resource name.
+// names holds the fetched names per resource type. When names of a type are
+// needed but have not been fetched yet, that type is returned as well.
+func commandCompletions(input string, names map[string][]string) ([]string, string) {
+	rest := strings.TrimPrefix(input, "kubectl ")
+	words := strings.Fields(rest)
+
+	// The word being typed is empty right after a space
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(rest, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	head := input[:len(input)-len(current)]
+
+	var candidates []string
+	needed := ""
+	switch {
+	case len(words) == 0:
+		candidates = completionVerbs
+	case len(words) == 1 && (resourceTypeVerbs[words[0]] || words[0] == "explain"):
+		candidates = completionResources
+	case len(words) == 1 && podNameVerbs[words[0]]:
+		candidates, needed = completionNames(names, "pods")
+	case len(words) == 2 && resourceTypeVerbs[words[0]]:
+		candidates, needed = completionNames(names, canonicalResource(words[1]))
+	}
+
+	suggestions := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		suggestions = append(suggestions, head+c)
+	}
+	return suggestions, needed
+}
+
+// completionNames returns the fetched names of resource, or resource itself
+// as the second result if they still have to be fetched.
+func completionNames(names map[string][]string, resource string) ([]string, string) {
+	fetched, ok := names[resource]
+	if !ok {
+		return nil, resource
+	}
+	return fetched, ""
+}
+
+func canonicalResource(resource string) string {
+	resource = strings.ToLower(resource)
+	if plural, ok := resourceAliases[resource]; ok {
+		return plural
+	}
+	return resource
+}
+
+// updateCommandSuggestions refreshes the Tab completions of the custom
+// command input, fetching resource names the first time they are needed.
+func (m Model) updateCommandSuggestions() (Model, tea.Cmd) {
+	suggestions, needed := commandCompletions(m.textInput.Value(), m.completionNames)
+	m.textInput.SetSuggestions(suggestions)
+	if needed == "" || m.completionFetching[needed] {
+		return m, nil
+	}
+	return m.markCompletionFetch(needed), m.fetchCompletionNames(needed)
+}
+
+// markCompletionFetch records that the names of resource are being fetched,
+// so the fetch is not started twice.
+func (m Model) markCompletionFetch(resource string) Model {
+	if m.completionFetching == nil {
+		m.completionFetching = map[string]bool{}
+	}
+	m.completionFetching[resource] = true
+	return m
+}
+
+// forgetCompletionNames drops the fetched names, and ignores fetches still
+// running, once another cluster is used.
+func (m Model) forgetCompletionNames() Model {
+	m.completionNames = nil
+	m.completionFetching = nil
+	return m
+}
+
+func (m Model) fetchCompletionNames(resource string) tea.Cmd {
+	return func() tea.Msg {
+		names, err := m.kubectlClient.ListResourceNames(resource)
+		return completionNamesLoadedMsg{resource: resource, names: names, err: err}
+	}
+}
+
+// maxNamespaceMatches is how many matching namespaces are listed below the
+// namespace input.
+const maxNamespaceMatches = 5
+
+// startNamespaceSuggestions offers existing namespaces as Tab completions of
+// the namespace input, fetching them the first time. If they cannot be
+// listed, any name can still be typed.
+func (m Model) startNamespaceSuggestions() (Model, tea.Cmd) {
+	m.textInput.ShowSuggestions = true
+	if names, ok := m.completionNames["namespaces"]; ok {
+		m.textInput.SetSuggestions(names)
+		return m, nil
+	}
+	if m.completionFetching["namespaces"] {
+		return m, nil
+	}
+
+	m = m.markCompletionFetch("namespaces")
+	return m, func() tea.Msg {
+		names, err := m.kubectlClient.ListNamespaceNames()
+		return completionNamesLoadedMsg{resource: "namespaces", names: names, err: err}
+	}
+}
+
+// stopNamespaceSuggestions stops offering completions once the namespace
+// input is left. The fetched names are kept for the next visit.
+func (m Model) stopNamespaceSuggestions() Model {
+	m.textInput.ShowSuggestions = false
+	m.textInput.SetSuggestions(nil)
+	return m
+}
+
+// namespaceMatches returns the first maxNamespaceMatches names starting with
+// the typed prefix, or none before anything is typed.
+func namespaceMatches(names []string, prefix string) []string {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil
+	}
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+			if len(matches) == maxNamespaceMatches {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// clearCommandSuggestions stops offering completions once the custom command
+// input is left, as the text input is shared with other screens.
+func (m Model) clearCommandSuggestions() Model {
+	m.textInput.ShowSuggestions = false
+	m.textInput.SetSuggestions(nil)
+	m.completionNames = nil
+	return m
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCommandCompletions(t *testing.T) {
+	names := map[string][]string{"pods": {"web-1", "web-2"}}
+
+	// Verbs and types are long lists, so contains names suggestions that must
+	// be among them; otherwise the suggestions must equal want
+	tests := []struct {
+		input    string
+		want     []string
+		contains []string
+		needed   string
+	}{
+		{"get po", nil, []string{"get pods", "get deployments"}, ""},
+		{"kubectl describe deploy", nil, []string{"kubectl describe deployments", "kubectl describe pods"}, ""},
+		{"des", nil, []string{"describe", "get"}, ""},
+		{"get pods ", []string{"get pods web-1", "get pods web-2"}, nil, ""},
+		{"get po we", []string{"get po web-1", "get po web-2"}, nil, ""},
+		{"logs w", []string{"logs web-1", "logs web-2"}, nil, ""},
+		{"get svc ", nil, nil, "services"},
+		{"get pods web-1 -o", nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		got, needed := commandCompletions(tt.input, names)
+		if needed != tt.needed {
+			t.Errorf("commandCompletions(%q) needs %q, want %q", tt.input, needed, tt.needed)
+		}
+		if tt.contains != nil {
+			for _, want := range tt.contains {
+				if !containsString(got, want) {
+					t.Errorf("commandCompletions(%q) = %v, missing %q", tt.input, got, want)
+				}
+			}
+			continue
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("commandCompletions(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// Test that Tab completes a resource type in the custom command input.
+func TestCustomCommandTabCompletesResource(t *testing.T) {
+	m := Model{textInput: textinput.New(), keys: defaultKeyMap()}
+	m = m.navigateToCustomCommand()
+
+	for _, r := range "get po" {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(Model)
+
+	if got := m.textInput.Value(); got != "get pods" {
+		t.Errorf("value after Tab = %q, want %q", got, "get pods")
+	}
+}
+
+// Test that the namespace input lists and Tab completes fetched namespaces,
+// and still takes free text when listing them failed.
+func TestNamespaceInputCompletesNamespaces(t *testing.T) {
+	m := Model{textInput: textinput.New(), keys: defaultKeyMap(), width: 80, height: 40, ready: true}
+	m, cmd := m.navigateToNamespaceInput().startNamespaceSuggestions()
+	if cmd == nil {
+		t.Fatalf("expected the namespaces to be fetched")
+	}
+	updated, _ := m.Update(completionNamesLoadedMsg{resource: "namespaces", names: []string{"default", "kube-system", "kube-public"}})
+	m = updated.(Model)
+
+	for _, r := range "kube-s" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	if view := m.View(); !strings.Contains(view, "  kube-system") || strings.Contains(view, "kube-public") {
+		t.Errorf("expected only kube-system to be listed, got:\n%s", view)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m = updated.(Model); m.textInput.Value() != "kube-system" {
+		t.Errorf("value after Tab = %q, want kube-system", m.textInput.Value())
+	}
+
+	// Fetched once: a second visit reuses the names
+	if _, cmd = m.navigateToNamespaceInput().startNamespaceSuggestions(); cmd != nil {
+		t.Errorf("expected the namespaces not to be fetched again")
+	}
+
+	failed := Model{textInput: textinput.New(), keys: defaultKeyMap()}
+	failed, _ = failed.navigateToNamespaceInput().startNamespaceSuggestions()
+	updated, _ = failed.Update(completionNamesLoadedMsg{resource: "namespaces", err: errors.New("forbidden")})
+	failed = updated.(Model)
+	for _, r := range "team-a" {
+		updated, _ = failed.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		failed = updated.(Model)
+	}
+	if failed.textInput.Value() != "team-a" {
+		t.Errorf("expected free text after a failed fetch, got %q", failed.textInput.Value())
+	}
+}
+
+// Test that namespaces fetched after the input was left are still kept, and
+// that switching context forgets them and drops fetches for the old one.
+func TestNamespaceSuggestionsSurviveLeavingAndResetOnSwitch(t *testing.T) {
+	m := Model{textInput: textinput.New(), keys: defaultKeyMap(), width: 80, height: 40}
+	m, cmd := m.navigateToNamespaceInput().startNamespaceSuggestions()
+	if cmd == nil {
+		t.Fatalf("expected the namespaces to be fetched")
+	}
+	if _, cmd = m.startNamespaceSuggestions(); cmd != nil {
+		t.Errorf("expected no second fetch while the first is running")
+	}
+
+	// Enter was pressed before the names arrived
+	m = m.stopNamespaceSuggestions()
+	m.currentScreen = FlagsSelectionScreen
+	updated, _ := m.Update(completionNamesLoadedMsg{resource: "namespaces", names: []string{"dev-a"}})
+	m = updated.(Model)
+	if m, cmd = m.navigateToNamespaceInput().startNamespaceSuggestions(); cmd != nil {
+		t.Errorf("expected the names fetched meanwhile to be reused")
+	}
+
+	updated, _ = m.Update(contextSwitchedMsg{newContext: "prod"})
+	m = updated.(Model)
+	if _, ok := m.completionNames["namespaces"]; ok {
+		t.Errorf("expected the namespaces of the old context to be forgotten")
+	}
+	m, cmd = m.navigateToNamespaceInput().startNamespaceSuggestions()
+	if cmd == nil {
+		t.Fatalf("expected the namespaces of the new context to be fetched")
+	}
+
+	// A fetch started before the switch is not kept
+	stale := m.forgetCompletionNames()
+	updated, _ = stale.Update(completionNamesLoadedMsg{resource: "namespaces", names: []string{"dev-a"}})
+	if _, ok := updated.(Model).completionNames["namespaces"]; ok {
+		t.Errorf("expected a fetch for the old context to be dropped")
+	}
+}
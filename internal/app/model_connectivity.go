@@ -0,0 +1,47 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scheduleConnectivityRecheck waits before checking the current context's
+// cluster again. Nothing is scheduled away from the main menu, so the checks
+// stop until it is shown again.
+func (m Model) scheduleConnectivityRecheck() (Model, tea.Cmd) {
+	if m.connectivityRecheck <= 0 || m.currentScreen != MainMenuScreen {
+		m.recheckPending = false
+		return m, nil
+	}
+	m.recheckID++
+	m.recheckPending = true
+	id := m.recheckID
+	return m, tea.Tick(m.connectivityRecheck, func(time.Time) tea.Msg {
+		return connectivityRecheckMsg{id: id}
+	})
+}
+
+// handleConnectivityRecheck checks the cluster once the wait is over, unless
+// the main menu was left in the meantime.
+func (m Model) handleConnectivityRecheck(msg connectivityRecheckMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.recheckID {
+		return m, nil
+	}
+	if m.currentScreen != MainMenuScreen {
+		m.recheckPending = false
+		return m, nil
+	}
+	return m, m.checkCurrentContext()
+}
+
+// resumeConnectivityRecheck restarts the checks when a key press or click
+// has returned to the main menu.
+func resumeConnectivityRecheck(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m, ok := model.(Model)
+	if !ok || m.recheckPending || m.currentScreen != MainMenuScreen {
+		return model, cmd
+	}
+	m, recheck := m.scheduleConnectivityRecheck()
+	return m, tea.Batch(cmd, recheck)
+}
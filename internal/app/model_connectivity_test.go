@@ -0,0 +1,80 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that the cluster is rechecked only while the main menu is shown, and
+// that the rechecks resume on returning to it.
+func TestConnectivityRecheckOnlyOnMainMenu(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		currentScreen: MainMenuScreen, connectivityRecheck: 30 * time.Second}
+	m.list = ui.NewList(m.mainMenuItems(), "Kubernetes Wizard", 80, 36)
+
+	updated, cmd := m.Update(currentContextCheckedMsg{context: "prod", status: kubectl.ContextReachable})
+	m = updated.(Model)
+	if cmd == nil || !m.recheckPending {
+		t.Fatalf("expected a recheck to be scheduled on the main menu")
+	}
+
+	// A stale wait is ignored
+	if _, cmd = m.Update(connectivityRecheckMsg{id: m.recheckID - 1}); cmd != nil {
+		t.Errorf("expected a stale recheck to be ignored")
+	}
+	if _, cmd = m.Update(connectivityRecheckMsg{id: m.recheckID}); cmd == nil {
+		t.Errorf("expected the cluster to be checked on the main menu")
+	}
+
+	// Away from the main menu the wait ends without a check
+	m.currentScreen = CommandHistoryScreen
+	updated, cmd = m.Update(connectivityRecheckMsg{id: m.recheckID})
+	m = updated.(Model)
+	if cmd != nil || m.recheckPending {
+		t.Fatalf("expected the rechecks to stop off the main menu")
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.currentScreen != MainMenuScreen || cmd == nil || !m.recheckPending {
+		t.Errorf("expected the rechecks to resume on the main menu, got %s (pending %v)", m.currentScreen, m.recheckPending)
+	}
+}
+
+// Test that a zero interval disables the rechecks.
+func TestConnectivityRecheckDisabled(t *testing.T) {
+	m := Model{currentScreen: MainMenuScreen}
+	updated, cmd := m.Update(currentContextCheckedMsg{context: "prod", status: kubectl.ContextReachable})
+	if cmd != nil || updated.(Model).recheckPending {
+		t.Errorf("expected no recheck without an interval")
+	}
+}
+
+// Test that a check finishing after a context switch does not flag the new
+// context's cluster.
+func TestStaleContextCheckIgnored(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, currentScreen: MainMenuScreen,
+		connectivityRecheck: 30 * time.Second, recheckPending: true}
+	stale := currentContextCheckedMsg{context: "prod", status: kubectl.ContextUnreachable, switchID: m.contextSwitchID}
+	updated, _ := m.Update(contextSwitchedMsg{newContext: "dev"})
+	m = updated.(Model)
+	m.permissionsContext = ""
+
+	updated, cmd := m.Update(stale)
+	if m = updated.(Model); m.clusterWarning != "" || m.permissionsContext != "" {
+		t.Errorf("expected the check of prod to be dropped, got banner %q", m.clusterWarning)
+	}
+	if cmd == nil || m.recheckID == 0 {
+		t.Errorf("expected the next recheck to be scheduled after a dropped check")
+	}
+
+	updated, _ = m.Update(currentContextCheckedMsg{context: "dev", status: kubectl.ContextUnreachable, switchID: m.contextSwitchID})
+	if m = updated.(Model); m.clusterWarning == "" {
+		t.Errorf("expected the check of the current context to set the banner")
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// allContainersTitle is the container list entry that shows the logs of
+// every container, each line prefixed with the pod and container it is from.
+const allContainersTitle = "All containers"
+
+// containerFlags returns the logs flags selecting container, which may be
+// allContainersTitle.
+func containerFlags(container string) []string {
+	if container == allContainersTitle {
+		return []string{"--all-containers=true", "--prefix"}
+	}
+	return []string{"-c " + container}
+}
+
+// loadLogsContainers fetches the containers of the pod selected for Logs.
+func (m Model) loadLogsContainers() tea.Cmd {
+	namespace, name := splitNamespacedName(m.selectedResourceName)
+	namespace = strings.TrimPrefix(m.fieldNamespaceFlag(namespace), " -n ")
+
+	return withSpinner(fmt.Sprintf("Loading containers of %s…", name), func() tea.Msg {
+		info, err := m.kubectlClient.GetPodInfo(name, namespace)
+		var containers []string
+		for _, c := range info.Containers {
+			containers = append(containers, c.Name)
+		}
+		return podContainersLoadedMsg{containers: containers, err: err}
+	})
+}
+
+// handlePodContainersLoaded asks which container to show logs of when the
+// pod has several. With one, or when they could not be listed, kubectl's
+// default container is used.
+func (m Model) handlePodContainersLoaded(msg podContainersLoadedMsg) Model {
+	if msg.err != nil {
+		m = m.navigateToFlagsSelection()
+		m.err = fmt.Errorf("Could not list containers, showing the default one: %v", msg.err)
+		return m
+	}
+	if len(msg.containers) < 2 {
+		return m.navigateToFlagsSelection()
+	}
+	return m.navigateToContainerSelection(msg.containers)
+}
+
+// navigateToContainerSelection lists the pod's containers, then an entry for
+// all of them.
+func (m Model) navigateToContainerSelection(containers []string) Model {
+	var items []list.Item
+	for _, c := range containers {
+		items = append(items, ui.NewSimpleItem(c, "Show logs of this container (-c "+c+")"))
+	}
+	items = append(items, ui.NewSimpleItem(allContainersTitle, "Show logs of every container, prefixed with its name (--all-containers=true --prefix)"))
+	m.list = ui.NewList(items, "Select container of "+m.selectedResourceName, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = ContainerSelectionScreen
+	return m
+}
+
+func (m Model) handleContainerSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	m.logsContainer = selected.(ui.SimpleItem).Title()
+	return m.navigateToFlagsSelection(), nil
+}
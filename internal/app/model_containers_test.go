@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that logs of a multi-container pod ask for the container and build
+// the command with it, while a single container goes straight to the flags.
+func TestLogsContainerSelection(t *testing.T) {
+	start := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourcePods, selectedAction: ActionLogs, selectedResourceName: "web"}
+	enter := func(m Model, index int) Model {
+		m.list.Select(index)
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return updated.(Model)
+	}
+
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{1, "kubectl logs web -c sidecar"},
+		{2, "kubectl logs web --all-containers=true --prefix"},
+	}
+	for _, tt := range tests {
+		updated, _ := start.Update(podContainersLoadedMsg{containers: []string{"app", "sidecar"}})
+		m := updated.(Model)
+		if m.currentScreen != ContainerSelectionScreen || len(m.list.Items()) != 3 {
+			t.Fatalf("expected two containers and All containers, got %s with %d items", m.currentScreen, len(m.list.Items()))
+		}
+		m = enter(m, tt.index)
+		if m.currentScreen != FlagsSelectionScreen {
+			t.Fatalf("expected the flags after picking a container, got %s", m.currentScreen)
+		}
+		if m = enter(m, 0); m.currentCommand != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, m.currentCommand)
+		}
+	}
+
+	updated, _ := start.Update(podContainersLoadedMsg{containers: []string{"app"}})
+	m := enter(updated.(Model), 0)
+	if m.currentCommand != "kubectl logs web" {
+		t.Errorf("expected no container flag for a single container, got %q", m.currentCommand)
+	}
+
+	updated, _ = start.Update(podContainersLoadedMsg{err: fmt.Errorf("pods \"web\" not found")})
+	if m = updated.(Model); m.currentScreen != FlagsSelectionScreen || m.err == nil {
+		t.Errorf("expected the flags with an error when containers can't be listed, got %s (%v)", m.currentScreen, m.err)
+	}
+}
+
+// Test that the --previous warning counts the restarts of the chosen
+// container, and of every container only when all of them are shown.
+func TestPreviousLogsCountChosenContainerRestarts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *'@.name==\"sidecar\"'*) echo 0 ;;\n" +
+		"  *'@.name==\"app\"'*) echo 3 ;;\n" +
+		"  *'[*]'*) echo 3 0 ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		container string
+		restarts  int
+	}{
+		{"sidecar", 0},
+		{"app", 3},
+		{allContainersTitle, 3},
+	}
+	for _, tt := range tests {
+		m := Model{kubectlClient: kubectl.NewClient(), selectedResource: ResourcePods, selectedAction: ActionLogs,
+			selectedResourceName: "web", selectedFlags: []string{"--previous"}, logsContainer: tt.container}
+		msg, ok := m.checkPreviousLogs()().(podRestartsCheckedMsg)
+		if !ok || msg.err != nil || msg.restarts != tt.restarts {
+			t.Errorf("restarts for %s = %+v, want %d", tt.container, msg, tt.restarts)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	contextFlagItemTitle       = "--context <name>..."
+	contextFlagItemDescription = "Run against another kube context without switching to it"
+	contextFlagListTitle       = "Run Against Context"
+)
+
+// navigateToContextFlagSelection lists the kube contexts to pick one for a
+// --context flag. The flags list is kept so it can be restored afterwards.
+func (m Model) navigateToContextFlagSelection() Model {
+	m.flagsList = m.list
+
+	var items []list.Item
+	contexts, err := m.kubectlClient.ListContexts()
+	if err != nil {
+		items = []list.Item{ui.NewSimpleItem("Unable to load contexts", err.Error())}
+	} else if len(contexts) == 0 {
+		items = []list.Item{ui.NewSimpleItem("No contexts found", "Configure kubeconfig to add contexts")}
+	} else {
+		currentCtx, _ := m.kubectlClient.GetCurrentContext()
+		for _, name := range contexts {
+			desc := ""
+			if name == currentCtx {
+				desc = "(current)"
+			}
+			items = append(items, ui.NewSimpleItem(name, desc))
+		}
+	}
+
+	m.list = ui.NewList(items, contextFlagListTitle, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = ContextFlagSelectionScreen
+	return m
+}
+
+// handleContextFlagSelection ticks "--context=<name>" for the highlighted
+// context and returns to the flags list. Only one context flag is kept.
+func (m Model) handleContextFlagSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	name := selected.(ui.SimpleItem).Title()
+	if name == "Unable to load contexts" || name == "No contexts found" {
+		return m, nil
+	}
+
+	flag := "--context=" + name
+	kept := m.selectedFlags[:0]
+	for _, f := range m.selectedFlags {
+		if !strings.HasPrefix(f, "--context=") {
+			kept = append(kept, f)
+		}
+	}
+	m.selectedFlags = append(kept, flag)
+
+	m = m.restoreFlagsList()
+	items := m.list.Items()
+	for i := len(items) - 1; i >= 0; i-- {
+		if strings.HasPrefix(stripCheckbox(items[i].(ui.SimpleItem).Title()), "--context=") {
+			m.list.RemoveItem(i)
+		}
+	}
+	m.list.InsertItem(len(m.list.Items()), ui.NewSimpleItem("[x] "+flag, describeFlag(flag)))
+	m.err = nil
+	return m, nil
+}
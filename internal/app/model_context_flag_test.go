@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Test that picking a context ticks a single --context flag on the flags
+// list, replacing one picked earlier.
+func TestContextFlagSelectionAddsFlag(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionGet}
+	m = m.navigateToFlagsSelection()
+
+	for _, name := range []string{"staging", "prod"} {
+		m.flagsList = m.list
+		m.list = ui.NewList([]list.Item{
+			ui.NewSimpleItem("staging", "(current)"),
+			ui.NewSimpleItem("prod", ""),
+		}, contextFlagListTitle, m.width, m.height-4)
+		m.currentScreen = ContextFlagSelectionScreen
+		if name == "prod" {
+			m.list.Select(1)
+		}
+
+		updated, _ := m.handleContextFlagSelection()
+		m = updated.(Model)
+	}
+
+	if m.currentScreen != FlagsSelectionScreen {
+		t.Fatalf("expected flags selection screen, got %s", m.currentScreen)
+	}
+	if len(m.selectedFlags) != 1 || m.selectedFlags[0] != "--context=prod" {
+		t.Errorf("expected selected flags [--context=prod], got %v", m.selectedFlags)
+	}
+	ticked := 0
+	for _, item := range m.list.Items() {
+		if title := item.(ui.SimpleItem).Title(); title == "[x] --context=prod" {
+			ticked++
+		} else if title == "[x] --context=staging" {
+			t.Errorf("expected the earlier context flag to be replaced")
+		}
+	}
+	if ticked != 1 {
+		t.Errorf("expected one ticked --context=prod item, got %d", ticked)
+	}
+
+	cmd, err := m.buildSelectedCommand()
+	if err != nil || cmd != "kubectl get pods --context=prod" {
+		t.Errorf("expected the command to carry the context flag, got %q (%v)", cmd, err)
+	}
+}
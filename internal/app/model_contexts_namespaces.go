@@ -2,9 +2,11 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 )
 
@@ -14,9 +16,12 @@ func (m Model) navigateToContextsAndNamespacesMenu() Model {
 	items := []list.Item{
 		ui.NewSimpleItem("Switch Context", "Switch the current kube context"),
 		ui.NewSimpleItem("Set Default Namespace", "Choose a default namespace for commands"),
+		ui.NewSimpleItem("Create Namespace", "Create a new namespace"),
+		ui.NewSimpleItem("Delete Namespace", "Delete a namespace and everything in it"),
+		ui.NewSimpleItem("Set Kubeconfig", "Use a specific kubeconfig file for all commands"),
 		ui.NewSimpleItem("Back to Main Menu", "Return to the main menu"),
 	}
-	m.list = ui.NewList(items, "Contexts & Namespaces", m.width, m.height-4)
+	m.list = ui.NewList(m.withoutMutatingItems(items), "Contexts & Namespaces", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = ContextsNamespacesMenuScreen
 	return m
@@ -42,49 +47,143 @@ func (m Model) navigateToContextsList() Model {
 		}
 	} else {
 		for _, name := range contexts {
-			desc := ""
+			parts := []string{}
 			if name == currentCtx {
-				desc = "(current)"
+				parts = append(parts, "(current)")
 			}
-			items = append(items, ui.NewSimpleItem(name, desc))
+			if status, ok := m.contextHealth[name]; ok {
+				parts = append(parts, contextStatusLabel(status))
+			}
+			items = append(items, ui.NewSimpleItem(name, strings.Join(parts, " ")))
 		}
 	}
 
-	m.list = ui.NewList(items, "Kube Contexts (Enter=switch)", m.width, m.height-4)
+	m.list = ui.NewList(items, "Kube Contexts (Enter=switch, 'c'=check reachability)", m.width, m.listHeight())
+	m.jumping = false
 	m.previousScreen = m.currentScreen
 	m.currentScreen = ContextsListScreen
 	return m
 }
 
 func (m Model) navigateToNamespacesList() Model {
-	items := []list.Item{}
-
-	namespaces, err := m.kubectlClient.ListNamespaceNames()
+	namespaces, err := m.kubectlClient.ListNamespaces()
+	m.namespaces = namespaces
 	if err != nil {
 		m.err = err
-		items = []list.Item{
+		m.list = ui.NewList([]list.Item{
 			ui.NewSimpleItem("Unable to load namespaces", err.Error()),
-		}
-	} else if len(namespaces) == 0 {
-		items = []list.Item{
-			ui.NewSimpleItem("No namespaces found", "Create namespaces to select a default"),
-		}
+		}, namespacesListTitle, m.width, m.listHeight())
 	} else {
-		for _, ns := range namespaces {
-			desc := ""
-			if ns == m.defaultNamespace {
-				desc = "(current default)"
-			}
-			items = append(items, ui.NewSimpleItem(ns, desc))
-		}
+		m.list = m.buildNamespacesList("")
 	}
+	m.jumping = false
 
-	m.list = ui.NewList(items, "Namespaces (Enter=set default)", m.width, m.height-4)
 	m.previousScreen = m.currentScreen
 	m.currentScreen = NamespacesListScreen
 	return m
 }
 
+const (
+	namespacesListTitle = "Namespaces (Enter=set default, 'p'=pin/unpin)"
+	pinnedMarker        = "⭐ "
+)
+
+// buildNamespacesList renders the cached namespaces with pinned ones sorted to
+// the top and highlights selectName when it is present.
+func (m Model) buildNamespacesList(selectName string) list.Model {
+	if len(m.namespaces) == 0 {
+		return ui.NewList([]list.Item{
+			ui.NewSimpleItem("No namespaces found", "Create namespaces to select a default"),
+		}, namespacesListTitle, m.width, m.listHeight())
+	}
+
+	pinned := []kubectl.NamespaceInfo{}
+	others := []kubectl.NamespaceInfo{}
+	for _, ns := range m.namespaces {
+		if m.prefsStore != nil && m.prefsStore.IsNamespacePinned(ns.Name) {
+			pinned = append(pinned, ns)
+		} else {
+			others = append(others, ns)
+		}
+	}
+
+	items := []list.Item{}
+	selectIdx := 0
+	for i, ns := range append(pinned, others...) {
+		title := ns.Name
+		if i < len(pinned) {
+			title = pinnedMarker + ns.Name
+		}
+		if ns.Name == selectName {
+			selectIdx = i
+		}
+		items = append(items, ui.NewSimpleItem(title, m.namespaceDescription(ns)))
+	}
+
+	l := ui.NewList(items, namespacesListTitle, m.width, m.listHeight())
+	l.Select(selectIdx)
+	return l
+}
+
+// selectedNamespaceName returns the namespace highlighted in the namespaces
+// list without its pinned marker.
+func (m Model) selectedNamespaceName() string {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return ""
+	}
+	title := selected.(ui.SimpleItem).Title()
+	if title == "Unable to load namespaces" || title == "No namespaces found" {
+		return ""
+	}
+	return strings.TrimPrefix(title, pinnedMarker)
+}
+
+// togglePinnedNamespace pins or unpins the highlighted namespace and re-sorts the list.
+func (m Model) togglePinnedNamespace() Model {
+	name := m.selectedNamespaceName()
+	if name == "" || m.prefsStore == nil {
+		return m
+	}
+
+	pinned, err := m.prefsStore.TogglePinnedNamespace(name)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	if pinned {
+		m.err = fmt.Errorf("✓ Pinned namespace %s", name)
+	} else {
+		m.err = fmt.Errorf("✓ Unpinned namespace %s", name)
+	}
+	m.list = m.buildNamespacesList(name)
+	return m
+}
+
+// namespaceDescription summarises a namespace's status and pod count for the
+// namespaces list, e.g. "Active · 12 pods (current default)".
+func (m Model) namespaceDescription(ns kubectl.NamespaceInfo) string {
+	parts := []string{}
+	if ns.Status != "" {
+		parts = append(parts, ns.Status)
+	}
+	switch {
+	case ns.PodCount == 1:
+		parts = append(parts, "1 pod")
+	case ns.PodCount >= 0:
+		parts = append(parts, fmt.Sprintf("%d pods", ns.PodCount))
+	}
+
+	desc := strings.Join(parts, " · ")
+	if ns.Name == m.defaultNamespace {
+		if desc != "" {
+			desc += " "
+		}
+		desc += "(current default)"
+	}
+	return desc
+}
+
 func (m Model) handleContextsAndNamespacesMenuSelection() (tea.Model, tea.Cmd) {
 	selected := m.list.SelectedItem()
 	if selected == nil {
@@ -98,6 +197,12 @@ func (m Model) handleContextsAndNamespacesMenuSelection() (tea.Model, tea.Cmd) {
 		return m.navigateToContextsList(), nil
 	case "Set Default Namespace":
 		return m.navigateToNamespacesList(), nil
+	case "Create Namespace":
+		return m.navigateToCreateNamespace(), nil
+	case "Delete Namespace":
+		return m.navigateToDeleteNamespaceSelection(), nil
+	case "Set Kubeconfig":
+		return m.navigateToKubeconfigInput(), nil
 	case "Back to Main Menu":
 		return m.navigateToMainMenu(), nil
 	}
@@ -115,29 +220,227 @@ func (m Model) handleContextSelection() (tea.Model, tea.Cmd) {
 	if title == "Unable to load contexts" || title == "No contexts found" {
 		return m, nil
 	}
+	if m.needsContextConfirmation(title) {
+		return m.navigateToContextConfirmation(title, false), nil
+	}
 
 	return m, m.switchContext(title)
 }
 
 func (m Model) handleNamespaceSelection() (tea.Model, tea.Cmd) {
+	name := m.selectedNamespaceName()
+	if name == "" {
+		return m, nil
+	}
+
+	m.defaultNamespace = name
+	m.err = fmt.Errorf("✓ Default namespace set to %s", name)
+	return m.navigateToContextsAndNamespacesMenu(), nil
+}
+
+func (m Model) navigateToCreateNamespace() Model {
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = "Namespace name (e.g. team-a)"
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = CreateNamespaceScreen
+	return m
+}
+
+func (m Model) handleCreateNamespace() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(m.textInput.Value())
+	if name == "" {
+		return m, nil
+	}
+	if !ValidateResourceName(name) {
+		m.err = fmt.Errorf("invalid namespace name: must be a valid Kubernetes DNS-1123 label")
+		return m, nil
+	}
+
+	m.err = nil
+	m.textInput.Blur()
+	return m.confirmContextThen(withSpinner(fmt.Sprintf("Creating namespace %s…", name), func() tea.Msg {
+		return namespaceChangedMsg{name: name, err: m.kubectlClient.CreateNamespace(name)}
+	}))
+}
+
+// navigateToDeleteNamespaceSelection lists the namespaces to pick one for deletion.
+func (m Model) navigateToDeleteNamespaceSelection() Model {
+	m = m.navigateToNamespacesList()
+	m.list.Title = "Delete Namespace (Enter=select)"
+	m.currentScreen = DeleteNamespaceSelectionScreen
+	return m
+}
+
+func (m Model) handleDeleteNamespaceSelection() (tea.Model, tea.Cmd) {
+	name := m.selectedNamespaceName()
+	if name == "" {
+		return m, nil
+	}
+
+	items := []list.Item{
+		ui.NewSimpleItem("Cancel", "Go back without deleting"),
+		ui.NewSimpleItem("Confirm Delete", fmt.Sprintf("Permanently delete namespace %s and every resource in it", name)),
+	}
+	m.deletingNamespace = name
+	m.list = ui.NewList(items, fmt.Sprintf("⚠️  CONFIRM DELETION: namespace %s", name), m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = DeleteNamespaceConfirmationScreen
+	return m, nil
+}
+
+func (m Model) handleDeleteNamespaceConfirmation() (tea.Model, tea.Cmd) {
 	selected := m.list.SelectedItem()
 	if selected == nil {
 		return m, nil
 	}
 
-	title := selected.(ui.SimpleItem).Title()
-	if title == "Unable to load namespaces" || title == "No namespaces found" {
+	name := m.deletingNamespace
+	m.deletingNamespace = ""
+
+	if selected.(ui.SimpleItem).Title() == "Confirm Delete" && name != "" {
+		return m.confirmContextThen(withSpinner(fmt.Sprintf("Deleting namespace %s…", name), func() tea.Msg {
+			return namespaceChangedMsg{name: name, deleted: true, err: m.kubectlClient.DeleteNamespace(name)}
+		}))
+	}
+
+	// Cancel - go back to the namespace picker
+	return m.navigateToDeleteNamespaceSelection(), nil
+}
+
+func (m Model) navigateToKubeconfigInput() Model {
+	m.textInput.SetValue(m.kubectlClient.Kubeconfig())
+	m.textInput.Placeholder = "Path to kubeconfig (leave empty for default)"
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = KubeconfigInputScreen
+	return m
+}
+
+func (m Model) handleKubeconfigInput() (tea.Model, tea.Cmd) {
+	// The path is passed to kubectl as a single argument (no shell), so it
+	// is only trimmed rather than sanitized to keep Windows paths intact.
+	path := strings.TrimSpace(m.textInput.Value())
+
+	if err := m.kubectlClient.SetKubeconfig(path); err != nil {
+		m.err = err
 		return m, nil
 	}
 
-	m.defaultNamespace = title
-	m.err = fmt.Errorf("✓ Default namespace set to %s", title)
+	m = m.forgetCompletionNames()
+	m.vocabulary = nil
+	if path == "" {
+		m.err = fmt.Errorf("✓ Using default kubeconfig")
+	} else {
+		m.err = fmt.Errorf("✓ Using kubeconfig %s", path)
+	}
 	return m.navigateToContextsAndNamespacesMenu(), nil
 }
 
+// contextStatusLabel renders a context reachability status for list descriptions
+func contextStatusLabel(status kubectl.ContextStatus) string {
+	switch status {
+	case kubectl.ContextReachable:
+		return "✅ reachable"
+	case kubectl.ContextUnreachable:
+		return "❌ unreachable"
+	case kubectl.ContextTimeout:
+		return "⏱️ timeout"
+	default:
+		return string(status)
+	}
+}
+
+// probeContexts checks every listed context concurrently. It is opt-in
+// because probing many clusters can take a few seconds.
+func (m Model) probeContexts() tea.Cmd {
+	return func() tea.Msg {
+		contexts, err := m.kubectlClient.ListContexts()
+		if err != nil {
+			return contextsProbedMsg{err: err}
+		}
+		return contextsProbedMsg{statuses: m.kubectlClient.ProbeContexts(contexts)}
+	}
+}
+
+// switchContext makes name the current context. The kubeconfig is checked
+// again first, as the context may have been removed since the list loaded.
 func (m Model) switchContext(name string) tea.Cmd {
 	return func() tea.Msg {
+		if contexts, err := m.kubectlClient.ListContexts(); err == nil && !containsString(contexts, name) {
+			return contextSwitchedMsg{newContext: name, missing: true}
+		}
 		err := m.kubectlClient.UseContext(name)
 		return contextSwitchedMsg{newContext: name, err: err}
 	}
 }
+
+// applyStrictNamespace pins currentCommand to the default namespace when
+// strict namespace mode is on. Overriding a namespace the command already
+// named is reported as a preview warning.
+func (m Model) applyStrictNamespace() Model {
+	if !m.strictNamespace {
+		return m
+	}
+	cmd, overridden := strictNamespaceCommand(m.currentCommand, m.defaultNamespace)
+	m.currentCommand = cmd
+	if len(overridden) > 0 {
+		m.previewWarning = fmt.Sprintf("Strict namespace mode: namespace %s replaced with %s",
+			strings.Join(overridden, ", "), m.defaultNamespace)
+		m.previewWarningCommand = cmd
+	}
+	return m
+}
+
+// strictNamespaceCommand rewrites cmd to run in namespace: any -n/--namespace
+// flags are dropped and "-n <namespace>" is added before a "--" separator,
+// if there is one. Quoted arguments, such as a jsonpath or a selector, stay
+// quoted. All-namespaces commands, and those that can't be split, are
+// returned unchanged. The second result lists namespaces that were replaced.
+func strictNamespaceCommand(cmd, namespace string) (string, []string) {
+	if namespace == "" {
+		return cmd, nil
+	}
+
+	args, err := kubectl.SplitArgs(cmd)
+	if err != nil {
+		return cmd, nil
+	}
+	var tail []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, tail = args[:i], args[i:]
+			break
+		}
+	}
+
+	var kept, overridden []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+		switch {
+		case arg == "-A" || arg == "--all-namespaces" || arg == "--all-namespaces=true":
+			return cmd, nil
+		case arg == "-n" || arg == "--namespace":
+			if i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+		case strings.HasPrefix(arg, "-n="), strings.HasPrefix(arg, "--namespace="):
+			value = arg[strings.Index(arg, "=")+1:]
+		default:
+			kept = append(kept, arg)
+			continue
+		}
+		if value != "" && value != namespace {
+			overridden = append(overridden, value)
+		}
+	}
+
+	kept = append(kept, "-n", namespace)
+	quoted := make([]string, 0, len(kept)+len(tail))
+	for _, arg := range append(kept, tail...) {
+		quoted = append(quoted, kubectl.QuoteArg(arg))
+	}
+	return strings.Join(quoted, " "), overridden
+}
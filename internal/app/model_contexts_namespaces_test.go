@@ -0,0 +1,281 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStrictNamespaceCommand(t *testing.T) {
+	tests := []struct {
+		cmd        string
+		want       string
+		overridden []string
+	}{
+		{"kubectl get pods", "kubectl get pods -n team", nil},
+		{"kubectl get pods -n team", "kubectl get pods -n team", nil},
+		{"kubectl delete pod web -n prod", "kubectl delete pod web -n team", []string{"prod"}},
+		{"kubectl get pods --namespace=prod -o wide", "kubectl get pods -o wide -n team", []string{"prod"}},
+		{"kubectl exec -it web --namespace prod -- /bin/sh -n x", "kubectl exec -it web -n team -- /bin/sh -n x", []string{"prod"}},
+		{"kubectl get pods -A", "kubectl get pods -A", nil},
+		{"kubectl get pods --all-namespaces", "kubectl get pods --all-namespaces", nil},
+		{"kubectl get pods -o jsonpath='{.items[*].metadata.name}' -n prod",
+			"kubectl get pods -o 'jsonpath={.items[*].metadata.name}' -n team", []string{"prod"}},
+		{"kubectl get pods -l 'tier in (web, api)'", "kubectl get pods -l 'tier in (web, api)' -n team", nil},
+	}
+
+	for _, tt := range tests {
+		got, overridden := strictNamespaceCommand(tt.cmd, "team")
+		if got != tt.want || !reflect.DeepEqual(overridden, tt.overridden) {
+			t.Errorf("strictNamespaceCommand(%q) = %q, %v; want %q, %v", tt.cmd, got, overridden, tt.want, tt.overridden)
+		}
+	}
+}
+
+// Test that strict mode also holds for commands run without the preview,
+// such as from history or a hotkey.
+func TestStrictNamespaceEnforcedWhenExecuting(t *testing.T) {
+	m := Model{
+		strictNamespace:  true,
+		readOnly:         true,
+		defaultNamespace: "team",
+		currentCommand:   "kubectl delete pod web -n prod",
+	}
+	// Read-only mode refuses the delete, reporting the command as it would have run
+	msg, ok := m.executeCommand()().(commandExecutedMsg)
+	if !ok {
+		t.Fatalf("expected the command to be refused, got %#v", msg)
+	}
+	if want := "kubectl delete pod web -n team"; msg.result.Command != want {
+		t.Errorf("command = %q, want %q", msg.result.Command, want)
+	}
+}
+
+// Test that strict mode pins a typed custom command to the default namespace
+// and warns about the namespace it replaced.
+func TestStrictNamespaceWarnsOnOverride(t *testing.T) {
+	m := Model{
+		strictNamespace:  true,
+		defaultNamespace: "team",
+		currentCommand:   "kubectl get pods -n prod",
+	}
+
+	m = m.navigateToCommandPreview()
+	if m.currentCommand != "kubectl get pods -n team" {
+		t.Errorf("command = %q, want it pinned to team", m.currentCommand)
+	}
+	if m.previewWarning == "" || m.previewWarningCommand != m.currentCommand {
+		t.Errorf("expected a preview warning about the replaced namespace")
+	}
+}
+
+func TestCreateNamespaceRejectsInvalidName(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40}
+	m = m.navigateToCreateNamespace()
+	m.textInput.SetValue("Team_A")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("expected no command for an invalid namespace name")
+	}
+	if m.currentScreen != CreateNamespaceScreen || m.err == nil {
+		t.Errorf("expected to stay on the input with an error, got %s (err %v)", m.currentScreen, m.err)
+	}
+}
+
+func TestDeleteNamespaceAsksForConfirmation(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40}
+	m.list = ui.NewList([]list.Item{
+		ui.NewSimpleItem(pinnedMarker+"team-a", ""),
+		ui.NewSimpleItem("team-b", ""),
+	}, "Delete Namespace", m.width, m.height-4)
+	m.currentScreen = DeleteNamespaceSelectionScreen
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != DeleteNamespaceConfirmationScreen || m.deletingNamespace != "team-a" {
+		t.Fatalf("expected confirmation for team-a, got %s for %q", m.currentScreen, m.deletingNamespace)
+	}
+	if !strings.Contains(m.list.Title, "team-a") {
+		t.Errorf("expected the confirmation title to name the namespace, got %q", m.list.Title)
+	}
+
+	m.list.Select(1)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("expected Confirm Delete to start deleting the namespace")
+	}
+
+	// The spinner stops whether the delete succeeded or failed
+	m = updated.(Model)
+	m.loading = true
+	// Without kubectl the namespaces list shows its load error
+	t.Setenv("PATH", t.TempDir())
+	m.kubectlClient = kubectl.NewClient()
+	for _, msg := range []namespaceChangedMsg{{name: "team-a", deleted: true}, {name: "team-a", deleted: true, err: os.ErrPermission}} {
+		if updated, _ = m.Update(msg); updated.(Model).loading {
+			t.Errorf("expected the spinner to stop after %+v", msg)
+		}
+	}
+}
+
+func TestTypeToJumpSelectsFirstMatchingNamespace(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, currentScreen: NamespacesListScreen}
+	m.list = ui.NewList([]list.Item{
+		ui.NewSimpleItem(pinnedMarker+"team-b", ""),
+		ui.NewSimpleItem("default", ""),
+		ui.NewSimpleItem("kube-public", ""),
+		ui.NewSimpleItem("kube-system", ""),
+		ui.NewSimpleItem("team-a", ""),
+	}, namespacesListTitle, 80, 40)
+
+	for _, r := range "/kube-s" {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	if got := m.selectedNamespaceName(); got != "kube-system" {
+		t.Errorf("selected %q after typing kube-s, want kube-system", got)
+	}
+	if !strings.Contains(m.View(), "Jump: kube-s") {
+		t.Errorf("expected the typed name below the list, got:\n%s", m.View())
+	}
+
+	// However long it takes, typing goes on until Esc
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m = updated.(Model); !m.jumping || m.jumpPrefix != "kube-" {
+		t.Errorf("expected Backspace to take back a character, got %q", m.jumpPrefix)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.jumping || strings.Contains(m.View(), "Jump:") {
+		t.Errorf("expected Esc to stop typing")
+	}
+
+	// The jump key starts a new name, matching pinned namespaces by name
+	for _, r := range "/T" {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	if got := m.selectedNamespaceName(); got != "team-b" {
+		t.Errorf("selected %q after typing T, want team-b", got)
+	}
+
+	// Enter stops typing as it acts on the name
+	if m, ok := m.typeToJump(tea.KeyMsg{Type: tea.KeyEnter}); ok || m.jumping {
+		t.Errorf("expected Enter to stop typing and reach the list")
+	}
+}
+
+// Test that keys are left to the list and the screen's shortcuts until the
+// jump key is pressed, and that Esc stops typing without leaving the list.
+func TestTypeToJumpLeavesShortcutsAlone(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, currentScreen: NamespacesListScreen}
+	m.list = ui.NewList([]list.Item{ui.NewSimpleItem("default", ""), ui.NewSimpleItem("kube-system", ""), ui.NewSimpleItem("prod", "")}, namespacesListTitle, 80, 40)
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	for _, s := range []string{"p", "j", "k", "G", "g", "d"} {
+		if _, ok := m.typeToJump(runes(s)); ok {
+			t.Errorf("expected %q to be left to the list rather than start a jump", s)
+		}
+	}
+	updated, _ := m.Update(runes("j"))
+	if m = updated.(Model); m.list.Index() != 1 {
+		t.Errorf("expected j to move down the list, got index %d", m.list.Index())
+	}
+
+	m, _ = m.typeToJump(runes("/"))
+	if _, ok := m.typeToJump(runes("p")); !ok {
+		t.Error("expected 'p' to be typed after the jump key")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentScreen != NamespacesListScreen || m.jumping {
+		t.Errorf("expected Esc to stop typing and stay on the list, got %s", m.currentScreen)
+	}
+}
+
+// Test that switching to a context removed since the list loaded refreshes
+// the list instead of calling use-context.
+func TestSwitchContextThatNoLongerExists(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *get-contexts*) echo dev; echo prod ;;\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"  *use-context*) echo 'use-context should not run' >&2; exit 1 ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	m := Model{keys: defaultKeyMap(), kubectlClient: kubectl.NewClient(), width: 80, height: 40, currentScreen: ContextsListScreen}
+	m.list = ui.NewList([]list.Item{ui.NewSimpleItem("dev", ""), ui.NewSimpleItem("staging", "")}, "Kube Contexts", 80, 40)
+	m.list.Select(1)
+
+	msg := m.switchContext("staging")()
+	switched, ok := msg.(contextSwitchedMsg)
+	if !ok || !switched.missing {
+		t.Fatalf("switchContext() = %#v, want a missing context", msg)
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if m.err == nil || m.err.Error() != "context staging no longer exists" {
+		t.Errorf("err = %v, want context staging no longer exists", m.err)
+	}
+	var names []string
+	for _, item := range m.list.Items() {
+		names = append(names, item.(ui.SimpleItem).Title())
+	}
+	if !reflect.DeepEqual(names, []string{"dev", "prod"}) {
+		t.Errorf("contexts list = %v, want it reloaded", names)
+	}
+}
+
+// Test that the preview notes when the default namespace was added rather
+// than chosen, and not once the command changes or all namespaces are chosen
+// instead.
+func TestPreviewNotesImplicitDefaultNamespace(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		selectedResource: ResourcePods, selectedAction: ActionGet, defaultNamespace: "team"}
+	m = m.navigateToFlagsSelection()
+	m.list.Select(0)
+	updated, _ := m.handleFlagsSelection()
+	m = updated.(Model)
+	if m.currentCommand != "kubectl get pods -n team" || !strings.Contains(m.View(), "namespace 'team' applied from default") {
+		t.Errorf("expected a note for %q, got:\n%s", m.currentCommand, m.View())
+	}
+	m.currentScreen = SaveFavouriteScreen
+	if !strings.Contains(m.View(), "applied from default") {
+		t.Errorf("expected the note when saving %q as a favourite", m.currentCommand)
+	}
+
+	// The note goes once the command is changed, e.g. by appending arguments
+	implicit := m.currentCommand
+	m.currentCommand += " -l app=web"
+	if m = m.navigateToCommandPreview(); strings.Contains(m.View(), "applied from default") {
+		t.Errorf("expected no note for %q", m.currentCommand)
+	}
+	m.currentCommand = implicit
+
+	m = m.navigateToFlagsSelection()
+	for i, item := range m.list.Items() {
+		if stripCheckbox(item.(ui.SimpleItem).Title()) == "-A" {
+			m.list.Select(i)
+		}
+	}
+	m = m.toggleFlag()
+	m.list.Select(0)
+	updated, _ = m.handleFlagsSelection()
+	if m = updated.(Model); strings.Contains(m.View(), "applied from default") {
+		t.Errorf("expected no note for %q", m.currentCommand)
+	}
+}
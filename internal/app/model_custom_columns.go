@@ -0,0 +1,182 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const customColumnsItemTitle = "Custom Columns..."
+
+var columnIndexRe = regexp.MustCompile(`\[\d+\]`)
+
+// fetchColumnFields loads the first resource of the selected type as JSON
+// and offers its field paths for the custom columns builder.
+func (m Model) fetchColumnFields() tea.Cmd {
+	kind := getResourceShortName(m.selectedResource)
+	cmd := fmt.Sprintf("kubectl get %s -o jsonpath={.items[0]}", kind) + m.fieldNamespaceFlag("")
+
+	return withSpinner(fmt.Sprintf("Fetching a %s to pick columns from…", kind), func() tea.Msg {
+		result, err := m.kubectlClient.ExecuteRaw(cmd)
+		if err != nil {
+			return columnFieldsLoadedMsg{err: err}
+		}
+		if result.Error != "" {
+			return columnFieldsLoadedMsg{err: fmt.Errorf(result.Error)}
+		}
+		if strings.TrimSpace(result.Output) == "" {
+			return columnFieldsLoadedMsg{err: fmt.Errorf("no %s found to pick columns from", strings.ToLower(m.selectedResource.String()))}
+		}
+
+		var obj interface{}
+		if err := json.Unmarshal([]byte(result.Output), &obj); err != nil {
+			return columnFieldsLoadedMsg{err: fmt.Errorf("failed to parse %s JSON: %v", kind, err)}
+		}
+		return columnFieldsLoadedMsg{keys: flattenFieldPaths(obj)}
+	})
+}
+
+// navigateToCustomColumns shows the field picker. The flags list is kept so
+// it can be restored, with its ticked flags, when the builder is left.
+func (m Model) navigateToCustomColumns(keys []string) Model {
+	m.flagsList = m.list
+	m.customColumns = nil
+
+	items := []list.Item{
+		ui.NewSimpleItem("Done (Continue)", "Add the picked fields as -o custom-columns"),
+		ui.NewSimpleItem("---", ""),
+	}
+	for _, k := range keys {
+		items = append(items, ui.NewSimpleItem("[ ] "+k, ""))
+	}
+
+	m.list = ui.NewList(items, "Select Columns (Space to toggle, Enter when done)", m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = CustomColumnsScreen
+	return m
+}
+
+// toggleCustomColumn ticks or unticks the highlighted field. Columns keep
+// the order in which they were picked.
+func (m Model) toggleCustomColumn() Model {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m
+	}
+	title := selected.(ui.SimpleItem).Title()
+	if title == "Done (Continue)" || title == "---" {
+		return m
+	}
+
+	path := stripCheckbox(title)
+	picked := false
+	for i, c := range m.customColumns {
+		if c == path {
+			m.customColumns = append(m.customColumns[:i:i], m.customColumns[i+1:]...)
+			picked = true
+			break
+		}
+	}
+	if !picked {
+		m.customColumns = append(m.customColumns, path)
+	}
+
+	checkbox := "[x] "
+	if picked {
+		checkbox = "[ ] "
+	}
+	m.list.SetItem(m.list.Index(), ui.NewSimpleItem(checkbox+path, ""))
+	return m
+}
+
+// handleCustomColumnsSelection toggles fields, or on Done returns to the
+// flags list with the assembled custom-columns flag ticked.
+func (m Model) handleCustomColumnsSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	if selected.(ui.SimpleItem).Title() != "Done (Continue)" {
+		return m.toggleCustomColumn(), nil
+	}
+	if len(m.customColumns) == 0 {
+		m.err = fmt.Errorf("pick at least one field with Space")
+		return m, nil
+	}
+
+	flag := customColumnsFlag(m.customColumns)
+
+	// Only one custom-columns flag makes sense; replace any earlier one
+	kept := m.selectedFlags[:0]
+	for _, f := range m.selectedFlags {
+		if !strings.HasPrefix(f, "-o custom-columns=") {
+			kept = append(kept, f)
+		}
+	}
+	m.selectedFlags = append(kept, flag)
+
+	m = m.restoreFlagsList()
+	items := m.list.Items()
+	for i := len(items) - 1; i >= 0; i-- {
+		if strings.HasPrefix(stripCheckbox(items[i].(ui.SimpleItem).Title()), "-o custom-columns=") {
+			m.list.RemoveItem(i)
+		}
+	}
+	m.list.InsertItem(len(m.list.Items()), ui.NewSimpleItem("[x] "+flag, "Columns picked with the custom columns builder"))
+	m.err = nil
+	return m, nil
+}
+
+// restoreFlagsList returns to the flags list a picker such as the custom
+// columns builder was opened from.
+func (m Model) restoreFlagsList() Model {
+	m.list = m.flagsList
+	m.list.SetSize(m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = FlagsSelectionScreen
+	return m
+}
+
+// customColumnsFlag assembles "-o custom-columns=NAME:.metadata.name,...".
+func customColumnsFlag(paths []string) string {
+	used := map[string]int{}
+	columns := make([]string, 0, len(paths))
+	for _, p := range paths {
+		name := customColumnName(p)
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		columns = append(columns, name+":."+p)
+	}
+	return "-o custom-columns=" + strings.Join(columns, ",")
+}
+
+// customColumnName derives a column header from the last segment of a field
+// path, e.g. "spec.containers[0].image" becomes "IMAGE".
+func customColumnName(path string) string {
+	path = columnIndexRe.ReplaceAllString(path, "")
+	last := path
+	if i := strings.LastIndex(strings.ReplaceAll(path, `\.`, "__"), "."); i >= 0 {
+		last = path[i+1:]
+	}
+	last = strings.ReplaceAll(last, `\.`, ".")
+
+	var b strings.Builder
+	for _, r := range strings.ToUpper(last) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "VALUE"
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bulkSelects reports whether the resource name list offers ticking several
+// names at once for the action.
+func bulkSelects(action Action) bool {
+	return action == ActionDelete || action == ActionDescribe
+}
+
+// bulkSelectionDescription describes a ticked name in the resource name list.
+func bulkSelectionDescription(action Action) string {
+	if action == ActionDescribe {
+		return "Selected to describe"
+	}
+	return "Selected for deletion"
+}
+
+// separateDescribeOutputs puts a header above each resource in the output of
+// a describe of several resources, which kubectl only separates by a blank
+// line. Each resource starts with an unindented "Name:" line. The second
+// result is false, and output is returned as is, for other commands or a
+// single resource.
+func separateDescribeOutputs(cmd, output string) (string, bool) {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(cmd), "kubectl "))
+	if len(fields) < 2 || fields[0] != "describe" {
+		return output, false
+	}
+	kind := fields[1]
+	if strings.Contains(kind, "/") {
+		kind = ""
+	}
+
+	lines := strings.Split(output, "\n")
+	var starts []int
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Name:") {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) < 2 {
+		return output, false
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(lines[:starts[0]], "\n"))
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(lines[start], "Name:"))
+		sb.WriteString(fmt.Sprintf("━━━ %s ━━━\n", strings.TrimSpace(kind+" "+name)))
+		sb.WriteString(strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n"))
+		sb.WriteString("\n\n")
+	}
+	return sb.String(), true
+}
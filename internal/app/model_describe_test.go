@@ -0,0 +1,56 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Test that ticking several names for Describe describes all of them in one
+// command.
+func TestBulkDescribeSelectsMultipleNames(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourcePods, selectedAction: ActionDescribe}
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"a", "b", "c"}})
+	m = updated.(Model)
+
+	for _, idx := range []int{0, 2} {
+		m.list.Select(idx)
+		updated, _ = m.toggleBulkSelection()
+		m = updated.(Model)
+	}
+	updated, _ = m.handleResourceNameSelection()
+	if m = updated.(Model); m.currentScreen != FlagsSelectionScreen || m.selectedResourceName != "a c" {
+		t.Fatalf("expected the flags for %q, got %q on %s", "a c", m.selectedResourceName, m.currentScreen)
+	}
+
+	m.list.Select(0)
+	updated, _ = m.handleFlagsSelection()
+	if m = updated.(Model); m.currentCommand != "kubectl describe pod a c" {
+		t.Errorf("got command %q", m.currentCommand)
+	}
+}
+
+func TestSeparateDescribeOutputs(t *testing.T) {
+	output := "Name:         a\nNamespace:    default\nContainers:\n  app:\n    Image:  nginx\n\n\nName:         c\nNamespace:    default\n"
+	got, ok := separateDescribeOutputs("kubectl describe pod a c", output)
+	if !ok {
+		t.Fatalf("expected headers for two pods")
+	}
+	want := "━━━ pod a ━━━\nName:         a\nNamespace:    default\nContainers:\n  app:\n    Image:  nginx\n\n" +
+		"━━━ pod c ━━━\nName:         c\nNamespace:    default\n\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, ok := separateDescribeOutputs("kubectl describe pod a", "Name:  a\n"); ok {
+		t.Errorf("expected no headers for one pod")
+	}
+	if _, ok := separateDescribeOutputs("kubectl get pods", output); ok {
+		t.Errorf("expected no headers for a get")
+	}
+	if got, _ := separateDescribeOutputs("kubectl describe pod/a pod/c", output); !strings.HasPrefix(got, "━━━ a ━━━") {
+		t.Errorf("expected a header without the kind, got:\n%s", got)
+	}
+}
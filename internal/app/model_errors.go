@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+// Errors screen: failed commands whose error kubectl explains poorly are
+// shown in full together with a suggestion of what to do about it.
+
+// kubectlErrorHint pairs fragments of kubectl's stderr with a suggestion.
+type kubectlErrorHint struct {
+	fragments  []string // Matched case-insensitively; any one is enough
+	suggestion string
+}
+
+// kubectlErrorHints are checked in order; the first match wins, so the
+// specific "localhost:8080" refusal of a missing kubeconfig comes before the
+// general connection errors.
+var kubectlErrorHints = []kubectlErrorHint{
+	{
+		fragments: []string{"current-context is not set", "no cluster context configured", "context was not found",
+			"no configuration has been provided", "localhost:8080 was refused"},
+		suggestion: "No cluster is selected. Run 'kubectl config use-context <name>' or use Contexts & Namespaces to select a cluster.",
+	},
+	{
+		fragments:  []string{"unauthorized", "you must be logged in", "provide credentials", "token has expired", "expired token"},
+		suggestion: "Your credentials were rejected or have expired. Log in to the cluster again (e.g. refresh your cloud provider's kubeconfig) and retry.",
+	},
+	{
+		fragments: []string{"forbidden", "cannot list resource", "cannot get resource", "cannot create resource",
+			"cannot delete resource", "cannot patch resource", "cannot update resource"},
+		suggestion: "Your user is not allowed to do this (RBAC). Check with 'kubectl auth can-i <verb> <resource> -n <namespace>', try a namespace you have access to, or ask a cluster admin for a Role and RoleBinding.",
+	},
+	{
+		fragments:  []string{"(notfound)", "\" not found"},
+		suggestion: "The resource does not exist here. Check the name and namespace: pick another namespace with -n, or search every namespace with -A.",
+	},
+	{
+		fragments: []string{"connection refused", "unable to connect to the server", "no such host", "i/o timeout",
+			"tls handshake timeout", "no route to host", "network is unreachable"},
+		suggestion: "The API server could not be reached. Check your VPN or network, that the cluster is running, and the server address of the context ('kubectl config view --minify').",
+	},
+}
+
+// interpretKubectlError returns a suggestion for a kubectl error message, or
+// "" when the error is not one of the common ones in kubectlErrorHints.
+func interpretKubectlError(stderr string) string {
+	lower := strings.ToLower(stderr)
+	for _, hint := range kubectlErrorHints {
+		for _, fragment := range hint.fragments {
+			if strings.Contains(lower, fragment) {
+				return hint.suggestion
+			}
+		}
+	}
+	return ""
+}
+
+// navigateToErrorScreen shows the error of the failed command with the
+// suggestion for it.
+func (m Model) navigateToErrorScreen(stderr, suggestion string) Model {
+	var b strings.Builder
+	b.WriteString(m.GetErrorStyle().Render("kubectl reported:") + "\n")
+	b.WriteString(strings.TrimSpace(stderr) + "\n\n")
+	b.WriteString(m.GetHighlightStyle().Render("What to try:") + "\n")
+	b.WriteString(suggestion)
+
+	m.viewport.SetContent(ui.WrapContent(b.String(), m.viewport.Width))
+	m.viewport.GotoTop()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = ErrorScreen
+	return m
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+func TestInterpretKubectlError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   string // Fragment of the expected suggestion; "" for none
+	}{
+		{"error: current-context is not set", "Contexts & Namespaces"},
+		{"no cluster context configured", "Contexts & Namespaces"},
+		{"The connection to the server localhost:8080 was refused - did you specify the right host or port?", "Contexts & Namespaces"},
+		{"error: You must be logged in to the server (Unauthorized)", "credentials"},
+		{`Error from server (Forbidden): pods is forbidden: User "dev" cannot list resource "pods" in API group "" in the namespace "kube-system"`, "kubectl auth can-i"},
+		{`Error from server (NotFound): pods "web-0" not found`, "-A"},
+		{`Error from server (NotFound): namespaces "team-x" not found`, "-A"},
+		{"Unable to connect to the server: dial tcp 10.0.0.1:6443: connect: connection refused", "VPN"},
+		{"Unable to connect to the server: dial tcp: lookup api.example.com: no such host", "VPN"},
+		{"Unable to connect to the server: net/http: TLS handshake timeout", "VPN"},
+		{`error: the server doesn't have a resource type "widgets"`, ""},
+		{`exec: "vim": executable file not found in $PATH`, ""},
+	}
+
+	for _, tt := range tests {
+		got := interpretKubectlError(tt.stderr)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("interpretKubectlError(%q) = %q, want it to mention %q", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+// Test that a recognised failure opens the errors screen, while other
+// failures and the explained metrics-server error stay on the output screen.
+func TestFailedCommandOpensErrorScreen(t *testing.T) {
+	tests := []struct {
+		cmd    string
+		stderr string
+		screen Screen
+	}{
+		{"kubectl get pods", `Error from server (Forbidden): pods is forbidden: User "dev" cannot list resource "pods"`, ErrorScreen},
+		{"kubectl get widgets", `error: the server doesn't have a resource type "widgets"`, CommandOutputScreen},
+		{"kubectl top pods", "error: Metrics API not available", CommandOutputScreen},
+	}
+
+	for _, tt := range tests {
+		m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, viewport: ui.NewViewport(80, 30), currentCommand: tt.cmd}
+		updated, _ := m.Update(commandExecutedMsg{result: kubectl.CommandResult{Command: tt.cmd, Error: tt.stderr}})
+		m = updated.(Model)
+		if m.currentScreen != tt.screen {
+			t.Errorf("%s failing with %q opened %s, want %s", tt.cmd, tt.stderr, m.currentScreen, tt.screen)
+		}
+		if tt.screen == ErrorScreen && !strings.Contains(m.View(), "What to try") {
+			t.Errorf("expected the errors screen to show a suggestion, got:\n%s", m.View())
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxEventLines bounds how many event lines the stream screen keeps.
+const maxEventLines = 2000
+
+// maxEventBatch is how many already received lines are rendered at once.
+const maxEventBatch = 100
+
+// startEventsStream spawns "kubectl get events -A --watch" and reports its
+// line channel back to Update, which then keeps reading from it.
+func (m Model) startEventsStream() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		lines, errc, err := m.kubectlClient.Stream(ctx, "get", "events", "-A", "--watch")
+		if err != nil {
+			cancel()
+			return eventsStreamEndedMsg{err: err}
+		}
+		return eventsStreamStartedMsg{lines: lines, errc: errc, cancel: cancel}
+	}
+}
+
+// waitForEvents blocks until the stream delivers at least one line and then
+// returns it together with whatever else is already buffered.
+func waitForEvents(lines <-chan string, errc <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return eventsStreamEndedMsg{lines: lines, err: <-errc}
+		}
+
+		batch := []string{line}
+		for len(batch) < maxEventBatch {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return eventLinesMsg{source: lines, lines: batch}
+				}
+				batch = append(batch, line)
+			default:
+				return eventLinesMsg{source: lines, lines: batch}
+			}
+		}
+		return eventLinesMsg{source: lines, lines: batch}
+	}
+}
+
+func (m Model) navigateToEventsStream() Model {
+	m.eventLines = nil
+	m.eventsPaused = false
+	m.heldEventLines = nil
+	m.viewport.SetContent("Waiting for events...")
+	m.viewport.GotoTop()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = EventsStreamScreen
+	return m
+}
+
+// appendEventLines adds streamed lines to the viewport, following the end of
+// the stream unless the user has scrolled up.
+func (m Model) appendEventLines(lines []string) Model {
+	follow := m.viewport.AtBottom() || len(m.eventLines) == 0
+	m.eventLines = append(m.eventLines, lines...)
+	if over := len(m.eventLines) - maxEventLines; over > 0 {
+		m.eventLines = m.eventLines[over:]
+	}
+	m.viewport.SetContent(m.renderEventLines())
+	if follow {
+		m.viewport.GotoBottom()
+	}
+	return m
+}
+
+// renderEventLines joins the event lines, colouring Warning events.
+func (m Model) renderEventLines() string {
+	var b strings.Builder
+	for i, line := range m.eventLines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if isWarningEvent(line) {
+			line = m.GetWarningStyle().Render(line)
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// isWarningEvent reports whether a "kubectl get events" line is of type
+// Warning. Only a whole "Warning" field counts, so messages that merely
+// mention warnings are not highlighted.
+func isWarningEvent(line string) bool {
+	for _, field := range strings.Fields(line) {
+		if field == "Warning" {
+			return true
+		}
+	}
+	return false
+}
+
+// stopEventsStream kills the watch process, if one is running.
+func (m Model) stopEventsStream() Model {
+	if m.stopEvents != nil {
+		m.stopEvents()
+		m.stopEvents = nil
+	}
+	m.eventsSource = nil
+	m.eventsErrc = nil
+	return m
+}
+
+// eventsStreamStopped describes why the stream ended.
+func eventsStreamStopped(err error) error {
+	if err != nil {
+		return fmt.Errorf("Event stream stopped: %v", err)
+	}
+	return fmt.Errorf("Event stream stopped")
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/favourites"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/hotkeys"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 )
 
@@ -14,7 +16,10 @@ import (
 
 func (m Model) isTextInputScreen() bool {
 	switch m.currentScreen {
-	case SaveFavouriteScreen, RenameFavouriteScreen, RenameSavedOutputScreen, NamespaceInputScreen, SaveOutputNameScreen:
+	case SaveFavouriteScreen, RenameFavouriteScreen, RenameSavedOutputScreen, NamespaceInputScreen, SaveOutputNameScreen, KubeconfigInputScreen,
+		TemplateInputScreen, CustomCommandScreen, PluginArgsScreen, CreateNamespaceScreen,
+		GrepPatternInputScreen, JSONPathInputScreen, ContextConfirmationScreen, YAMLEditorScreen, AppendArgsScreen,
+		SettingsInputScreen:
 		return true
 	default:
 		return false
@@ -23,25 +28,23 @@ func (m Model) isTextInputScreen() bool {
 
 func (m Model) tryParseHotkey(key string) (string, bool) {
 	key = strings.TrimSpace(strings.ToUpper(key))
-	switch key {
-	case "F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12":
-		return key, true
-	default:
+	if !hotkeys.IsValidKey(key) {
 		return "", false
 	}
+	return key, true
 }
 
 func (m Model) navigateToHotkeysList() Model {
 	items := []list.Item{}
 	if m.hotkeyStore == nil {
 		items = []list.Item{ui.NewSimpleItem("Hotkeys unavailable", "")}
-		m.list = ui.NewList(items, "Hotkeys", m.width, m.height-4)
+		m.list = ui.NewList(items, "Hotkeys", m.width, m.listHeight())
 		m.previousScreen = m.currentScreen
 		m.currentScreen = HotkeysListScreen
 		return m
 	}
 
-	keys := []string{"F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12"}
+	keys := hotkeys.Keys
 	for _, k := range keys {
 		if b, ok := m.hotkeyStore.Get(k); ok {
 			items = append(items, ui.NewSimpleItem(k, b.Name))
@@ -52,7 +55,7 @@ func (m Model) navigateToHotkeysList() Model {
 	if len(items) == 0 {
 		items = []list.Item{ui.NewSimpleItem("No hotkeys bound", "")}
 	}
-	m.list = ui.NewList(items, "Hotkeys ('d'=unbind, Esc=back)", m.width, m.height-4)
+	m.list = ui.NewList(items, "Hotkeys ('d'=unbind, Esc=back)", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = HotkeysListScreen
 	return m
@@ -64,10 +67,25 @@ func (m Model) navigateToFavouritesList() Model {
 		return m.navigateToMainMenu()
 	}
 
-	favs := m.favStore.List()
-	items := make([]list.Item, len(favs))
-	for i, fav := range favs {
-		items[i] = ui.NewSimpleItem(fav.Name, fav.Command)
+	// Groups are only listed once a favourite has been put in one
+	groups := m.favStore.Groups()
+	if len(groups) <= 1 {
+		m.favouriteGroup = ""
+	} else if m.favouriteGroup != "" && !containsString(groups, m.favouriteGroup) {
+		m.favouriteGroup = ""
+	}
+	if len(groups) > 1 && m.favouriteGroup == "" {
+		return m.navigateToFavouriteGroups(groups)
+	}
+
+	var items []list.Item
+	m.favouriteIndices = nil
+	for i, fav := range m.favStore.List() {
+		if m.favouriteGroup != "" && fav.GroupName() != m.favouriteGroup {
+			continue
+		}
+		items = append(items, ui.NewSimpleItem(fav.Name, fav.Command))
+		m.favouriteIndices = append(m.favouriteIndices, i)
 	}
 
 	if len(items) == 0 {
@@ -76,12 +94,63 @@ func (m Model) navigateToFavouritesList() Model {
 		}
 	}
 
-	m.list = ui.NewList(items, "Favourites (Enter=run, 'd'=delete, 'r'=rename, 'h'=bind hotkey)", m.width, m.height-4)
+	title := "Favourites (Enter=run, 'd'=delete, 'r'=rename, 'h'=bind hotkey)"
+	if m.favouriteGroup != "" {
+		title = "Favourites › " + m.favouriteGroup + " (Enter=run, 'd'=delete, 'r'=rename, 'h'=bind hotkey)"
+	}
+	m.list = ui.NewList(items, title, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = FavouritesListScreen
+	return m
+}
+
+// navigateToFavouriteGroups lists the favourite groups; entering one lists
+// its favourites.
+func (m Model) navigateToFavouriteGroups(groups []string) Model {
+	counts := map[string]int{}
+	for _, fav := range m.favStore.List() {
+		counts[fav.GroupName()]++
+	}
+	items := make([]list.Item, len(groups))
+	for i, g := range groups {
+		noun := "favourites"
+		if counts[g] == 1 {
+			noun = "favourite"
+		}
+		items[i] = ui.NewSimpleItem(favouriteGroupMarker+g, fmt.Sprintf("%d %s", counts[g], noun))
+	}
+
+	m.favouriteIndices = nil
+	m.list = ui.NewList(items, "Favourites (Enter=open group)", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = FavouritesListScreen
 	return m
 }
 
+// favouriteGroupMarker prefixes group names in the favourites list
+const favouriteGroupMarker = "📁 "
+
+// selectedFavouriteIndex returns the store index of the highlighted
+// favourite. It is false on the group list and on placeholder items.
+func (m Model) selectedFavouriteIndex() (int, bool) {
+	idx := m.list.Index()
+	if m.favStore == nil || idx < 0 || idx >= len(m.favouriteIndices) {
+		return 0, false
+	}
+	return m.favouriteIndices[idx], true
+}
+
+// splitFavouriteName splits "group/name", as typed when saving or renaming
+// a favourite, into its group and name. grouped is false without a slash.
+func splitFavouriteName(input string) (group, name string, grouped bool) {
+	input = strings.TrimSpace(input)
+	i := strings.Index(input, "/")
+	if i < 0 {
+		return "", input, false
+	}
+	return strings.TrimSpace(input[:i]), strings.TrimSpace(input[i+1:]), true
+}
+
 func (m Model) saveFavourite(fav favourites.Favourite) tea.Cmd {
 	return func() tea.Msg {
 		err := m.favStore.Add(fav)
@@ -96,9 +165,38 @@ func (m Model) deleteFavourite(idx int) tea.Cmd {
 	}
 }
 
-func (m Model) renameFavourite(idx int, newName string) tea.Cmd {
+// renameFavourite renames a favourite and, when group is not empty, moves
+// it into that group.
+func (m Model) renameFavourite(idx int, newName, group string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.favStore.Rename(idx, newName)
+		if err == nil && group != "" {
+			err = m.favStore.SetGroup(idx, group)
+		}
 		return favouriteRenamedMsg{err: err}
 	}
 }
+
+func (m Model) loadCommandVocabulary() tea.Cmd {
+	return withSpinner("Checking the command…", m.fetchCommandVocabulary)
+}
+
+// fetchCommandVocabulary looks up the installed plugins and the resource
+// types the cluster serves. Either may be missing if kubectl fails to list it.
+func (m Model) fetchCommandVocabulary() tea.Msg {
+	var vocab commandVocabulary
+	plugins, _, err := m.kubectlClient.ListPlugins()
+	if err != nil {
+		logger.Debug("Failed to list plugins for command checks: %v", err)
+	}
+	vocab.plugins = plugins
+	resources, err := m.kubectlClient.ListAPIResources()
+	if err != nil {
+		logger.Debug("Failed to list API resources for command checks: %v", err)
+	}
+	vocab.resources = make(map[string]bool, len(resources))
+	for _, r := range resources {
+		vocab.resources[r] = true
+	}
+	return commandVocabularyLoadedMsg{vocabulary: vocab}
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/favourites"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func favouriteTitles(m Model) string {
+	var titles []string
+	for _, item := range m.list.Items() {
+		titles = append(titles, item.(ui.SimpleItem).Title())
+	}
+	return strings.Join(titles, ",")
+}
+
+// Test that favourites in groups are listed by group, that files written
+// before groups existed load into General, and that actions in a group act
+// on the right favourite.
+func TestFavouriteGroups(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	old := `[{"name":"pods","command":"kubectl get pods"},{"name":"nodes","command":"kubectl get nodes"}]`
+	if err := os.WriteFile(filepath.Join(home, "kube-wizard-favourites.json"), []byte(old), 0644); err != nil {
+		t.Fatalf("failed to write favourites: %v", err)
+	}
+	store, err := favourites.NewStore()
+	if err != nil {
+		t.Fatalf("favourites.NewStore() error = %v", err)
+	}
+
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, favStore: store}
+	m = m.navigateToFavouritesList()
+	if got := favouriteTitles(m); got != "pods,nodes" {
+		t.Fatalf("expected ungrouped favourites to be listed flat, got %s", got)
+	}
+
+	m.currentCommand = "kubectl get pods -n shop"
+	m.textInput.SetValue("Shop / shop pods")
+	updated, cmd := m.handleSaveFavourite()
+	if m = updated.(Model); cmd == nil {
+		t.Fatalf("expected the favourite to be saved, err %v", m.err)
+	}
+	cmd()
+
+	m = m.navigateToFavouritesList()
+	if got := favouriteTitles(m); got != favouriteGroupMarker+"General,"+favouriteGroupMarker+"Shop" {
+		t.Fatalf("expected the group list, got %s", got)
+	}
+	if _, ok := m.selectedFavouriteIndex(); ok {
+		t.Error("a group should not count as a favourite")
+	}
+
+	m.list.Select(1)
+	updated, _ = m.handleFavouriteSelection()
+	m = updated.(Model)
+	if got := favouriteTitles(m); got != "shop pods" {
+		t.Fatalf("expected the Shop group's favourites, got %s", got)
+	}
+	if idx, ok := m.selectedFavouriteIndex(); !ok || idx != 2 {
+		t.Errorf("selectedFavouriteIndex() = %d, %v; want 2", idx, ok)
+	}
+
+	// Renaming keeps the group shown in the input; General moves it back
+	m = m.navigateToRenameFavourite(2)
+	if got := m.textInput.Value(); got != "Shop/shop pods" {
+		t.Errorf("expected the rename input to show the group, got %q", got)
+	}
+	m.textInput.SetValue("General/shop pods")
+	_, cmd = m.handleRenameFavourite()
+	cmd()
+	if fav, _ := store.Get(2); fav.Group != "" {
+		t.Errorf("expected the favourite back in General, got group %q", fav.Group)
+	}
+
+	m.favouriteGroup = "Shop"
+	m = m.navigateToFavouritesList()
+	if m.favouriteGroup != "" || favouriteTitles(m) != "pods,nodes,shop pods" {
+		t.Errorf("expected a flat list once the group is empty, got %s", favouriteTitles(m))
+	}
+}
+
+func TestCommandProblem(t *testing.T) {
+	tests := map[string]string{
+		"kubectl get pods -n shop":                        "",
+		"kubectl get deploy,svc":                          "",
+		"kubectl describe certificates.cert-manager.io x": "",
+		"kubectl delete pod/web":                          "",
+		"kubectl logs {pod} -n {ns}":                      "",
+		"kubectl get {kind}":                              "",
+		"kubectl get pods -o jsonpath='{.items[*]}'":      "",
+		"kubectl gte pods":                                `unknown verb "gte"`,
+		"kubectl get podz":                                `unknown resource type "podz"`,
+		"kubectl get pods -o jsonpath='{.items":           "unterminated ' quote in command",
+		"kubectl -n shop get pods":                        "",
+		"kubectl --context=prod -n shop get leases":       "",
+		"kubectl get csr,rc":                              "",
+		"kubectl get ingressclasses,priorityclasses":      "",
+		"kubectl -n shop gte pods":                        `unknown verb "gte"`,
+		"kubectl view-secret db":                          `unknown verb "view-secret"`,
+		"kubectl get widgets":                             `unknown resource type "widgets"`,
+	}
+	for cmd, want := range tests {
+		if got := commandProblem(cmd, nil); got != want {
+			t.Errorf("commandProblem(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+
+	// Plugins on PATH and the cluster's own types are accepted too
+	vocab := &commandVocabulary{plugins: []string{"view-secret", "cert manager"}, resources: map[string]bool{"widgets": true}}
+	tests = map[string]string{
+		"kubectl view-secret db":         "",
+		"kubectl cert manager status":    "",
+		"kubectl get widgets":            "",
+		"kubectl get podz":               `unknown resource type "podz"`,
+		"kubectl -n shop view-secret db": "",
+	}
+	for cmd, want := range tests {
+		if got := commandProblem(cmd, vocab); got != want {
+			t.Errorf("commandProblem(%q) with plugins and cluster types = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+// Test that a likely typo is reported before saving a favourite once the
+// cluster's own types have been checked, and that saving again keeps it
+// anyway.
+func TestSaveFavouriteWarnsAboutInvalidCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *api-resources*) echo 'widgets   wd   example.com/v1   true   Widget' ;;\n" +
+		"  *plugin*) echo kubectl-view_secret ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	store, err := favourites.NewStore()
+	if err != nil {
+		t.Fatalf("favourites.NewStore() error = %v", err)
+	}
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, favStore: store,
+		kubectlClient: kubectl.NewClient(), currentCommand: "kubectl get podz"}
+	m = m.navigateToSaveFavourite()
+	m.textInput.SetValue("typo")
+
+	updated, cmd := m.handleSaveFavourite()
+	m = updated.(Model)
+	if cmd == nil || m.err != nil {
+		t.Fatalf("expected plugins and cluster types to be looked up first, got %v", m.err)
+	}
+	updated, cmd = m.Update(m.fetchCommandVocabulary())
+	m = updated.(Model)
+	if cmd != nil || m.err == nil || !strings.Contains(m.err.Error(), "may be invalid") {
+		t.Fatalf("expected a warning before saving, got %v", m.err)
+	}
+	if !m.vocabulary.knowsResource("wd") || !m.vocabulary.knowsVerb("view-secret") {
+		t.Errorf("expected the cluster types and plugins to be kept, got %+v", m.vocabulary)
+	}
+
+	_, cmd = m.handleSaveFavourite()
+	if cmd == nil {
+		t.Fatal("expected the second Enter to save anyway")
+	}
+	cmd()
+	if len(store.List()) != 1 {
+		t.Errorf("expected the favourite to be saved, got %d", len(store.List()))
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+// Deep links: starting the wizard part-way through a command flow.
+
+// WithGoto starts the model at the action named by target, given as
+// "<resource>/<action>" (e.g. "pods/logs" or "deployments/view-yaml"), as if
+// the resource and action had been picked from the menus. Actions that need
+// a name fetch the names from Init. Esc walks back through the menus as usual.
+func (m Model) WithGoto(target string) (Model, error) {
+	resourceName, actionName, ok := strings.Cut(strings.TrimSpace(target), "/")
+	if !ok || resourceName == "" || actionName == "" {
+		return m, fmt.Errorf("invalid --goto %q: want <resource>/<action>, e.g. pods/logs", target)
+	}
+
+	var resource ResourceType
+	found := false
+	for _, r := range allResourceTypes() {
+		if normalizeGotoName(r.String()) == normalizeGotoName(resourceName) {
+			resource, found = r, true
+			break
+		}
+	}
+	if !found {
+		return m, fmt.Errorf("invalid --goto %q: unknown resource %q", target, resourceName)
+	}
+
+	m = m.navigateToResourceSelection()
+	m.selectedResource = resource
+	m = m.navigateToActionSelection()
+
+	var actions []string
+	for i, item := range m.list.Items() {
+		title := item.(ui.SimpleItem).Title()
+		if normalizeGotoName(title) != normalizeGotoName(actionName) {
+			actions = append(actions, strings.ToLower(strings.ReplaceAll(trimMenuNote(title), " ", "-")))
+			continue
+		}
+		m.list.Select(i)
+		updated, cmd := m.handleActionSelection()
+		m = updated.(Model)
+		m.initCmd = cmd
+		return m, nil
+	}
+	return m, fmt.Errorf("invalid --goto %q: %s has no action %q (available: %s)",
+		target, strings.ToLower(resource.String()), actionName, strings.Join(actions, ", "))
+}
+
+// allResourceTypes lists the resource types offered by the resource menu.
+func allResourceTypes() []ResourceType {
+	return []ResourceType{
+		ResourcePods, ResourceDeployments, ResourceServices, ResourceNodes,
+		ResourceConfigMaps, ResourceSecrets, ResourceIngress,
+	}
+}
+
+// normalizeGotoName folds a menu title or --goto part for comparison, so
+// "Port Forward" matches "port-forward" and "Top (Metrics)" matches "top".
+func normalizeGotoName(name string) string {
+	name = strings.ToLower(trimMenuNote(name))
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name)
+}
+
+// trimMenuNote drops a parenthesised note from a menu title, e.g. the
+// "(Metrics)" of "Top (Metrics)".
+func trimMenuNote(title string) string {
+	if i := strings.Index(title, " ("); i >= 0 {
+		return title[:i]
+	}
+	return title
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func TestWithGoto(t *testing.T) {
+	tests := []struct {
+		target      string
+		resource    ResourceType
+		action      Action
+		screen      Screen
+		wantInitCmd bool
+	}{
+		{"pods/logs", ResourcePods, ActionLogs, ActionSelectionScreen, true},
+		{"Deployments/View-YAML", ResourceDeployments, ActionViewYAML, ActionSelectionScreen, true},
+		{"pods/port_forward", ResourcePods, ActionPortForward, ActionSelectionScreen, true},
+		{"nodes/top", ResourceNodes, ActionTop, FlagsSelectionScreen, false},
+	}
+
+	for _, tt := range tests {
+		m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40}
+		m, err := m.WithGoto(tt.target)
+		if err != nil {
+			t.Fatalf("WithGoto(%q) returned error: %v", tt.target, err)
+		}
+		if m.selectedResource != tt.resource || m.selectedAction != tt.action || m.currentScreen != tt.screen {
+			t.Errorf("WithGoto(%q) = %s/%s on %s, want %s/%s on %s", tt.target,
+				m.selectedResource, m.selectedAction, m.currentScreen, tt.resource, tt.action, tt.screen)
+		}
+		if (m.initCmd != nil) != tt.wantInitCmd {
+			t.Errorf("WithGoto(%q): Init command set = %v, want %v", tt.target, m.initCmd != nil, tt.wantInitCmd)
+		}
+	}
+}
+
+func TestWithGotoRejectsUnknownTargets(t *testing.T) {
+	tests := map[string]string{
+		"pods":          "want <resource>/<action>",
+		"widgets/get":   `unknown resource "widgets"`,
+		"nodes/logs":    "nodes has no action",
+		"secrets/exec/": "secrets has no action",
+	}
+
+	for target, want := range tests {
+		m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40}
+		if _, err := m.WithGoto(target); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("WithGoto(%q) error = %v, want it to contain %q", target, err, want)
+		}
+	}
+}
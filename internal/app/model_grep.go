@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	grepFlagLabel       = "grep <pattern>"
+	grepFlagDescription = "Only show output lines matching a regular expression"
+)
+
+// toggleGrepFilter clears the output filter, or prompts for a pattern when
+// none is set. The flags list is kept so it can be restored afterwards.
+func (m Model) toggleGrepFilter() Model {
+	if m.outputFilter != "" {
+		m.outputFilter = ""
+		return m.setGrepFlagItem("[ ] "+grepFlagLabel, grepFlagDescription)
+	}
+
+	m.flagsList = m.list
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = "Pattern (e.g. nginx|redis)"
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = GrepPatternInputScreen
+	return m
+}
+
+// handleGrepPatternInput stores the typed pattern and ticks the grep item.
+// The pattern never reaches a shell, so it is not sanitized.
+func (m Model) handleGrepPatternInput() (tea.Model, tea.Cmd) {
+	pattern := strings.TrimSpace(m.textInput.Value())
+	if pattern == "" {
+		return m, nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		m.err = fmt.Errorf("invalid pattern: %v", err)
+		return m, nil
+	}
+
+	m.err = nil
+	m.outputFilter = pattern
+	m.textInput.Blur()
+	m = m.restoreFlagsList()
+	return m.setGrepFlagItem("[x] "+grepFlagLabel, "Only lines matching "+pattern), nil
+}
+
+// setGrepFlagItem replaces the grep entry of the flags list.
+func (m Model) setGrepFlagItem(title, desc string) Model {
+	for i, item := range m.list.Items() {
+		if stripCheckbox(item.(ui.SimpleItem).Title()) == grepFlagLabel {
+			m.list.SetItem(i, ui.NewSimpleItem(title, desc))
+			break
+		}
+	}
+	return m
+}
+
+// filterOutputLines keeps the lines of output matching pattern, plus the
+// column header of table output, and summarises how many lines were kept.
+// Blank lines are dropped and not counted.
+func filterOutputLines(output, pattern string) (string, string) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return output, ""
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	var kept []string
+	if len(lines) > 0 && isTableHeader(lines[0]) {
+		kept = append(kept, lines[0])
+		lines = lines[1:]
+	}
+	matched, total := 0, 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		total++
+		if re.MatchString(line) {
+			kept = append(kept, line)
+			matched++
+		}
+	}
+
+	summary := fmt.Sprintf("(filtered: %d of %d lines)", matched, total)
+	if len(kept) == 0 {
+		return "", summary
+	}
+	return strings.Join(kept, "\n") + "\n", summary
+}
+
+// outputSize describes the size of kubectl's output, e.g. "40 lines,
+// 2310 chars", followed by the filter summary when a grep filter is on. body
+// is measured as kubectl printed it, without the labels the wizard adds.
+func outputSize(body, filterSummary string) string {
+	body = strings.TrimRight(body, "\n")
+	lines := 0
+	if body != "" {
+		lines = strings.Count(body, "\n") + 1
+	}
+	size := fmt.Sprintf("%s, %s", pluralize(lines, "line"), pluralize(utf8.RuneCountInString(body), "char"))
+	if filterSummary != "" {
+		size += " " + filterSummary
+	}
+	return size
+}
+
+// pluralize formats n with its noun, adding an "s" unless n is 1.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// isTableHeader reports whether line looks like the column header kubectl
+// prints above table output, e.g. "NAME   READY   STATUS".
+func isTableHeader(line string) bool {
+	return strings.HasPrefix(line, "NAME") || strings.HasPrefix(line, "NAMESPACE") ||
+		strings.HasPrefix(line, "LAST SEEN")
+}
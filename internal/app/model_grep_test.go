@@ -0,0 +1,104 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterOutputLines(t *testing.T) {
+	output := "NAMESPACE   NAME        READY\n" +
+		"default     nginx-1     1/1\n" +
+		"default     redis-0     1/1\n" +
+		"shop        nginx-2     0/1\n\n"
+
+	got, summary := filterOutputLines(output, "nginx")
+	want := "NAMESPACE   NAME        READY\ndefault     nginx-1     1/1\nshop        nginx-2     0/1\n"
+	if got != want {
+		t.Errorf("filterOutputLines() = %q, want %q", got, want)
+	}
+	if summary != "(filtered: 2 of 3 lines)" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	got, summary = filterOutputLines("line one\nline two\n", "^three")
+	if got != "" || summary != "(filtered: 0 of 2 lines)" {
+		t.Errorf("expected nothing to match, got %q %q", got, summary)
+	}
+}
+
+// Test that the grep item prompts for a pattern, ticks itself and filters
+// the output of the command that is then run.
+func TestGrepFlagFiltersOutput(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourcePods, selectedAction: ActionGet, viewport: ui.NewViewport(80, 20)}
+	m = m.navigateToFlagsSelection()
+	for i, item := range m.list.Items() {
+		if stripCheckbox(item.(ui.SimpleItem).Title()) == grepFlagLabel {
+			m.list.Select(i)
+		}
+	}
+
+	m = m.toggleFlag()
+	if m.currentScreen != GrepPatternInputScreen {
+		t.Fatalf("expected the pattern prompt, got %s", m.currentScreen)
+	}
+	m.textInput.SetValue("web-")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != FlagsSelectionScreen || m.outputFilter != "web-" {
+		t.Fatalf("expected the flags list with the filter set, got %s (%q)", m.currentScreen, m.outputFilter)
+	}
+	if title := m.list.SelectedItem().(ui.SimpleItem).Title(); title != "[x] "+grepFlagLabel {
+		t.Errorf("expected the grep item to be ticked, got %q", title)
+	}
+	if len(m.selectedFlags) != 0 {
+		t.Errorf("expected grep not to become a kubectl flag, got %v", m.selectedFlags)
+	}
+
+	m.currentCommand = "kubectl get pods"
+	updated, _ = m.Update(commandExecutedMsg{result: kubectl.CommandResult{
+		Output: "NAME    READY\nweb-1   1/1\ndb-0    1/1\n",
+	}})
+	m = updated.(Model)
+	if m.currentOutputContent != "Output:\nNAME    READY\nweb-1   1/1\n" {
+		t.Errorf("unexpected filtered output %q", m.currentOutputContent)
+	}
+	if m.outputFilterSummary != "(filtered: 1 of 2 lines)" {
+		t.Errorf("unexpected summary %q", m.outputFilterSummary)
+	}
+	m.ready = true
+	if view := m.View(); !strings.Contains(view, "2 lines, 25 chars (filtered: 1 of 2 lines)") {
+		t.Errorf("expected the size and filter in the footer, got:\n%s", view)
+	}
+}
+
+// Test that the size of a failed command counts what kubectl printed, not the
+// labels the wizard puts above its error and output.
+func TestOutputSizeOfFailedCommand(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, viewport: ui.NewViewport(80, 30),
+		currentCommand: "kubectl get pods"}
+	updated, _ := m.Update(commandExecutedMsg{result: kubectl.CommandResult{Error: "boom\n", Output: "web-1\n"}})
+	if m = updated.(Model); m.currentOutputSize != "2 lines, 10 chars" {
+		t.Errorf("currentOutputSize = %q, want 2 lines, 10 chars", m.currentOutputSize)
+	}
+}
+
+func TestOutputSize(t *testing.T) {
+	tests := []struct {
+		content, summary, want string
+	}{
+		{"NAME\nweb-1\n", "", "2 lines, 10 chars"},
+		{"", "", "0 lines, 0 chars"},
+		{"naïve\n", "(filtered: 1 of 4 lines)", "1 line, 5 chars (filtered: 1 of 4 lines)"},
+	}
+	for _, tt := range tests {
+		if got := outputSize(tt.content, tt.summary); got != tt.want {
+			t.Errorf("outputSize(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
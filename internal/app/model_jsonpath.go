@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	jsonPathFlagLabel       = "-o jsonpath=<expr>"
+	jsonPathFlagDescription = "Print only the fields selected by a JSONPath expression"
+	jsonPathFlagPrefix      = "-o jsonpath="
+)
+
+// jsonPathFlag returns the flag for expr, quoted so that brackets, spaces
+// and quotes in the expression reach kubectl as a single argument.
+func jsonPathFlag(expr string) string {
+	return jsonPathFlagPrefix + kubectl.QuoteArg(expr)
+}
+
+// toggleJSONPathFlag unticks the jsonpath flag, or prompts for an
+// expression when none is set. The flags list is kept so it can be restored
+// afterwards.
+func (m Model) toggleJSONPathFlag() Model {
+	for i, f := range m.selectedFlags {
+		if strings.HasPrefix(f, jsonPathFlagPrefix) {
+			m.selectedFlags = append(m.selectedFlags[:i], m.selectedFlags[i+1:]...)
+			return m.setJSONPathFlagItem("[ ] "+jsonPathFlagLabel, jsonPathFlagDescription)
+		}
+	}
+
+	m.flagsList = m.list
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = "{.items[*].metadata.name}"
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = JSONPathInputScreen
+	return m
+}
+
+// handleJSONPathInput ticks the jsonpath flag with the typed expression.
+func (m Model) handleJSONPathInput() (tea.Model, tea.Cmd) {
+	expr := strings.TrimSpace(m.textInput.Value())
+	if expr == "" {
+		m.err = fmt.Errorf("enter a JSONPath expression, e.g. {.items[*].metadata.name}")
+		return m, nil
+	}
+
+	flag := jsonPathFlag(expr)
+	m.err = nil
+	m.selectedFlags = append(m.selectedFlags, flag)
+	m.textInput.Blur()
+	m = m.restoreFlagsList()
+	return m.setJSONPathFlagItem("[x] "+flag, jsonPathFlagDescription), nil
+}
+
+// setJSONPathFlagItem replaces the jsonpath entry of the flags list.
+func (m Model) setJSONPathFlagItem(title, desc string) Model {
+	for i, item := range m.list.Items() {
+		if strings.HasPrefix(stripCheckbox(item.(ui.SimpleItem).Title()), jsonPathFlagPrefix) {
+			m.list.SetItem(i, ui.NewSimpleItem(title, desc))
+			break
+		}
+	}
+	return m
+}
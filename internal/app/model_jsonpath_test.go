@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that the jsonpath item prompts for an expression, refuses an empty
+// one and adds a quoted flag that survives the command tokenizer.
+func TestJSONPathFlag(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourcePods, selectedAction: ActionGet}
+	m = m.navigateToFlagsSelection()
+	for i, item := range m.list.Items() {
+		if stripCheckbox(item.(ui.SimpleItem).Title()) == jsonPathFlagLabel {
+			m.list.Select(i)
+		}
+	}
+
+	m = m.toggleFlag()
+	if m.currentScreen != JSONPathInputScreen {
+		t.Fatalf("expected the expression prompt, got %s", m.currentScreen)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = updated.(Model); m.currentScreen != JSONPathInputScreen || m.err == nil {
+		t.Fatalf("expected an empty expression to be refused, got %s (err %v)", m.currentScreen, m.err)
+	}
+
+	expr := `{range .items[*]}{.metadata.name}{"\n"}{end}`
+	m.textInput.SetValue(expr)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != FlagsSelectionScreen || len(m.selectedFlags) != 1 {
+		t.Fatalf("expected the flag to be ticked, got %s with %q", m.currentScreen, m.selectedFlags)
+	}
+	if title := m.list.SelectedItem().(ui.SimpleItem).Title(); title != "[x] "+m.selectedFlags[0] {
+		t.Errorf("expected the item to show the flag, got %q", title)
+	}
+
+	cmd, err := buildCommand(m.selectedResource, m.selectedAction, "", m.selectedFlags)
+	if err != nil {
+		t.Fatalf("buildCommand() error = %v", err)
+	}
+	args, err := kubectl.SplitArgs(cmd)
+	if err != nil || args[len(args)-1] != "jsonpath="+expr {
+		t.Errorf("expected the expression as one argument, got %q (%v) from %q", args, err, cmd)
+	}
+	if isTableCommand(cmd) {
+		t.Errorf("jsonpath output should not be drawn as a table")
+	}
+
+	m = m.toggleFlag()
+	if len(m.selectedFlags) != 0 || stripCheckbox(m.list.SelectedItem().(ui.SimpleItem).Title()) != jsonPathFlagLabel {
+		t.Errorf("expected toggling again to clear the flag, got %q", m.selectedFlags)
+	}
+}
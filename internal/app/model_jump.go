@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Type-to-jump for the contexts and namespaces lists: the jump key, '/' by
+// default, starts typing the start of a name, shown below the list, so that
+// other keys keep their meaning, including the list's own j/k/g/G navigation.
+// Typing goes on until Esc or Enter.
+
+// typeToJump moves the selection of the contexts or namespaces list to the
+// first name starting with the characters typed since the jump key. It
+// reports false for keys it leaves to handleKeyPress: anything on other
+// screens, every key but the jump key unless a name is being typed, and
+// Enter and the arrow keys while it is. Esc stops typing without leaving the
+// list and Enter stops it as it acts on the highlighted name.
+func (m Model) typeToJump(msg tea.KeyMsg) (Model, bool) {
+	if m.currentScreen != ContextsListScreen && m.currentScreen != NamespacesListScreen {
+		m.jumping = false
+		return m, false
+	}
+
+	if !m.jumping {
+		if msg.Type != tea.KeyRunes || !key.Matches(msg, m.keys.Jump) {
+			return m, false
+		}
+		m.jumping = true
+		m.jumpPrefix = ""
+		return m, true
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.jumping = false
+		return m, true
+	case tea.KeyEnter:
+		m.jumping = false
+		return m, false
+	case tea.KeyBackspace:
+		if prefix := []rune(m.jumpPrefix); len(prefix) > 0 {
+			m.jumpPrefix = string(prefix[:len(prefix)-1])
+		}
+	case tea.KeyRunes:
+		m.jumpPrefix += string(msg.Runes)
+	default:
+		return m, false
+	}
+
+	if idx := jumpIndex(m.list.Items(), m.jumpPrefix); idx >= 0 {
+		m.list.Select(idx)
+	}
+	return m, true
+}
+
+// jumpIndex returns the index of the first item whose name starts with
+// prefix, ignoring case and the pinned marker, or -1 when none does.
+func jumpIndex(items []list.Item, prefix string) int {
+	prefix = strings.ToLower(prefix)
+	for i, item := range items {
+		simple, ok := item.(ui.SimpleItem)
+		if !ok {
+			continue
+		}
+		name := strings.TrimPrefix(simple.Title(), pinnedMarker)
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			return i
+		}
+	}
+	return -1
+}
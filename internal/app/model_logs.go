@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLogViewBytes limits how much of the log file is loaded into the viewer.
+const maxLogViewBytes = 64 * 1024
+
+// WithLogPath returns a copy of the model that knows where the log file lives.
+func (m Model) WithLogPath(path string) Model {
+	m.logPath = path
+	return m
+}
+
+// readLogTail reads at most maxBytes from the end of the file at path. When
+// the file is truncated, the first partial line is dropped.
+func readLogTail(path string, maxBytes int64) (string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", false, err
+	}
+
+	offset := int64(0)
+	truncated := info.Size() > maxBytes
+	if truncated {
+		offset = info.Size() - maxBytes
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return "", false, err
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", false, err
+	}
+
+	content := string(data)
+	if truncated {
+		if idx := strings.Index(content, "\n"); idx >= 0 {
+			content = content[idx+1:]
+		}
+	}
+	return content, truncated, nil
+}
+
+// loadLogs reads the tail of the log file for the log viewer.
+func (m Model) loadLogs() tea.Cmd {
+	path := m.logPath
+	return func() tea.Msg {
+		if path == "" {
+			return logsLoadedMsg{err: fmt.Errorf("logging is not available")}
+		}
+		content, truncated, err := readLogTail(path, maxLogViewBytes)
+		if err != nil {
+			return logsLoadedMsg{err: fmt.Errorf("failed to read log file: %w", err)}
+		}
+		return logsLoadedMsg{content: content, truncated: truncated}
+	}
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleMouse processes mouse input. The scroll wheel scrolls the active
+// viewport or moves the list cursor; clicking a list row selects it and
+// clicking the already selected row activates it, like pressing Enter.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch m.currentScreen {
+	case CommandOutputScreen, SavedOutputViewScreen, CommandHelpScreen, DryRunScreen, LogViewerScreen,
+		ClusterConnectivityScreen, ClusterInfoScreen, EventsStreamScreen, PodDebugScreen, PinnedOutputViewScreen, ErrorScreen:
+		m.viewport, cmd = ui.UpdateViewport(m.viewport, msg)
+		return m, cmd
+	case SavedOutputVersionsScreen, HotkeyBindScreen:
+		return m, nil
+	}
+	if m.isTextInputScreen() || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.list.CursorUp()
+	case tea.MouseButtonWheelDown:
+		m.list.CursorDown()
+	case tea.MouseButtonLeft:
+		idx, ok := m.listIndexAt(msg.Y)
+		if !ok {
+			return m, nil
+		}
+		if idx == m.list.Index() {
+			return m.handleEnterKey()
+		}
+		m.list.Select(idx)
+	}
+
+	return m, nil
+}
+
+// listIndexAt maps a terminal row to the list item rendered there. Only the
+// lines drawn above and below the list are rendered to find where it starts.
+func (m Model) listIndexAt(y int) (int, bool) {
+	above := m.viewHeader()
+	switch m.currentScreen {
+	case CommandPreviewScreen:
+		above += m.commandPreviewHeader()
+	case DeleteConfirmationScreen:
+		above += m.deleteConfirmationHeader()
+	}
+	top := strings.Count(above, "\n")
+
+	// Views taller than the terminal lose their first lines when rendered
+	if lines := top + m.list.Height() + strings.Count(m.viewFooter(), "\n"); m.height > 0 && lines > m.height {
+		top -= lines - m.height
+	}
+
+	return ui.ListIndexAt(m.list, y-top)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -10,7 +11,9 @@ import (
 
 // Navigation handlers for the main application flow.
 
-func (m Model) navigateToMainMenu() Model {
+// mainMenuItems lists the main menu entries, including Pinned Outputs once
+// something has been pinned and leaving out Plugins in read-only mode.
+func (m Model) mainMenuItems() []list.Item {
 	items := []list.Item{
 		ui.NewSimpleItem("Run Command", "Execute kubectl commands"),
 		ui.NewSimpleItem("Custom Command", "Build an advanced kubectl command"),
@@ -21,18 +24,46 @@ func (m Model) navigateToMainMenu() Model {
 		ui.NewSimpleItem("Hotkeys", "Manage hotkey bindings"),
 		ui.NewSimpleItem("Contexts & Namespaces", "Manage kube contexts and default namespace"),
 		ui.NewSimpleItem("Check Cluster Connectivity", "Verify connection to Kubernetes cluster"),
+		ui.NewSimpleItem("Watch Events", "Stream cluster events live"),
+		ui.NewSimpleItem("Port Forwards", "Start and stop saved port-forwards in the background"),
+		ui.NewSimpleItem("Plugins", "Run installed kubectl plugins (krew)"),
+		ui.NewSimpleItem("View Logs", "Show recent entries from the log file"),
+		ui.NewSimpleItem("Settings", "Change the theme, defaults and safety options"),
 		ui.NewSimpleItem("Exit", "Quit the application"),
 	}
-	m.list = ui.NewList(items, "Kubernetes Wizard", m.width, m.height-4)
+	if len(m.pinnedOutputs) > 0 {
+		// Listed only once something has been pinned, after Saved Outputs
+		pinned := ui.NewSimpleItem("Pinned Outputs", fmt.Sprintf("Reopen the %d output(s) pinned this session", len(m.pinnedOutputs)))
+		for i, item := range items {
+			if item.(ui.SimpleItem).Title() == "Saved Outputs" {
+				items = append(items[:i+1], append([]list.Item{pinned}, items[i+1:]...)...)
+				break
+			}
+		}
+	}
+	return m.withoutMutatingItems(items)
+}
+
+func (m Model) navigateToMainMenu() Model {
+	m.list = ui.NewList(m.mainMenuItems(), "Kubernetes Wizard", m.width, m.listHeight())
+
+	// Leaving a live view stops its background process
+	m = m.stopEventsStream()
+	m = m.clearCommandSuggestions()
 
 	// Reset wizard selections when returning to the main menu to avoid stale state
 	m.selectedResource = 0
 	m.selectedAction = 0
 	m.selectedResourceName = ""
 	m.selectedFlags = nil
+	m.logsContainer = ""
+	m = m.clearFollowedPods()
 	m.customNamespace = ""
 	m.needsNamespaceInput = false
+	m.implicitNamespaceCommand = ""
+	m.outputFilter = ""
 	m.currentCommand = ""
+	m.commandStdin = ""
 
 	m.previousScreen = m.currentScreen
 	m.currentScreen = MainMenuScreen
@@ -46,7 +77,7 @@ func (m Model) navigateToCommandHistory() Model {
 		items = []list.Item{
 			ui.NewSimpleItem("History unavailable", "Command history could not be loaded"),
 		}
-		m.list = ui.NewList(items, "Command History", m.width, m.height-4)
+		m.list = ui.NewList(items, "Command History", m.width, m.listHeight())
 		m.previousScreen = m.currentScreen
 		m.currentScreen = CommandHistoryScreen
 		return m
@@ -59,11 +90,14 @@ func (m Model) navigateToCommandHistory() Model {
 		}
 	} else {
 		for _, entry := range entries {
-			timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
+			timestamp := humanizeSince(entry.Timestamp)
+			if m.historyAbsoluteTime {
+				timestamp = entry.Timestamp.Format("2006-01-02 15:04:05")
+			}
 			items = append(items, ui.NewSimpleItem(entry.Command, timestamp))
 		}
 	}
-	m.list = ui.NewList(items, "Command History (Enter=run, 's'=save as favourite, Esc=back)", m.width, m.height-4)
+	m.list = ui.NewList(items, "Command History (Enter=run, 's'=save as favourite, 'e'=export as script, 'T'=toggle times, Esc=back)", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = CommandHistoryScreen
 	return m
@@ -73,6 +107,9 @@ func (m Model) navigateToCustomCommand() Model {
 	// Reuse the text input to capture a free-form kubectl command.
 	m.textInput.SetValue("")
 	m.textInput.Placeholder = "e.g. get pods -n default"
+	m.textInput.ShowSuggestions = true
+	m.textInput.SetSuggestions(completionVerbs)
+	m.completionNames = nil
 	m.textInput.Focus()
 	m.previousScreen = m.currentScreen
 	m.currentScreen = CustomCommandScreen
@@ -83,11 +120,16 @@ func (m Model) navigateToCustomCommand() Model {
 func (m Model) navigateToResourceSelection() Model {
 	// Starting a new command flow: reset selections from any previous run
 	m.selectedResource = 0
+	m.selectedResources = nil
 	m.selectedAction = 0
 	m.selectedResourceName = ""
 	m.selectedFlags = nil
+	m.logsContainer = ""
+	m = m.clearFollowedPods()
 	m.customNamespace = ""
 	m.needsNamespaceInput = false
+	m.implicitNamespaceCommand = ""
+	m.outputFilter = ""
 	m.currentCommand = ""
 
 	items := []list.Item{
@@ -99,7 +141,14 @@ func (m Model) navigateToResourceSelection() Model {
 		ui.NewSimpleItem("Secrets", "Inspect secrets (careful: may show sensitive data)"),
 		ui.NewSimpleItem("Ingress", "Inspect ingress resources"),
 	}
-	m.list = ui.NewList(items, "Select Resource Type", m.width, m.height-4)
+	// Most used resource types first; ties keep the order above
+	if m.prefsStore != nil {
+		sort.SliceStable(items, func(i, j int) bool {
+			return m.prefsStore.ResourceUseCount(items[i].(ui.SimpleItem).Title()) >
+				m.prefsStore.ResourceUseCount(items[j].(ui.SimpleItem).Title())
+		})
+	}
+	m.list = ui.NewList(items, "Select Resource Type (Space to tick several for one Get)", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = ResourceSelectionScreen
 	return m
@@ -114,28 +163,44 @@ func (m Model) navigateToActionSelection() Model {
 			ui.NewSimpleItem("Get", "List all pods"),
 			ui.NewSimpleItem("Top (Metrics)", "View CPU/Memory usage and pods"),
 			ui.NewSimpleItem("Describe", "Describe a specific pod"),
+			ui.NewSimpleItem("Debug", "Show a pod's status and events together"),
+			ui.NewSimpleItem("Wait", "Wait until a pod is Ready"),
+			ui.NewSimpleItem("View YAML", "Show the pod YAML read-only"),
+			ui.NewSimpleItem("Owners", "Show the ReplicaSet/Deployment chain that owns a pod"),
+			ui.NewSimpleItem("Quick Actions", "Show and copy a pod's IP, node and images"),
 			ui.NewSimpleItem("Logs", "View logs from a pod"),
 			ui.NewSimpleItem("Exec", "Execute shell in a pod"),
 			ui.NewSimpleItem("Port Forward", "Forward local port to pod"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of a pod to view"),
 			ui.NewSimpleItem("Edit", "Edit pod YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the pod YAML here and apply it"),
+			ui.NewSimpleItem("Restart", "Delete a pod so its controller recreates it"),
 			ui.NewSimpleItem("Delete", "Delete a pod"),
 		}
 	case ResourceDeployments:
 		items = []list.Item{
 			ui.NewSimpleItem("Get", "List all deployments"),
 			ui.NewSimpleItem("Describe", "Describe a specific deployment"),
+			ui.NewSimpleItem("Show Pods", "List the pods a deployment manages and act on one"),
+			ui.NewSimpleItem("Wait", "Wait until a deployment is Available"),
+			ui.NewSimpleItem("View YAML", "Show the deployment YAML read-only"),
 			ui.NewSimpleItem("Logs", "View logs for a deployment"),
 			ui.NewSimpleItem("Exec", "Execute shell in a deployment pod"),
 			ui.NewSimpleItem("Port Forward", "Forward local port to deployment"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of a deployment to view"),
 			ui.NewSimpleItem("Edit", "Edit deployment YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the deployment YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete a deployment"),
 		}
 	case ResourceServices:
 		items = []list.Item{
 			ui.NewSimpleItem("Get", "List all services"),
 			ui.NewSimpleItem("Describe", "Describe a specific service"),
+			ui.NewSimpleItem("View YAML", "Show the service YAML read-only"),
 			ui.NewSimpleItem("Port Forward", "Forward local port to service"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of a service to view"),
 			ui.NewSimpleItem("Edit", "Edit service YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the service YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete a service"),
 		}
 	case ResourceNodes:
@@ -143,29 +208,44 @@ func (m Model) navigateToActionSelection() Model {
 			ui.NewSimpleItem("Get", "List all nodes"),
 			ui.NewSimpleItem("Top (Metrics)", "View CPU/Memory usage for nodes"),
 			ui.NewSimpleItem("Describe", "Describe a specific node"),
+			ui.NewSimpleItem("Wait", "Wait until a node is Ready"),
+			ui.NewSimpleItem("View YAML", "Show the node YAML read-only"),
+			ui.NewSimpleItem("Cordon", "Mark a node as unschedulable"),
+			ui.NewSimpleItem("Uncordon", "Mark a node as schedulable again"),
+			ui.NewSimpleItem("Drain", "Evict all pods from a node for maintenance"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of a node to view"),
 			ui.NewSimpleItem("Edit", "Edit node YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the node YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete a node"),
 		}
 	case ResourceConfigMaps:
 		items = []list.Item{
 			ui.NewSimpleItem("Get", "List all configmaps"),
 			ui.NewSimpleItem("Describe", "Describe a specific configmap"),
+			ui.NewSimpleItem("View YAML", "Show the configmap YAML read-only"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of a configmap to view"),
 			ui.NewSimpleItem("Edit", "Edit configmap YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the configmap YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete a configmap"),
 		}
 	case ResourceSecrets:
 		items = []list.Item{
 			ui.NewSimpleItem("Get", "List all secrets"),
 			ui.NewSimpleItem("Describe", "Describe a specific secret (may reveal sensitive data)"),
+			ui.NewSimpleItem("View YAML", "Show the secret YAML read-only (data is only base64-encoded)"),
 			ui.NewSimpleItem("Extract Field", "Pick a field to decode and view"),
 			ui.NewSimpleItem("Edit", "Edit secret YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the secret YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete a secret"),
 		}
 	case ResourceIngress:
 		items = []list.Item{
 			ui.NewSimpleItem("Get", "List all ingress resources"),
 			ui.NewSimpleItem("Describe", "Describe a specific ingress"),
+			ui.NewSimpleItem("View YAML", "Show the ingress YAML read-only"),
+			ui.NewSimpleItem("Extract Field", "Pick a field of an ingress to view"),
 			ui.NewSimpleItem("Edit", "Edit ingress YAML"),
+			ui.NewSimpleItem("Edit YAML", "Edit the ingress YAML here and apply it"),
 			ui.NewSimpleItem("Delete", "Delete an ingress"),
 		}
 	default:
@@ -174,7 +254,7 @@ func (m Model) navigateToActionSelection() Model {
 		}
 	}
 
-	m.list = ui.NewList(items, "Select Action", m.width, m.height-4)
+	m.list = ui.NewList(m.markDeniedActions(m.withoutMutatingItems(items)), m.actionSelectionTitle(), m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = ActionSelectionScreen
 	return m
@@ -190,25 +270,68 @@ func (m Model) navigateToPortInput() Model {
 }
 
 func (m Model) navigateToDeleteConfirmation() Model {
+	if m.selectedAction == ActionRestart {
+		items := []list.Item{
+			ui.NewSimpleItem("Cancel", "Go back without restarting"),
+			ui.NewSimpleItem("Confirm Restart", "Delete pod "+m.selectedResourceName+" so its controller recreates it"),
+		}
+		m.list = ui.NewList(items, "⚠️  CONFIRM RESTART: pod "+m.selectedResourceName, m.width, m.listHeight())
+		m.previousScreen = m.currentScreen
+		m.currentScreen = DeleteConfirmationScreen
+		return m
+	}
+	if m.selectedAction == ActionDrain {
+		items := []list.Item{
+			ui.NewSimpleItem("Cancel", "Go back without draining"),
+			ui.NewSimpleItem("Confirm Drain", "Cordon node "+m.selectedResourceName+" and evict its pods"),
+		}
+		m.list = ui.NewList(items, "⚠️  CONFIRM DRAIN: node "+m.selectedResourceName, m.width, m.listHeight())
+		m.previousScreen = m.currentScreen
+		m.currentScreen = DeleteConfirmationScreen
+		return m
+	}
+
+	kind := getResourceShortName(m.selectedResource)
+	target := fmt.Sprintf("%s %s", kind, m.selectedResourceName)
+	if names := strings.Fields(m.selectedResourceName); len(names) > 1 {
+		target = fmt.Sprintf("%d %ss", len(names), kind)
+	}
 	items := []list.Item{
 		ui.NewSimpleItem("Cancel", "Go back without deleting"),
-		ui.NewSimpleItem("Confirm Delete", fmt.Sprintf("Permanently delete %s %s", getResourceShortName(m.selectedResource), m.selectedResourceName)),
+		ui.NewSimpleItem("Confirm Delete", "Permanently delete "+target),
 	}
-	title := fmt.Sprintf("⚠️  CONFIRM DELETION: %s %s", getResourceShortName(m.selectedResource), m.selectedResourceName)
-	m.list = ui.NewList(items, title, m.width, m.height-4)
+	title := "⚠️  CONFIRM DELETION: " + target
+	m.list = ui.NewList(items, title, m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = DeleteConfirmationScreen
 	return m
 }
 
 func (m Model) navigateToCommandPreview() Model {
+	m = m.applyStrictNamespace()
+
 	items := []list.Item{
 		ui.NewSimpleItem("Execute", "Run the command"),
+	}
+	// Interactive commands take over the terminal and produce no output to save
+	if !isInteractiveCommand(m.currentCommand) {
+		items = append(items, ui.NewSimpleItem("Run and Save", "Run the command and save its output as "+suggestOutputName(m.currentCommand)))
+	}
+	// Port-forwards can be saved to run in the background later
+	if commandVerb(m.currentCommand) == "port-forward" {
+		items = append(items, ui.NewSimpleItem("Save Port Forward", "Save it to start and stop from Port Forwards in the background"))
+	}
+	// Only offer a dry run for verbs that can change cluster state
+	if _, ok := dryRunCommand(m.currentCommand); ok {
+		items = append(items, ui.NewSimpleItem("Dry Run", "Validate against the cluster without applying changes"))
+	}
+	items = append(items,
+		ui.NewSimpleItem("Append Args", "Add flags the wizard does not offer"),
 		ui.NewSimpleItem("Help", "Show --help output"),
 		ui.NewSimpleItem("Save as Favourite", "Save for later use"),
 		ui.NewSimpleItem("Back", "Return to previous screen"),
-	}
-	m.list = ui.NewList(items, "Command Preview", m.width, m.height-4)
+	)
+	m.list = ui.NewList(items, "Command Preview", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = CommandPreviewScreen
 	return m
@@ -219,6 +342,8 @@ func (m Model) navigateToFlagsSelection() Model {
 	m.selectedFlags = []string{}
 	m.customNamespace = ""
 	m.needsNamespaceInput = false
+	m.implicitNamespaceCommand = ""
+	m.outputFilter = ""
 
 	// Build list of common flags based on action
 	var items []list.Item
@@ -228,43 +353,72 @@ func (m Model) navigateToFlagsSelection() Model {
 		items = []list.Item{
 			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
 			ui.NewSimpleItem("---", ""),
-			ui.NewSimpleItem("[ ] -o wide", "Show additional columns"),
-			ui.NewSimpleItem("[ ] -o yaml", "Output in YAML format"),
-			ui.NewSimpleItem("[ ] -o json", "Output in JSON format"),
-			ui.NewSimpleItem("[ ] --show-labels", "Show labels"),
-			ui.NewSimpleItem("[ ] -A", "All namespaces"),
+			flagItem("-o wide"),
+			flagItem("-o yaml"),
+			flagItem("-o json"),
+			ui.NewSimpleItem(customColumnsItemTitle, "Pick fields to show with -o custom-columns"),
+			ui.NewSimpleItem("[ ] "+jsonPathFlagLabel, jsonPathFlagDescription),
+			flagItem("--show-labels"),
+			flagItem("-A"),
 			ui.NewSimpleItem("[ ] -n <namespace>", "Specify custom namespace"),
+			ui.NewSimpleItem(contextFlagItemTitle, contextFlagItemDescription),
+			ui.NewSimpleItem("[ ] "+grepFlagLabel, grepFlagDescription),
 		}
 	case ActionDescribe:
 		items = []list.Item{
 			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
 			ui.NewSimpleItem("---", ""),
-			ui.NewSimpleItem("[ ] --show-events=true", "Show events"),
+			flagItem("--show-events=true"),
 			ui.NewSimpleItem("[ ] -n <namespace>", "Specify custom namespace"),
+			ui.NewSimpleItem(contextFlagItemTitle, contextFlagItemDescription),
 		}
 	case ActionLogs:
 		items = []list.Item{
 			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
 			ui.NewSimpleItem("---", ""),
-			ui.NewSimpleItem("[ ] -f", "Follow log output"),
-			ui.NewSimpleItem("[ ] --tail=100", "Show last 100 lines"),
-			ui.NewSimpleItem("[ ] --tail=50", "Show last 50 lines"),
-			ui.NewSimpleItem("[ ] --since=1h", "Show logs from last hour"),
-			ui.NewSimpleItem("[ ] --since=5m", "Show logs from last 5 minutes"),
-			ui.NewSimpleItem("[ ] --previous", "Show logs from previous container"),
+			flagItem("-f"),
+			flagItem("--tail=100"),
+			flagItem("--tail=50"),
+			flagItem("--since=1h"),
+			flagItem("--since=5m"),
+			flagItem("--previous"),
 			ui.NewSimpleItem("[ ] -n <namespace>", "Specify custom namespace"),
+			ui.NewSimpleItem(contextFlagItemTitle, contextFlagItemDescription),
+			ui.NewSimpleItem("[ ] "+grepFlagLabel, grepFlagDescription),
 		}
 	case ActionTop:
 		items = []list.Item{
 			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
 			ui.NewSimpleItem("---", ""),
-			ui.NewSimpleItem("[ ] -A", "All namespaces"),
+			flagItem("-A"),
+			ui.NewSimpleItem("[ ] -n <namespace>", "Specify custom namespace"),
+			ui.NewSimpleItem(contextFlagItemTitle, contextFlagItemDescription),
+			flagItem("--sort-by=cpu"),
+			flagItem("--sort-by=memory"),
+			flagItem("--use-protocol-buffers"),
+		}
+	case ActionWait:
+		items = []list.Item{
+			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
+			ui.NewSimpleItem("---", ""),
+			flagItem("--timeout=30s"),
+			flagItem("--timeout=2m"),
+			flagItem("--timeout=5m"),
 			ui.NewSimpleItem("[ ] -n <namespace>", "Specify custom namespace"),
-			ui.NewSimpleItem("[ ] --use-protocol-buffers", "Use protocol buffers for communication"),
+			ui.NewSimpleItem(contextFlagItemTitle, contextFlagItemDescription),
+		}
+	case ActionDrain:
+		items = []list.Item{
+			ui.NewSimpleItem("Done (Continue)", "Proceed with selected flags"),
+			ui.NewSimpleItem("---", ""),
+			flagItem("--ignore-daemonsets"),
+			flagItem("--delete-emptydir-data"),
+			ui.NewSimpleItem("[ ] --force", "Also evict pods not managed by a controller"),
 		}
 	}
 
-	m.list = ui.NewList(items, "Select Flags (Space to toggle, Enter when done)", m.width, m.height-4)
+	m.list = ui.NewList(items, "Select Flags (Space to toggle, 'D' to save as default, Enter when done)", m.width, m.listHeight())
+	m = m.applyDefaultFlags()
 	m.previousScreen = m.currentScreen
 	m.currentScreen = FlagsSelectionScreen
 	return m
@@ -279,7 +433,7 @@ func (m Model) navigateToNamespaceInput() Model {
 	return m
 }
 
-func (m Model) navigateToSecretFieldSelection(keys []string) Model {
+func (m Model) navigateToFieldSelection(keys []string) Model {
 	items := []list.Item{
 		ui.NewSimpleItem("Custom JSONPath", "Enter a custom JSONPath (e.g. .metadata.labels)"),
 		ui.NewSimpleItem("---", ""),
@@ -287,7 +441,9 @@ func (m Model) navigateToSecretFieldSelection(keys []string) Model {
 
 	for _, k := range keys {
 		var description string
-		if strings.HasPrefix(k, "data.") {
+		if m.selectedResource != ResourceSecrets {
+			description = "Extract this field with -o jsonpath"
+		} else if strings.HasPrefix(k, "data.") {
 			description = "Extract and decode this base64-encoded data field"
 		} else if strings.HasPrefix(k, "stringData.") {
 			description = "Extract this plain text field"
@@ -299,13 +455,14 @@ func (m Model) navigateToSecretFieldSelection(keys []string) Model {
 		items = append(items, ui.NewSimpleItem(k, description))
 	}
 
-	m.list = ui.NewList(items, "Select Field to Extract", m.width, m.height-4)
+	m.list = ui.NewList(items, "Select Field to Extract", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
-	m.currentScreen = SecretFieldSelectionScreen
+	m.currentScreen = FieldSelectionScreen
 	return m
 }
 
 func (m Model) navigateToSaveFavourite() Model {
+	m.favouriteWarned = false
 	m.textInput.SetValue("")
 	m.textInput.Placeholder = "Enter favourite name"
 	m.textInput.Focus()
@@ -325,7 +482,11 @@ func (m Model) navigateToRenameFavourite(idx int) Model {
 	}
 
 	m.renamingFavouriteIdx = idx
-	m.textInput.SetValue(fav.Name)
+	if fav.Group != "" {
+		m.textInput.SetValue(fav.Group + "/" + fav.Name)
+	} else {
+		m.textInput.SetValue(fav.Name)
+	}
 	m.textInput.Focus()
 	m.previousScreen = m.currentScreen
 	m.currentScreen = RenameFavouriteScreen
@@ -337,20 +498,43 @@ func (m Model) navigateBack() Model {
 	case ResourceSelectionScreen:
 		return m.navigateToMainMenu()
 	case ActionSelectionScreen:
+		if m.followedPod != "" {
+			return m.navigateToDeploymentPods()
+		}
 		return m.navigateToResourceSelection()
 	case ResourceNameSelectionScreen:
 		return m.navigateToActionSelection()
 	case FlagsSelectionScreen:
+		// A combined get skipped the action selection
+		if len(m.selectedResources) > 0 {
+			return m.navigateToResourceSelection()
+		}
 		// Always return to the action selection from flags to keep navigation consistent
 		return m.navigateToActionSelection()
 	case CommandPreviewScreen:
+		if m.fromYAMLEditor() {
+			return m.returnToYAMLEditor()
+		}
+		if m.selectedAction == ActionOwners {
+			return m.navigateToOwners()
+		}
+		// Edit and exec have no flags to go back to
+		if m.selectedAction == ActionEdit || m.selectedAction == ActionExec {
+			return m.navigateToActionSelection()
+		}
 		return m.navigateToFlagsSelection()
 	case CommandHelpScreen:
 		return m.navigateToCommandPreview()
+	case DryRunScreen:
+		return m.navigateToCommandPreview()
 	case ClusterInfoScreen:
 		return m.navigateToMainMenu()
 	case ClusterConnectivityScreen:
 		return m.navigateToMainMenu()
+	case LogViewerScreen:
+		return m.navigateToMainMenu()
+	case EventsStreamScreen:
+		return m.navigateToMainMenu()
 	case CommandHistoryScreen:
 		return m.navigateToMainMenu()
 	case HotkeysListScreen:
@@ -359,15 +543,34 @@ func (m Model) navigateBack() Model {
 		m.hotkeyBindingPending = false
 		return m.navigateToFavouritesList()
 	case FavouritesListScreen:
+		if m.favouriteGroup != "" {
+			m.favouriteGroup = ""
+			return m.navigateToFavouritesList()
+		}
 		return m.navigateToMainMenu()
-	case SaveFavouriteScreen:
+	case SaveFavouriteScreen, AppendArgsScreen:
 		return m.navigateToCommandPreview()
 	case RenameFavouriteScreen:
 		return m.navigateToFavouritesList()
-	case SecretFieldSelectionScreen:
+	case FieldSelectionScreen, OwnersScreen, PodDebugScreen, PodQuickActionsScreen, WaitConditionScreen, ContainerSelectionScreen,
+		DeploymentPodsScreen:
 		return m.navigateToActionSelection()
 	case NamespaceInputScreen:
+		m = m.stopNamespaceSuggestions()
 		return m.navigateToFlagsSelection()
+	case CustomColumnsScreen, ContextFlagSelectionScreen, GrepPatternInputScreen, JSONPathInputScreen:
+		return m.restoreFlagsList()
+	case PluginArgsScreen:
+		return m.navigateToPluginsList(m.plugins)
+	case TemplateInputScreen:
+		m.saveAfterRun = false
+		switch m.templateReturnScreen {
+		case FavouritesListScreen:
+			return m.navigateToFavouritesList()
+		case CommandPreviewScreen:
+			return m.navigateToCommandPreview()
+		}
+		return m.navigateToMainMenu()
 	case SavedOutputsListScreen:
 		return m.navigateToMainMenu()
 	case SavedOutputVersionsScreen:
@@ -377,6 +580,8 @@ func (m Model) navigateBack() Model {
 			return m.navigateToSavedOutputVersions(m.selectedSavedOutputBase)
 		}
 		return m.navigateToSavedOutputsGroups()
+	case DeleteSavedOutputConfirmationScreen:
+		return m.navigateToSavedOutputsGroups()
 	case RenameSavedOutputScreen:
 		if m.renamingSavedOutputIsGroup {
 			return m.navigateToSavedOutputVersions(m.renamingSavedOutput)
@@ -390,8 +595,39 @@ func (m Model) navigateBack() Model {
 		return m.navigateToContextsAndNamespacesMenu()
 	case NamespacesListScreen:
 		return m.navigateToContextsAndNamespacesMenu()
+	case KubeconfigInputScreen, CreateNamespaceScreen, DeleteNamespaceSelectionScreen:
+		return m.navigateToContextsAndNamespacesMenu()
+	case DeleteNamespaceConfirmationScreen:
+		return m.navigateToDeleteNamespaceSelection()
 	case PortInputScreen:
 		return m.navigateToActionSelection()
+	case PinnedOutputsListScreen:
+		return m.navigateToMainMenu()
+	case ErrorScreen:
+		if m.fromYAMLEditor() {
+			return m.returnToYAMLEditor()
+		}
+		return m.navigateToCommandPreview()
+	case CommandOutputScreen:
+		if m.fromYAMLEditor() {
+			return m.returnToYAMLEditor()
+		}
+		return m.navigateToMainMenu()
+	case ContextConfirmationScreen:
+		return m.navigateBackFromContextConfirmation()
+	case RecentContextsScreen:
+		return m.navigateToMainMenu()
+	case SettingsInputScreen:
+		m.textInput.Blur()
+		return m.navigateToSettings(settingIndex(m.editingSetting))
+	case UploadConfirmationScreen:
+		m.currentScreen = m.uploadReturnScreen
+		return m
+	case PinnedOutputViewScreen:
+		m = m.navigateToPinnedOutputs()
+		m.previousScreen = MainMenuScreen
+		m.list.Select(len(m.pinnedOutputs) - 1 - m.viewingPinnedOutput)
+		return m
 	default:
 		return m.navigateToMainMenu()
 	}
@@ -403,7 +639,7 @@ func (m Model) navigateToClusterInfo() Model {
 	m.currentScreen = ClusterInfoScreen
 
 	// Initialize viewport with loading message
-	m.viewport = ui.NewViewport(m.width, m.height-6)
+	m.viewport = ui.NewViewport(m.width, m.viewportHeight())
 	m.viewport.SetContent("Loading cluster information...\n\nThis may take a few moments.")
 
 	return m
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// nodeUsageInterval is how often node usage is sampled on Cluster Info
+	nodeUsageInterval = 10 * time.Second
+	// maxNodeUsageSamples is how many samples are kept, and drawn, per node
+	maxNodeUsageSamples = 20
+)
+
+// sparkLevels draw a percentage from 0 to 100 as one character.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// nodeUsageHistory holds a node's CPU and memory percentages, oldest first.
+type nodeUsageHistory struct {
+	cpu    []int
+	memory []int
+}
+
+// sampleNodeUsage takes one "kubectl top nodes" sample for the Cluster Info
+// visit identified by id.
+func (m Model) sampleNodeUsage(id int) tea.Cmd {
+	return func() tea.Msg {
+		usage, err := m.kubectlClient.GetNodeUsage()
+		return nodeUsageSampledMsg{id: id, usage: usage, err: err}
+	}
+}
+
+// handleNodeUsageSample adds a sample to the trend and waits for the next
+// one. Sampling stops once Cluster Info is left.
+func (m Model) handleNodeUsageSample(msg nodeUsageSampledMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.nodeUsagePollID || m.currentScreen != ClusterInfoScreen {
+		return m, nil
+	}
+
+	// A failed sample, e.g. while metrics-server starts, is skipped rather
+	// than ending the trend
+	if msg.err == nil && len(msg.usage) > 0 {
+		m.nodeUsage = addNodeUsageSample(m.nodeUsage, msg.usage)
+		if m.clusterInfo != nil {
+			m.viewport.SetContent(formatClusterInfoForDisplay(m.clusterInfo, m.nodeUsage, m.width))
+		}
+	}
+
+	id := msg.id
+	return m, tea.Tick(nodeUsageInterval, func(time.Time) tea.Msg {
+		return nodeUsagePollMsg{id: id}
+	})
+}
+
+// addNodeUsageSample returns a new history with the sample appended, keeping
+// the last maxNodeUsageSamples per node. Nodes missing from the sample keep
+// their history unchanged.
+func addNodeUsageSample(history map[string]nodeUsageHistory, sample []kubectl.NodeUsage) map[string]nodeUsageHistory {
+	updated := make(map[string]nodeUsageHistory, len(sample))
+	for name, h := range history {
+		updated[name] = h
+	}
+	for _, u := range sample {
+		h := updated[u.Name]
+		updated[u.Name] = nodeUsageHistory{
+			cpu:    lastSamples(h.cpu, u.CPUPercent),
+			memory: lastSamples(h.memory, u.MemoryPercent),
+		}
+	}
+	return updated
+}
+
+// lastSamples appends v to a copy of values and drops the oldest samples
+// beyond maxNodeUsageSamples.
+func lastSamples(values []int, v int) []int {
+	values = append(append([]int(nil), values...), v)
+	if len(values) > maxNodeUsageSamples {
+		values = values[len(values)-maxNodeUsageSamples:]
+	}
+	return values
+}
+
+// sparkline draws percentages as a row of block characters, e.g. "▁▂▃▅▇".
+func sparkline(values []int) string {
+	var sb strings.Builder
+	for _, v := range values {
+		level := v * len(sparkLevels) / 101
+		if level < 0 {
+			level = 0
+		} else if level >= len(sparkLevels) {
+			level = len(sparkLevels) - 1
+		}
+		sb.WriteRune(sparkLevels[level])
+	}
+	return sb.String()
+}
+
+// formatNodeUsageTrend lists a CPU and memory sparkline with the latest
+// percentage for each node that has been sampled, in the order of nodes.
+func formatNodeUsageTrend(nodes []kubectl.NodeInfo, usage map[string]nodeUsageHistory) string {
+	nameWidth := 0
+	for _, node := range nodes {
+		if _, ok := usage[node.Name]; ok && len(node.Name) > nameWidth {
+			nameWidth = len(node.Name)
+		}
+	}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		h, ok := usage[node.Name]
+		if !ok || len(h.cpu) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %-*s  CPU %-*s %3d%%   Memory %-*s %3d%%\n",
+			nameWidth, node.Name,
+			maxNodeUsageSamples, sparkline(h.cpu), h.cpu[len(h.cpu)-1],
+			maxNodeUsageSamples, sparkline(h.memory), h.memory[len(h.memory)-1]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 20, 50, 80, 100, 130}); got != "▁▂▄▇██" {
+		t.Errorf("sparkline() = %q", got)
+	}
+}
+
+// Test that only the last maxNodeUsageSamples are kept per node and that
+// earlier histories are not modified.
+func TestAddNodeUsageSampleKeepsLastSamples(t *testing.T) {
+	var history map[string]nodeUsageHistory
+	for i := 0; i < maxNodeUsageSamples+5; i++ {
+		history = addNodeUsageSample(history, []kubectl.NodeUsage{{Name: "node-1", CPUPercent: i, MemoryPercent: 50}})
+	}
+	before := history
+	history = addNodeUsageSample(history, []kubectl.NodeUsage{{Name: "node-1", CPUPercent: 99, MemoryPercent: 50}})
+
+	cpu := history["node-1"].cpu
+	if len(cpu) != maxNodeUsageSamples || cpu[0] != 6 || cpu[len(cpu)-1] != 99 {
+		t.Errorf("unexpected samples %v", cpu)
+	}
+	if old := before["node-1"].cpu; old[len(old)-1] != maxNodeUsageSamples+4 {
+		t.Errorf("earlier history was modified: %v", old)
+	}
+}
+
+// Test that samples are drawn on Cluster Info and keep being taken only
+// while it is shown.
+func TestNodeUsageTrendOnClusterInfo(t *testing.T) {
+	m := Model{width: 160, height: 40, viewport: ui.NewViewport(160, 30), currentScreen: ClusterInfoScreen,
+		clusterInfo:     &kubectl.ClusterInfo{Nodes: []kubectl.NodeInfo{{Name: "node-1"}, {Name: "node-2"}}},
+		nodeUsagePollID: 3}
+
+	sample := nodeUsageSampledMsg{id: 3, usage: []kubectl.NodeUsage{{Name: "node-1", CPUPercent: 12, MemoryPercent: 95}}}
+	updated, cmd := m.Update(sample)
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected the next sample to be scheduled")
+	}
+	content := m.viewport.View()
+	if !strings.Contains(content, "Usage Trend") || !strings.Contains(content, "node-1  CPU ▁") || !strings.Contains(content, " 95%") {
+		t.Errorf("expected a trend for node-1, got:\n%s", content)
+	}
+
+	if _, cmd = m.Update(nodeUsageSampledMsg{id: 2}); cmd != nil {
+		t.Errorf("expected a sample from an earlier visit to be dropped")
+	}
+	m.currentScreen = MainMenuScreen
+	if _, cmd = m.Update(nodeUsagePollMsg{id: 3}); cmd != nil {
+		t.Errorf("expected sampling to stop off Cluster Info")
+	}
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/diff"
+)
+
+// maxCachedGetOutputs is how many get outputs are kept to compare re-runs with.
+const maxCachedGetOutputs = 20
+
+// getOutputKey identifies a get by its command and the context it ran in.
+func getOutputKey(context, cmd string) string {
+	return context + "|" + strings.Join(strings.Fields(cmd), " ")
+}
+
+// recordGetOutput remembers the output of a successful get and, when the
+// same command ran before in the same context, describes what changed since.
+// Other commands are not recorded and yield "".
+func (m Model) recordGetOutput(context, cmd, output string) (Model, string) {
+	if commandVerb(cmd) != "get" || isStreamingCommand(cmd) {
+		return m, ""
+	}
+
+	key := getOutputKey(context, cmd)
+	changes := ""
+	if previous, ok := m.lastGetOutputs[key]; ok {
+		changes = describeOutputChanges(cmd, previous, output)
+	}
+
+	// Replaced rather than updated, so earlier copies of the model keep theirs
+	outputs := make(map[string]string, len(m.lastGetOutputs)+1)
+	for k, v := range m.lastGetOutputs {
+		outputs[k] = v
+	}
+	outputs[key] = output
+	order := append(removeString(m.lastGetOrder, key), key)
+	if len(order) > maxCachedGetOutputs {
+		delete(outputs, order[0])
+		order = order[1:]
+	}
+	m.lastGetOutputs = outputs
+	m.lastGetOrder = order
+	return m, changes
+}
+
+// removeString returns a copy of values without s.
+func removeString(values []string, s string) []string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != s {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// describeOutputChanges summarises how after differs from before, e.g.
+// "3 pods added, 1 removed since last run". Tables are compared row by
+// row, ignoring AGE; other output by lines.
+func describeOutputChanges(cmd, before, after string) string {
+	var parts []string
+	beforeRows, beforeOK := tableRowLines(before)
+	afterRows, afterOK := tableRowLines(after)
+	if beforeOK && afterOK {
+		added, removed, changed := compareRows(beforeRows, afterRows)
+		if added > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s added", added, resourceNoun(cmd, added)))
+		}
+		if removed > 0 {
+			noun := ""
+			if len(parts) == 0 {
+				noun = resourceNoun(cmd, removed) + " "
+			}
+			parts = append(parts, fmt.Sprintf("%d %sremoved", removed, noun))
+		}
+		if changed > 0 {
+			noun := ""
+			if len(parts) == 0 {
+				noun = resourceNoun(cmd, changed) + " "
+			}
+			parts = append(parts, fmt.Sprintf("%d %schanged", changed, noun))
+		}
+	} else {
+		inserted, deleted := diff.Stats(diff.Lines(before, after))
+		if inserted > 0 || deleted > 0 {
+			parts = append(parts, fmt.Sprintf("%d lines added, %d removed", inserted, deleted))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "No changes since last run"
+	}
+	return strings.Join(parts, ", ") + " since last run"
+}
+
+// tableRowLines returns one "key\tcells" line per table row, sorted by key.
+// The key is the row's NAME, prefixed with its NAMESPACE when listed. AGE is
+// left out as it changes on every run.
+func tableRowLines(output string) ([]string, bool) {
+	tables, ok := parseTables(output)
+	if !ok {
+		return nil, false
+	}
+	var lines []string
+	for _, t := range tables {
+		for _, row := range t.rows {
+			var key string
+			var cells []string
+			for i, h := range t.headers {
+				switch h {
+				case "NAMESPACE":
+					key = row[i] + "/" + key
+				case "NAME":
+					key += row[i]
+				case "AGE":
+					continue
+				}
+				cells = append(cells, row[i])
+			}
+			if key == "" && len(row) > 0 {
+				key = row[0]
+			}
+			lines = append(lines, key+"\t"+strings.Join(cells, " "))
+		}
+	}
+	sort.Strings(lines)
+	return lines, true
+}
+
+// compareRows counts rows only in after, only in before, and in both with
+// different cells, matching rows by key.
+func compareRows(before, after []string) (added, removed, changed int) {
+	inserted := map[string]bool{}
+	deleted := map[string]bool{}
+	for _, l := range diff.Lines(strings.Join(before, "\n"), strings.Join(after, "\n")) {
+		key, _, _ := strings.Cut(l.Text, "\t")
+		switch l.Op {
+		case diff.Insert:
+			inserted[key] = true
+		case diff.Delete:
+			deleted[key] = true
+		}
+	}
+	for key := range inserted {
+		if deleted[key] {
+			changed++
+		} else {
+			added++
+		}
+	}
+	for key := range deleted {
+		if !inserted[key] {
+			removed++
+		}
+	}
+	return added, removed, changed
+}
+
+// resourceNoun names what a get lists, e.g. "pods" or "pod" for one, falling
+// back to "resources" for short or combined kinds.
+func resourceNoun(cmd string, n int) string {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(cmd), "kubectl "))
+	noun := "resources"
+	if len(fields) > 1 && len(fields[1]) > 3 && strings.HasSuffix(fields[1], "s") && !strings.Contains(fields[1], ",") {
+		noun = fields[1]
+	}
+	if n == 1 && !strings.HasSuffix(noun, "ss") {
+		return strings.TrimSuffix(noun, "s")
+	}
+	return noun
+}
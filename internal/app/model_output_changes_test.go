@@ -0,0 +1,73 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+)
+
+func TestDescribeOutputChanges(t *testing.T) {
+	before := "NAME    READY   STATUS    RESTARTS   AGE\n" +
+		"api     1/1     Running   0          5m\n" +
+		"old     1/1     Running   0          5m\n" +
+		"web     1/1     Running   0          5m\n"
+	after := "NAME      READY   STATUS             RESTARTS   AGE\n" +
+		"api       1/1     Running            0          6m\n" +
+		"web       0/1     CrashLoopBackOff   3          6m\n" +
+		"new-1     1/1     Running            0          10s\n" +
+		"new-two   1/1     Running            0          10s\n"
+
+	tests := []struct {
+		cmd, before, after, want string
+	}{
+		{"kubectl get pods", before, after, "2 pods added, 1 removed, 1 changed since last run"},
+		// Only AGE and column widths differ
+		{"kubectl get pods", before, strings.ReplaceAll(before, "5m", "7m"), "No changes since last run"},
+		{"kubectl get pods", before, strings.Replace(before, "old     1/1     Running   0          5m\n", "", 1), "1 pod removed since last run"},
+		{"kubectl get po -o yaml", "a: 1\nb: 2\n", "a: 1\nb: 3\nc: 4\n", "2 lines added, 1 removed since last run"},
+	}
+	for _, tt := range tests {
+		if got := describeOutputChanges(tt.cmd, tt.before, tt.after); got != tt.want {
+			t.Errorf("describeOutputChanges(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+// Test that re-running a get in the same context shows a banner with what
+// changed, while the first run, other contexts and other verbs show none.
+func TestRerunGetShowsChangesBanner(t *testing.T) {
+	m := Model{width: 80, height: 40, ready: true, viewport: ui.NewViewport(80, 30),
+		currentCommand: "kubectl get pods"}
+	run := func(m Model, context, output string) Model {
+		updated, _ := m.Update(commandExecutedMsg{result: kubectl.CommandResult{Output: output}, context: context})
+		return updated.(Model)
+	}
+
+	first := "NAME   READY   STATUS    RESTARTS   AGE\nweb    1/1     Running   0          5m\n"
+	m = run(m, "dev", first)
+	if strings.Contains(m.viewport.View(), "since last run") {
+		t.Fatalf("expected no banner on the first run")
+	}
+
+	m = run(m, "prod", first+"api    1/1     Running   0          1m\n")
+	if strings.Contains(m.viewport.View(), "since last run") {
+		t.Errorf("expected no banner for the first run in another context")
+	}
+
+	m = run(m, "dev", first+"api    1/1     Running   0          1m\n")
+	if !strings.Contains(m.viewport.View(), "1 pod added since last run") {
+		t.Errorf("expected a changes banner, got:\n%s", m.viewport.View())
+	}
+	if strings.Contains(m.currentOutputContent, "since last run") {
+		t.Errorf("expected the banner to be left out of the saved output")
+	}
+
+	m.currentCommand = "kubectl describe pod web"
+	m = run(m, "dev", "Name: web\n")
+	m = run(m, "dev", "Name: web\nStatus: Running\n")
+	if strings.Contains(m.viewport.View(), "since last run") {
+		t.Errorf("expected no banner for describe")
+	}
+}
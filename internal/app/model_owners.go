@@ -0,0 +1,117 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxOwnerDepth stops following owner references that loop or run deep.
+const maxOwnerDepth = 10
+
+// ownerLink is one resource in an owner chain.
+type ownerLink struct {
+	resource string // kubectl resource type, e.g. "replicaset.apps"
+	kind     string
+	name     string
+}
+
+func (l ownerLink) title() string {
+	return l.kind + " " + l.name
+}
+
+// walkOwners follows controller owner references up from the given resource
+// using fetch. The chain starts with the resource itself; when a lookup
+// fails the chain found so far is returned along with the error.
+func walkOwners(fetch func(resource, name string) ([]kubectl.OwnerReference, error), start ownerLink) ([]ownerLink, error) {
+	chain := []ownerLink{start}
+	for len(chain) <= maxOwnerDepth {
+		last := chain[len(chain)-1]
+		refs, err := fetch(last.resource, last.name)
+		if err != nil {
+			return chain, err
+		}
+		ref, ok := controllerOwner(refs)
+		if !ok {
+			break
+		}
+		chain = append(chain, ownerLink{resource: ref.Resource(), kind: ref.Kind, name: ref.Name})
+	}
+	return chain, nil
+}
+
+// controllerOwner picks the managing owner reference, falling back to the
+// first one when none is marked as the controller.
+func controllerOwner(refs []kubectl.OwnerReference) (kubectl.OwnerReference, bool) {
+	for _, ref := range refs {
+		if ref.Controller {
+			return ref, true
+		}
+	}
+	if len(refs) > 0 {
+		return refs[0], true
+	}
+	return kubectl.OwnerReference{}, false
+}
+
+func (m Model) loadOwners() tea.Cmd {
+	namespace, name := splitNamespacedName(m.selectedResourceName)
+	namespace = strings.TrimPrefix(m.fieldNamespaceFlag(namespace), " -n ")
+
+	return withSpinner(fmt.Sprintf("Following the owners of %s…", name), func() tea.Msg {
+		fetch := func(resource, name string) ([]kubectl.OwnerReference, error) {
+			return m.kubectlClient.GetOwnerReferences(resource, name, namespace)
+		}
+		chain, err := walkOwners(fetch, ownerLink{resource: "pod", kind: "Pod", name: name})
+		return ownersLoadedMsg{chain: chain, namespace: namespace, err: err}
+	})
+}
+
+// navigateToOwners lists the pod followed by each of its owners in turn.
+func (m Model) navigateToOwners() Model {
+	items := make([]list.Item, 0, len(m.ownerChain))
+	for i, link := range m.ownerChain {
+		desc := "Top-level owner"
+		switch {
+		case i+1 < len(m.ownerChain):
+			desc = "Owned by " + m.ownerChain[i+1].title()
+		case i == 0:
+			desc = "Not owned by any controller"
+		}
+		items = append(items, ui.NewSimpleItem(link.title(), desc))
+	}
+
+	title := "Owners (Enter=describe)"
+	if len(m.ownerChain) > 0 {
+		title = fmt.Sprintf("Owners of %s (Enter=describe)", m.ownerChain[0].name)
+	}
+	m.list = ui.NewList(items, title, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = OwnersScreen
+	return m
+}
+
+// handleOwnerSelection previews a describe of the highlighted owner.
+func (m Model) handleOwnerSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	title := selected.(ui.SimpleItem).Title()
+	for _, link := range m.ownerChain {
+		if link.title() != title {
+			continue
+		}
+		m.currentCommand = fmt.Sprintf("kubectl describe %s %s", link.resource, link.name)
+		if m.ownerNamespace != "" {
+			m.currentCommand += " -n " + m.ownerNamespace
+		}
+		return m.navigateToCommandPreview(), nil
+	}
+	return m, nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWalkOwners(t *testing.T) {
+	owners := map[string][]kubectl.OwnerReference{
+		"pod/web-5d9c7-abcde": {
+			{APIVersion: "v1", Kind: "Node", Name: "node-1"},
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d9c7", Controller: true},
+		},
+		"replicaset.apps/web-5d9c7": {{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: true}},
+	}
+	fetch := func(resource, name string) ([]kubectl.OwnerReference, error) {
+		return owners[resource+"/"+name], nil
+	}
+
+	chain, err := walkOwners(fetch, ownerLink{resource: "pod", kind: "Pod", name: "web-5d9c7-abcde"})
+	if err != nil {
+		t.Fatalf("walkOwners() error = %v", err)
+	}
+	want := []string{"Pod web-5d9c7-abcde", "ReplicaSet web-5d9c7", "Deployment web"}
+	if len(chain) != len(want) {
+		t.Fatalf("walkOwners() = %v, want %v", chain, want)
+	}
+	for i, link := range chain {
+		if link.title() != want[i] {
+			t.Errorf("chain[%d] = %q, want %q", i, link.title(), want[i])
+		}
+	}
+	if chain[2].resource != "deployment.apps" {
+		t.Errorf("expected the deployment to be fetched as deployment.apps, got %q", chain[2].resource)
+	}
+}
+
+func TestWalkOwnersKeepsChainOnError(t *testing.T) {
+	fetch := func(resource, name string) ([]kubectl.OwnerReference, error) {
+		if resource == "pod" {
+			return []kubectl.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d9c7"}}, nil
+		}
+		return nil, fmt.Errorf("forbidden")
+	}
+
+	chain, err := walkOwners(fetch, ownerLink{resource: "pod", kind: "Pod", name: "web-5d9c7-abcde"})
+	if err == nil || len(chain) != 2 {
+		t.Errorf("expected the pod and its ReplicaSet with an error, got %v (%v)", chain, err)
+	}
+}
+
+// Test that picking an owner previews a describe of it in the pod's namespace.
+func TestOwnerSelectionPreviewsDescribe(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, selectedResource: ResourcePods, selectedAction: ActionOwners}
+	updated, _ := m.Update(ownersLoadedMsg{
+		chain: []ownerLink{
+			{resource: "pod", kind: "Pod", name: "web-5d9c7-abcde"},
+			{resource: "replicaset.apps", kind: "ReplicaSet", name: "web-5d9c7"},
+			{resource: "deployment.apps", kind: "Deployment", name: "web"},
+		},
+		namespace: "shop",
+	})
+	m = updated.(Model)
+	if m.currentScreen != OwnersScreen {
+		t.Fatalf("expected the owners screen, got %s", m.currentScreen)
+	}
+
+	m.list.Select(2)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if want := "kubectl describe deployment.apps web -n shop"; m.currentScreen != CommandPreviewScreen || m.currentCommand != want {
+		t.Errorf("expected a preview of %q, got %q on %s", want, m.currentCommand, m.currentScreen)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentScreen != OwnersScreen {
+		t.Errorf("expected Esc to return to the owners, got %s", m.currentScreen)
+	}
+}
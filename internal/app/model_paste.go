@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// uploadHelp offers the upload key on output screens when a paste service
+// is configured, ending with a separator for the help that follows.
+func (m Model) uploadHelp() string {
+	if m.pasteClient == nil {
+		return ""
+	}
+	return fmt.Sprintf("'%s' to upload | ", m.keys.Upload.Help().Key)
+}
+
+// navigateToUploadConfirmation asks before the output on screen is sent to
+// the paste service, as it may hold secrets. The output screen is left as
+// is and shown again once the upload is confirmed or cancelled.
+func (m Model) navigateToUploadConfirmation() Model {
+	host := m.pasteClient.Endpoint
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	items := []list.Item{
+		ui.NewSimpleItem("Cancel", "Go back without uploading"),
+		ui.NewSimpleItem("Upload", "Send the output to "+host+"; anyone with the link can read it"),
+	}
+	m.list = ui.NewList(items, "Upload this output to "+host+"?", m.width, m.listHeight())
+	m.uploadReturnScreen = m.currentScreen
+	m.currentScreen = UploadConfirmationScreen
+	return m
+}
+
+// handleUploadConfirmation uploads the output when confirmed and returns to
+// the output screen either way.
+func (m Model) handleUploadConfirmation() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	m.currentScreen = m.uploadReturnScreen
+	if selected.(ui.SimpleItem).Title() != "Upload" || m.loading {
+		return m, nil
+	}
+	return m, withSpinner("Uploading output…", m.uploadCurrentOutput())
+}
+
+// uploadCurrentOutput uploads the output on screen. A command output that
+// can't be uploaded, e.g. while offline, is saved locally instead so it can
+// be shared later.
+func (m Model) uploadCurrentOutput() tea.Cmd {
+	name := m.selectedSavedOutput
+	saveOnFailure := m.currentScreen == CommandOutputScreen
+	if saveOnFailure {
+		name = suggestOutputName(m.currentCommand)
+	}
+	client := m.pasteClient
+	content := m.currentOutputContent
+	return func() tea.Msg {
+		url, err := client.Upload(name, content)
+		msg := outputUploadedMsg{url: url, err: err}
+		if err != nil && saveOnFailure {
+			saved := m.saveOutput(name)().(outputSavedMsg)
+			msg.savedAs, msg.saveErr = saved.filename, saved.err
+		}
+		return msg
+	}
+}
+
+// uploadStatus reports the outcome of an upload for the status line.
+func uploadStatus(msg outputUploadedMsg) error {
+	switch {
+	case msg.err == nil:
+		return fmt.Errorf("✓ Uploaded to: %s", msg.url)
+	case msg.savedAs != "":
+		return fmt.Errorf("Upload failed (%v), saved locally to: %s", msg.err, msg.savedAs)
+	case msg.saveErr != nil:
+		return fmt.Errorf("Upload failed (%v), and saving locally failed too: %v", msg.err, msg.saveErr)
+	}
+	return fmt.Errorf("Upload failed: %v", msg.err)
+}
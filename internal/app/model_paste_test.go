@@ -0,0 +1,130 @@
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/paste"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that an output is uploaded and its URL shown, and that a command
+// output is saved locally when the paste service can't be reached.
+func TestUploadOutput(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	var uploaded string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		uploaded = string(b)
+		io.WriteString(w, `{"url": "https://paste.example.com/abc"}`)
+	}))
+
+	m := Model{keys: defaultKeyMap(), currentScreen: CommandOutputScreen, pasteClient: paste.NewClient(server.URL),
+		currentCommand: "kubectl get pods", currentOutputContent: "Output:\nNAME\nweb\n"}
+	if !strings.Contains(m.uploadHelp(), "'U' to upload") {
+		t.Errorf("expected the upload key in the help, got %q", m.uploadHelp())
+	}
+	updated, _ := m.Update(m.uploadCurrentOutput()())
+	if got := updated.(Model).err; got == nil || got.Error() != "✓ Uploaded to: https://paste.example.com/abc" {
+		t.Errorf("expected the paste URL, got %v", got)
+	}
+	if uploaded != m.currentOutputContent {
+		t.Errorf("expected the output to be uploaded, got %q", uploaded)
+	}
+
+	server.Close()
+	updated, _ = m.Update(m.uploadCurrentOutput()())
+	if got := updated.(Model).err; got == nil || !strings.Contains(got.Error(), "saved locally to: ") {
+		t.Fatalf("expected a local save after the failed upload, got %v", got)
+	}
+	if _, err := os.Stat("saved_cmd/get-pods.txt"); err != nil {
+		t.Errorf("expected the output to be saved as get-pods: %v", err)
+	}
+
+	// A saved output is already on disk, so only the failure is reported
+	m.currentScreen = SavedOutputViewScreen
+	m.selectedSavedOutput = "pods"
+	updated, _ = m.Update(m.uploadCurrentOutput()())
+	if got := updated.(Model).err; got == nil || !strings.HasPrefix(got.Error(), "Upload failed: ") {
+		t.Errorf("expected only the upload failure, got %v", got)
+	}
+
+	m.pasteClient = nil
+	if m.uploadHelp() != "" {
+		t.Errorf("expected no upload key without a paste service")
+	}
+}
+
+// Test that the upload key asks before sending anything, that Cancel and Esc
+// return to the output, and that 'u' is left to the viewport for scrolling.
+func TestUploadRequiresConfirmation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.WriteString(w, "https://paste.example.com/abc")
+	}))
+	defer server.Close()
+
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		viewport: ui.NewViewport(80, 30), currentScreen: CommandOutputScreen, pasteClient: paste.NewClient(server.URL),
+		currentCommand: "kubectl get secret db -o yaml", currentOutputContent: "data:\n  password: c2VjcmV0\n"}
+	press := func(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+		updated, cmd := m.Update(msg)
+		return updated.(Model), cmd
+	}
+
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if m.currentScreen != CommandOutputScreen || m.err != nil {
+		t.Fatalf("expected 'u' to scroll the output, got screen %v, err %v", m.currentScreen, m.err)
+	}
+
+	upload := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")}
+	m, cmd := press(m, upload)
+	if m.currentScreen != UploadConfirmationScreen || cmd != nil {
+		t.Fatalf("expected a confirmation before uploading, got screen %v", m.currentScreen)
+	}
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.currentScreen != CommandOutputScreen {
+		t.Fatalf("expected Esc to return to the output, got %v", m.currentScreen)
+	}
+
+	m, _ = press(m, upload)
+	m, cmd = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentScreen != CommandOutputScreen || cmd != nil {
+		t.Fatalf("expected Cancel to return to the output without uploading, got screen %v", m.currentScreen)
+	}
+
+	m, _ = press(m, upload)
+	m.list.Select(1)
+	m, cmd = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentScreen != CommandOutputScreen || cmd == nil {
+		t.Fatalf("expected Upload to start the upload, got screen %v", m.currentScreen)
+	}
+	updated, _ := m.Update(m.uploadCurrentOutput()())
+	if got := updated.(Model).err; got == nil || got.Error() != "✓ Uploaded to: https://paste.example.com/abc" {
+		t.Errorf("expected the paste URL, got %v", got)
+	}
+	if requests != 1 {
+		t.Errorf("expected one upload, got %d", requests)
+	}
+
+	// Without a paste service the key does nothing
+	m.pasteClient = nil
+	if m, _ = press(m, upload); m.currentScreen != CommandOutputScreen {
+		t.Errorf("expected no confirmation without a paste service, got %v", m.currentScreen)
+	}
+}
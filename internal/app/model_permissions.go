@@ -0,0 +1,200 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RBAC-aware action menu: with check_permissions enabled, the actions of a
+// resource are checked with "kubectl auth can-i" and the ones the user may
+// not perform are marked and refused.
+
+// actionPermission is what "kubectl auth can-i" is asked for an action.
+type actionPermission struct {
+	verb     string
+	resource string
+}
+
+// actionPermissionFor returns the permission an action needs on resource.
+// Actions without a single clear permission, such as top, are not checked.
+func actionPermissionFor(action Action, resource ResourceType) (actionPermission, bool) {
+	name := getResourceShortName(resource)
+	switch action {
+	case ActionGet:
+		return actionPermission{"list", name}, true
+	case ActionDescribe, ActionViewYAML, ActionExtractField, ActionOwners, ActionDebug, ActionQuickActions, ActionWait:
+		return actionPermission{"get", name}, true
+	case ActionLogs:
+		return actionPermission{"get", "pods/log"}, true
+	case ActionShowPods:
+		return actionPermission{"list", "pods"}, true
+	case ActionEdit, ActionEditYAML, ActionCordon, ActionUncordon, ActionDrain:
+		return actionPermission{"patch", name}, true
+	case ActionDelete, ActionRestart:
+		return actionPermission{"delete", name}, true
+	case ActionExec:
+		return actionPermission{"create", "pods/exec"}, true
+	case ActionPortForward:
+		return actionPermission{"create", "pods/portforward"}, true
+	}
+	return actionPermission{}, false
+}
+
+// permissionKey identifies a can-i answer within one context.
+func permissionKey(namespace string, p actionPermission) string {
+	return namespace + "|" + p.verb + "|" + p.resource
+}
+
+// actionFromTitle maps an action menu title back to its Action.
+func actionFromTitle(title string) (Action, bool) {
+	for a := Action(0); a.String() != "Unknown"; a++ {
+		if a.String() == title {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// deniedPermission reports the permission action lacks for the selected
+// resource, going by the answers cached for the current context. Unchecked
+// permissions are assumed to be granted.
+func (m Model) deniedPermission(action Action) (actionPermission, bool) {
+	if !m.checkPermissions {
+		return actionPermission{}, false
+	}
+	p, ok := actionPermissionFor(action, m.selectedResource)
+	if !ok {
+		return actionPermission{}, false
+	}
+	allowed, checked := m.permissions[m.permissionsContext][permissionKey(m.defaultNamespace, p)]
+	return p, checked && !allowed
+}
+
+// markDeniedActions replaces the description of actions the user may not
+// perform, so they show up greyed out in the menu.
+func (m Model) markDeniedActions(items []list.Item) []list.Item {
+	for i, item := range items {
+		title := item.(ui.SimpleItem).Title()
+		action, ok := actionFromTitle(title)
+		if !ok {
+			continue
+		}
+		if p, denied := m.deniedPermission(action); denied {
+			items[i] = ui.NewSimpleItem(title, fmt.Sprintf("🔒 Not permitted: you cannot %s %s", p.verb, p.resource))
+		}
+	}
+	return items
+}
+
+// checkActionPermissions asks "kubectl auth can-i" about every action in the
+// action menu whose answer is not cached yet. The checks run
+// concurrently and the answers arrive as a permissionsCheckedMsg.
+func (m Model) checkActionPermissions() tea.Cmd {
+	if !m.checkPermissions {
+		return nil
+	}
+
+	namespace := m.defaultNamespace
+	cached := m.permissions[m.permissionsContext]
+	var pending []actionPermission
+	for _, item := range m.list.Items() {
+		action, ok := actionFromTitle(item.(ui.SimpleItem).Title())
+		if !ok {
+			continue
+		}
+		p, ok := actionPermissionFor(action, m.selectedResource)
+		if !ok {
+			continue
+		}
+		if _, done := cached[permissionKey(namespace, p)]; !done && !containsPermission(pending, p) {
+			pending = append(pending, p)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	client := m.kubectlClient
+	return func() tea.Msg {
+		kubeContext, err := client.GetCurrentContext()
+		if err != nil {
+			return permissionsCheckedMsg{err: err}
+		}
+
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			results = make(map[string]bool, len(pending))
+		)
+		for _, p := range pending {
+			wg.Add(1)
+			go func(p actionPermission) {
+				defer wg.Done()
+				allowed, err := client.CanI(p.verb, p.resource, namespace)
+				if err != nil {
+					// Unanswered checks leave the action available
+					return
+				}
+				mu.Lock()
+				results[permissionKey(namespace, p)] = allowed
+				mu.Unlock()
+			}(p)
+		}
+		wg.Wait()
+		return permissionsCheckedMsg{context: kubeContext, results: results}
+	}
+}
+
+// storePermissions caches can-i answers for their context and refreshes the
+// action menu if it is still shown.
+func (m Model) storePermissions(msg permissionsCheckedMsg) Model {
+	if msg.err != nil {
+		return m
+	}
+	if m.permissions == nil {
+		m.permissions = map[string]map[string]bool{}
+	}
+	if m.permissions[msg.context] == nil {
+		m.permissions[msg.context] = map[string]bool{}
+	}
+	for key, allowed := range msg.results {
+		m.permissions[msg.context][key] = allowed
+	}
+	m.permissionsContext = msg.context
+
+	if m.currentScreen == ActionSelectionScreen {
+		idx := m.list.Index()
+		m.list.SetItems(m.markDeniedActions(m.list.Items()))
+		m.list.Select(idx)
+	}
+	return m
+}
+
+// refuseDeniedAction sets an error explaining why action cannot be chosen,
+// and reports whether it did.
+func (m Model) refuseDeniedAction(action Action) (Model, bool) {
+	p, denied := m.deniedPermission(action)
+	if !denied {
+		return m, false
+	}
+	where := ""
+	if m.defaultNamespace != "" {
+		where = " -n " + m.defaultNamespace
+	}
+	m.err = fmt.Errorf("Not permitted: 'kubectl auth can-i %s %s%s' says no for context %s",
+		p.verb, p.resource, where, m.permissionsContext)
+	return m, true
+}
+
+func containsPermission(list []actionPermission, p actionPermission) bool {
+	for _, item := range list {
+		if item == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Test that actions kubectl auth can-i refuses are marked in the menu and
+// cannot be chosen, and that answers are cached per context.
+func TestActionPermissionsAreCheckedAndCached(t *testing.T) {
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"  *can-i*) echo \"$*\" >> " + calls + "\n" +
+		"    case \"$3\" in delete|patch) echo no; exit 1 ;; *) echo yes ;; esac ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	m := Model{
+		kubectlClient:    kubectl.NewClient(),
+		keys:             defaultKeyMap(),
+		textInput:        textinput.New(),
+		width:            80,
+		height:           40,
+		checkPermissions: true,
+		selectedResource: ResourceConfigMaps,
+	}
+	m = m.navigateToActionSelection()
+	cmd := m.checkActionPermissions()
+	if cmd == nil {
+		t.Fatal("expected permissions to be checked")
+	}
+	msg, ok := cmd().(permissionsCheckedMsg)
+	if !ok || msg.err != nil || msg.context != "dev" {
+		t.Fatalf("unexpected permissions message: %+v", msg)
+	}
+	m = m.storePermissions(msg)
+
+	descriptions := map[string]string{}
+	for _, item := range m.list.Items() {
+		si := item.(ui.SimpleItem)
+		descriptions[si.Title()] = si.Description()
+	}
+	if !strings.Contains(descriptions["Delete"], "Not permitted") || !strings.Contains(descriptions["Edit"], "Not permitted") {
+		t.Errorf("expected Delete and Edit to be marked, got %q / %q", descriptions["Delete"], descriptions["Edit"])
+	}
+	if strings.Contains(descriptions["Get"], "Not permitted") {
+		t.Errorf("Get should be permitted, got %q", descriptions["Get"])
+	}
+
+	for i, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "Delete" {
+			m.list.Select(i)
+		}
+	}
+	updated, _ := m.handleActionSelection()
+	m = updated.(Model)
+	if m.currentScreen != ActionSelectionScreen || m.err == nil || !strings.Contains(m.err.Error(), "can-i delete configmap") {
+		t.Errorf("expected Delete to be refused, screen %v err %v", m.currentScreen, m.err)
+	}
+
+	// Everything is cached now, so no further kubectl calls are made
+	if cmd := m.checkActionPermissions(); cmd != nil {
+		t.Error("expected cached answers to be reused")
+	}
+	data, _ := os.ReadFile(calls)
+	if n := strings.Count(string(data), "can-i"); n != 4 {
+		t.Errorf("expected one can-i call each for list, get, patch and delete, got %d:\n%s", n, data)
+	}
+
+	// Another context starts with no answers
+	m.permissionsContext = "prod"
+	if _, denied := m.deniedPermission(ActionDelete); denied {
+		t.Error("answers for dev should not apply to prod")
+	}
+}
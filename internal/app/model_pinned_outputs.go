@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pinned outputs: command outputs kept in memory for the session, so they can
+// be reopened from the main menu without saving them to disk.
+
+// maxPinnedOutputs is how many outputs are kept; pinning another drops the oldest.
+const maxPinnedOutputs = 5
+
+// pinnedOutput is a command output pinned from the output screen.
+type pinnedOutput struct {
+	command  string
+	context  string
+	content  string // Output as shown, e.g. with tables drawn
+	pinnedAt time.Time
+}
+
+// pinCurrentOutput keeps the output on screen in the pinned outputs ring.
+func (m Model) pinCurrentOutput() Model {
+	pinned := append([]pinnedOutput{}, m.pinnedOutputs...)
+	pinned = append(pinned, pinnedOutput{
+		command:  m.currentCommand,
+		context:  m.currentOutputContext,
+		content:  m.currentOutputView,
+		pinnedAt: now(),
+	})
+	if len(pinned) > maxPinnedOutputs {
+		pinned = pinned[len(pinned)-maxPinnedOutputs:]
+	}
+	m.pinnedOutputs = pinned
+	m.err = fmt.Errorf("✓ Pinned output of %s (%d of %d slots used)", m.currentCommand, len(pinned), maxPinnedOutputs)
+	return m
+}
+
+// navigateToPinnedOutputs lists pinned outputs, newest first.
+func (m Model) navigateToPinnedOutputs() Model {
+	items := []list.Item{}
+	for i := len(m.pinnedOutputs) - 1; i >= 0; i-- {
+		p := m.pinnedOutputs[i]
+		desc := "Pinned " + humanizeSince(p.pinnedAt)
+		if p.context != "" {
+			desc += " · context " + p.context
+		}
+		items = append(items, ui.NewSimpleItem(p.command, desc))
+	}
+	if len(items) == 0 {
+		items = append(items, ui.NewSimpleItem("No pinned outputs", "Press 'p' on a command output to pin it"))
+	}
+	m.list = ui.NewList(items, "Pinned Outputs (Enter=view, 'd'=unpin)", m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PinnedOutputsListScreen
+	return m
+}
+
+// selectedPinnedIndex maps the highlighted row to its index in pinnedOutputs,
+// or returns -1 when nothing is pinned.
+func (m Model) selectedPinnedIndex() int {
+	if len(m.pinnedOutputs) == 0 {
+		return -1
+	}
+	return len(m.pinnedOutputs) - 1 - m.list.Index()
+}
+
+func (m Model) handlePinnedOutputSelection() (tea.Model, tea.Cmd) {
+	idx := m.selectedPinnedIndex()
+	if idx < 0 {
+		return m, nil
+	}
+	m.viewingPinnedOutput = idx
+	m.viewport.SetContent(m.pinnedOutputs[idx].content)
+	m.viewport.GotoTop()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PinnedOutputViewScreen
+	return m, nil
+}
+
+// unpinOutput removes the highlighted output from the pinned outputs.
+func (m Model) unpinOutput() Model {
+	idx := m.selectedPinnedIndex()
+	if idx < 0 {
+		return m
+	}
+	command := m.pinnedOutputs[idx].command
+	pinned := append([]pinnedOutput{}, m.pinnedOutputs[:idx]...)
+	m.pinnedOutputs = append(pinned, m.pinnedOutputs[idx+1:]...)
+
+	row := m.list.Index()
+	m = m.navigateToPinnedOutputs()
+	m.previousScreen = MainMenuScreen
+	if row >= len(m.pinnedOutputs) {
+		row = len(m.pinnedOutputs) - 1
+	}
+	if row >= 0 {
+		m.list.Select(row)
+	}
+	m.err = fmt.Errorf("✓ Unpinned output of %s", command)
+	return m
+}
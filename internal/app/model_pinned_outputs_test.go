@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that pinned outputs outlive later commands, are listed on the main
+// menu and that only the newest maxPinnedOutputs are kept.
+func TestPinnedOutputsSurviveNavigation(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, viewport: ui.NewViewport(80, 30)}
+
+	for i := 1; i <= maxPinnedOutputs+1; i++ {
+		m.currentCommand = fmt.Sprintf("kubectl get pods -n ns%d", i)
+		updated, _ := m.Update(commandExecutedMsg{result: kubectl.CommandResult{Output: fmt.Sprintf("output %d", i)}})
+		m = updated.(Model)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		m = updated.(Model)
+	}
+	if len(m.pinnedOutputs) != maxPinnedOutputs || m.pinnedOutputs[0].command != "kubectl get pods -n ns2" {
+		t.Fatalf("pinned %d outputs starting with %q, want the newest %d", len(m.pinnedOutputs), m.pinnedOutputs[0].command, maxPinnedOutputs)
+	}
+
+	m = m.navigateToMainMenu()
+	found := false
+	for i, item := range m.list.Items() {
+		if item.FilterValue() == "Pinned Outputs" {
+			m.list.Select(i)
+			found = true
+			if i == 0 || m.list.Items()[i-1].FilterValue() != "Saved Outputs" {
+				t.Errorf("expected Pinned Outputs right after Saved Outputs")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected Pinned Outputs on the main menu")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != PinnedOutputsListScreen {
+		t.Fatalf("screen = %s, want the pinned outputs list", m.currentScreen)
+	}
+
+	// Newest first
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != PinnedOutputViewScreen || !strings.Contains(m.View(), "output 6") {
+		t.Errorf("expected the newest pinned output to be shown, got:\n%s", m.View())
+	}
+}
+
+func TestUnpinOutput(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40}
+	m.pinnedOutputs = []pinnedOutput{{command: "kubectl get pods"}, {command: "kubectl get svc"}}
+	m = m.navigateToPinnedOutputs()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = updated.(Model)
+	if len(m.pinnedOutputs) != 1 || m.pinnedOutputs[0].command != "kubectl get pods" {
+		t.Errorf("pinned outputs = %v, want only kubectl get pods left", m.pinnedOutputs)
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const noPluginsTitle = "No plugins found"
+
+func (m Model) loadPlugins() tea.Cmd {
+	return withSpinner("Looking for kubectl plugins…", func() tea.Msg {
+		plugins, warnings, err := m.kubectlClient.ListPlugins()
+		return pluginsLoadedMsg{plugins: plugins, warnings: warnings, err: err}
+	})
+}
+
+func (m Model) navigateToPluginsList(plugins []string) Model {
+	var items []list.Item
+	for _, p := range plugins {
+		items = append(items, ui.NewSimpleItem(p, "kubectl "+p))
+	}
+	if len(items) == 0 {
+		items = append(items, ui.NewSimpleItem(noPluginsTitle, "Install plugins with krew (kubectl krew install neat) or put kubectl-* executables on PATH"))
+	}
+
+	m.list = ui.NewList(items, "Plugins", m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PluginsListScreen
+	return m
+}
+
+func (m Model) handlePluginSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	plugin := selected.(ui.SimpleItem).Title()
+	if plugin == noPluginsTitle {
+		return m, nil
+	}
+
+	m.selectedPlugin = plugin
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = fmt.Sprintf("Arguments for kubectl %s (optional)", plugin)
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PluginArgsScreen
+	return m, nil
+}
+
+// handlePluginArgs previews "kubectl <plugin> <args>". Plugins take any
+// arguments, so unlike other inputs an empty value is accepted.
+func (m Model) handlePluginArgs() (tea.Model, tea.Cmd) {
+	m.currentCommand = strings.TrimSpace("kubectl " + m.selectedPlugin + " " + SanitizeInput(m.textInput.Value()))
+	m.textInput.Blur()
+	return m.navigateToCommandPreview(), nil
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// podDebugCommands returns the get and describe commands behind the pod
+// debug view of the selected pod.
+func (m Model) podDebugCommands() (string, string) {
+	namespace, name := splitNamespacedName(m.selectedResourceName)
+	ns := m.fieldNamespaceFlag(namespace)
+	return "kubectl get pod " + name + " -o wide" + ns, "kubectl describe pod " + name + ns
+}
+
+// loadPodDebug runs the get and describe for the pod debug view side by side.
+func (m Model) loadPodDebug() tea.Cmd {
+	getCmd, describeCmd := m.podDebugCommands()
+	return withSpinner("Loading pod status and events…", func() tea.Msg {
+		var msg podDebugLoadedMsg
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			msg.get, msg.getErr = m.kubectlClient.ExecuteRaw(getCmd)
+		}()
+		go func() {
+			defer wg.Done()
+			msg.describe, msg.describeErr = m.kubectlClient.ExecuteRaw(describeCmd)
+		}()
+		wg.Wait()
+		return msg
+	})
+}
+
+func (m Model) navigateToPodDebug() Model {
+	m.viewport = ui.NewViewport(m.width, m.viewportHeight())
+	m.viewport.SetContent("Loading pod status and events...")
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PodDebugScreen
+	return m
+}
+
+// renderPodDebug lays out the pod's get output above the Events section of
+// its describe output.
+func (m Model) renderPodDebug(msg podDebugLoadedMsg) string {
+	getCmd, describeCmd := m.podDebugCommands()
+
+	var b strings.Builder
+	b.WriteString(m.GetHeaderStyle().Render("Status") + " " + m.GetHelpStyle().Render(getCmd) + "\n\n")
+	if text, ok := debugResultError(msg.get, msg.getErr); !ok {
+		b.WriteString(m.GetErrorStyle().Render(text))
+	} else if tables, ok := parseTables(msg.get.Output); ok {
+		b.WriteString(m.renderTables(tables, m.viewport.Width))
+	} else {
+		b.WriteString(strings.TrimRight(msg.get.Output, "\n"))
+	}
+
+	b.WriteString("\n\n" + m.GetHeaderStyle().Render("Events") + " " + m.GetHelpStyle().Render(describeCmd) + "\n\n")
+	if text, ok := debugResultError(msg.describe, msg.describeErr); !ok {
+		b.WriteString(m.GetErrorStyle().Render(text))
+	} else if events := describeEvents(msg.describe.Output); events != "" {
+		b.WriteString(events)
+	} else {
+		b.WriteString("No events found in the describe output")
+	}
+	return b.String()
+}
+
+// debugResultError returns the error text of one of the pod debug commands;
+// false means the command failed.
+func debugResultError(result kubectl.CommandResult, err error) (string, bool) {
+	switch {
+	case result.Error != "":
+		return "Error: " + strings.TrimSpace(result.Error), false
+	case err != nil:
+		return fmt.Sprintf("Error: %v", err), false
+	}
+	return "", true
+}
+
+// describeEvents returns the Events section at the end of describe output,
+// without its "Events:" heading.
+func describeEvents(output string) string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Events:") {
+			rest := strings.TrimSpace(strings.TrimPrefix(line, "Events:"))
+			events := strings.TrimRight(strings.Join(lines[i+1:], "\n"), "\n ")
+			if events == "" {
+				// describe prints "Events: <none>" when there are none
+				return rest
+			}
+			return events
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+)
+
+const describePodOutput = `Name:         web-1
+Namespace:    shop
+Status:       Running
+Events:
+  Type     Reason   Age   From     Message
+  ----     ------   ----  ----     -------
+  Warning  BackOff  2m    kubelet  Back-off restarting failed container
+`
+
+func TestDescribeEvents(t *testing.T) {
+	got := describeEvents(describePodOutput)
+	if !strings.HasPrefix(got, "  Type     Reason") || !strings.HasSuffix(got, "failed container") {
+		t.Errorf("describeEvents() = %q", got)
+	}
+	if got := describeEvents("Name: web-1\nEvents:  <none>\n"); got != "<none>" {
+		t.Errorf("describeEvents() without events = %q, want <none>", got)
+	}
+	if got := describeEvents("Name: web-1\n"); got != "" {
+		t.Errorf("describeEvents() without a section = %q, want empty", got)
+	}
+}
+
+// Test that the debug view shows the get table above the describe events.
+func TestPodDebugShowsStatusAboveEvents(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 100, height: 40, selectedResource: ResourcePods,
+		selectedAction: ActionDebug, selectedResourceName: "shop/web-1"}
+	m = m.navigateToPodDebug()
+
+	getCmd, describeCmd := m.podDebugCommands()
+	if getCmd != "kubectl get pod web-1 -o wide -n shop" || describeCmd != "kubectl describe pod web-1 -n shop" {
+		t.Fatalf("unexpected commands %q and %q", getCmd, describeCmd)
+	}
+
+	updated, _ := m.Update(podDebugLoadedMsg{
+		get:      kubectl.CommandResult{Output: "NAME    READY   STATUS\nweb-1   0/1     CrashLoopBackOff\n"},
+		describe: kubectl.CommandResult{Output: describePodOutput},
+	})
+	m = updated.(Model)
+
+	content := m.viewport.View()
+	status := strings.Index(content, "CrashLoopBackOff")
+	events := strings.Index(content, "Back-off restarting")
+	if status < 0 || events < 0 || status > events {
+		t.Errorf("expected the pod status above its events, got:\n%s", content)
+	}
+	if strings.Contains(content, "Namespace:    shop") {
+		t.Errorf("expected only the Events section of describe, got:\n%s", content)
+	}
+}
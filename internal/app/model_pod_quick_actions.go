@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyToClipboard writes text to the system clipboard; tests replace it.
+var copyToClipboard = clipboard.WriteAll
+
+// loadPodQuickActions fetches the selected pod's JSON for the quick actions menu.
+func (m Model) loadPodQuickActions() tea.Cmd {
+	namespace, name := splitNamespacedName(m.selectedResourceName)
+	namespace = strings.TrimPrefix(m.fieldNamespaceFlag(namespace), " -n ")
+
+	return withSpinner(fmt.Sprintf("Loading details of %s…", name), func() tea.Msg {
+		info, err := m.kubectlClient.GetPodInfo(name, namespace)
+		return podInfoLoadedMsg{name: name, info: info, err: err}
+	})
+}
+
+// podQuickActionItems lists the pod's IP, node and one image per container.
+// Values a pending pod does not have yet are shown as "(none)".
+func podQuickActionItems(info kubectl.PodInfo) []list.Item {
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+	items := []list.Item{
+		ui.NewSimpleItem("Pod IP", orNone(info.IP)),
+		ui.NewSimpleItem("Node", orNone(info.Node)),
+	}
+	for _, c := range info.Containers {
+		items = append(items, ui.NewSimpleItem("Image ("+c.Name+")", orNone(c.Image)))
+	}
+	return items
+}
+
+func (m Model) navigateToPodQuickActions(name string, info kubectl.PodInfo) Model {
+	title := fmt.Sprintf("Quick Actions for %s (Enter=copy)", name)
+	m.list = ui.NewList(podQuickActionItems(info), title, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = PodQuickActionsScreen
+	return m
+}
+
+// handlePodQuickActionSelection copies the highlighted value to the clipboard.
+func (m Model) handlePodQuickActionSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	item := selected.(ui.SimpleItem)
+	value := item.Description()
+	if value == "(none)" {
+		m.err = fmt.Errorf("%s is not set yet", item.Title())
+		return m, nil
+	}
+	if err := copyToClipboard(value); err != nil {
+		m.err = fmt.Errorf("could not copy to the clipboard: %v", err)
+		return m, nil
+	}
+	m.err = fmt.Errorf("✓ Copied %s: %s", item.Title(), value)
+	return m, nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that the quick actions list the pod's details and Enter copies one.
+func TestPodQuickActionsCopyValue(t *testing.T) {
+	var copied string
+	original := copyToClipboard
+	defer func() { copyToClipboard = original }()
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, selectedResource: ResourcePods, selectedAction: ActionQuickActions}
+	updated, _ := m.Update(podInfoLoadedMsg{name: "web-5d9c7-abcde", info: kubectl.PodInfo{
+		IP:         "10.0.0.7",
+		Containers: []kubectl.ContainerImage{{Name: "web", Image: "nginx:1.25"}, {Name: "proxy", Image: "envoy:v1.29"}},
+	}})
+	m = updated.(Model)
+	if m.currentScreen != PodQuickActionsScreen {
+		t.Fatalf("expected the quick actions screen, got %s", m.currentScreen)
+	}
+	if got := len(m.list.Items()); got != 4 {
+		t.Fatalf("expected the IP, node and two images, got %d items", got)
+	}
+
+	m.list.Select(3)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if copied != "envoy:v1.29" {
+		t.Errorf("expected the proxy image to be copied, got %q", copied)
+	}
+	if m.err == nil || !strings.HasPrefix(m.err.Error(), "✓") {
+		t.Errorf("expected a confirmation, got %v", m.err)
+	}
+
+	// The pod is not scheduled yet, so there is no node to copy
+	copied = ""
+	m.list.Select(1)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if copied != "" || m.err == nil || strings.HasPrefix(m.err.Error(), "✓") {
+		t.Errorf("expected an error and nothing copied for a missing node, got %q (%v)", copied, m.err)
+	}
+
+	copyToClipboard = func(string) error { return fmt.Errorf("no clipboard utility found") }
+	m.list.Select(0)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = updated.(Model); m.err == nil || !strings.Contains(m.err.Error(), "no clipboard utility") {
+		t.Errorf("expected the clipboard error, got %v", m.err)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentScreen != ActionSelectionScreen {
+		t.Errorf("expected Esc to return to the actions, got %s", m.currentScreen)
+	}
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/portforwards"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Saved port-forwards run in the background rather than taking over the
+// terminal like a port-forward run from the preview. They keep running
+// while the wizard is used and are stopped when it exits.
+
+// runningForward is a port-forward started from the saved list.
+type runningForward struct {
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the kubectl process has exited
+}
+
+// parsePortForwardCommand turns "kubectl port-forward svc/web 8080:80 -n shop"
+// into a forward to save. Flags other than the namespace and context are not
+// kept, so commands using them cannot be saved.
+func parsePortForwardCommand(cmd string) (portforwards.Forward, error) {
+	args, err := kubectl.SplitArgs(strings.TrimPrefix(strings.TrimSpace(cmd), "kubectl "))
+	if err != nil {
+		return portforwards.Forward{}, err
+	}
+	if commandVerb(cmd) != "port-forward" {
+		return portforwards.Forward{}, fmt.Errorf("not a port-forward command")
+	}
+
+	var f portforwards.Forward
+	var ports []string
+	verb := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case (arg == "-n" || arg == "--namespace") && i+1 < len(args):
+			i++
+			f.Namespace = args[i]
+		case strings.HasPrefix(arg, "--namespace="):
+			f.Namespace = strings.TrimPrefix(arg, "--namespace=")
+		case arg == "--context" && i+1 < len(args):
+			i++
+			f.Context = args[i]
+		case strings.HasPrefix(arg, "--context="):
+			f.Context = strings.TrimPrefix(arg, "--context=")
+		case strings.HasPrefix(arg, "-"):
+			return portforwards.Forward{}, fmt.Errorf("%s cannot be saved: only the resource, ports, namespace and context are kept", arg)
+		case !verb:
+			// Flags may come before "port-forward", e.g. kubectl --context prod port-forward
+			verb = true
+		case f.Resource == "":
+			f.Resource = arg
+		default:
+			ports = append(ports, arg)
+		}
+	}
+	if f.Resource == "" || len(ports) == 0 {
+		return portforwards.Forward{}, fmt.Errorf("a port-forward needs a resource and ports, e.g. svc/web 8080:80")
+	}
+	f.Ports = strings.Join(ports, " ")
+	f.Name = f.Resource + " " + f.Ports
+	var where []string
+	if f.Namespace != "" {
+		where = append(where, f.Namespace)
+	}
+	if f.Context != "" {
+		where = append(where, "context "+f.Context)
+	}
+	if len(where) > 0 {
+		f.Name += " (" + strings.Join(where, ", ") + ")"
+	}
+	return f, nil
+}
+
+// savePortForward saves the previewed port-forward command and lists the
+// saved forwards.
+func (m Model) savePortForward() (tea.Model, tea.Cmd) {
+	if m.portForwardStore == nil {
+		m.err = fmt.Errorf("port forwards store not available")
+		return m, nil
+	}
+	f, err := parsePortForwardCommand(m.currentCommand)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	if err := m.portForwardStore.Add(f); err != nil {
+		m.err = fmt.Errorf("failed to save port forward: %v", err)
+		return m, nil
+	}
+	m.err = fmt.Errorf("✓ Saved port forward %s: press Enter to start it", f.Name)
+	m = m.navigateToPortForwards()
+	m.list.Select(len(m.list.Items()) - 1)
+	return m, nil
+}
+
+func (m Model) navigateToPortForwards() Model {
+	var items []list.Item
+	if m.portForwardStore != nil {
+		for _, f := range m.portForwardStore.List() {
+			status := "○ Stopped"
+			if _, ok := m.runningForwards[f.Name]; ok {
+				status = "● Running"
+			}
+			items = append(items, ui.NewSimpleItem(f.Name, status+" · "+f.Command()))
+		}
+	}
+	if len(items) == 0 {
+		items = []list.Item{
+			ui.NewSimpleItem("No port forwards saved", "Choose 'Save Port Forward' in the preview of a port-forward command"),
+		}
+	}
+
+	index := m.list.Index()
+	m.list = ui.NewList(items, "Port Forwards (Enter=start/stop, 'd'=delete)", m.width, m.listHeight())
+	if m.currentScreen == PortForwardsScreen {
+		// Refreshing the statuses keeps the highlighted row
+		m.list.Select(index)
+	} else {
+		m.previousScreen = m.currentScreen
+	}
+	m.currentScreen = PortForwardsScreen
+	return m
+}
+
+// togglePortForward starts the highlighted forward, or stops it when it is
+// running.
+func (m Model) togglePortForward() (tea.Model, tea.Cmd) {
+	if m.portForwardStore == nil {
+		return m, nil
+	}
+	f, ok := m.portForwardStore.Get(m.list.Index())
+	if !ok {
+		return m, nil
+	}
+	if running, ok := m.runningForwards[f.Name]; ok {
+		running.cancel()
+		m = m.forgetPortForward(f.Name)
+		m.err = fmt.Errorf("✓ Stopped port forward %s", f.Name)
+		return m.navigateToPortForwards(), nil
+	}
+	return m, m.startPortForward(f)
+}
+
+// startPortForward spawns the forward's kubectl process in the background.
+func (m Model) startPortForward(f portforwards.Forward) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		lines, errc, err := m.kubectlClient.Stream(ctx, f.Args()...)
+		if err != nil {
+			cancel()
+			return portForwardEndedMsg{name: f.Name, err: err}
+		}
+		return portForwardStartedMsg{name: f.Name, lines: lines, errc: errc, cancel: cancel}
+	}
+}
+
+// waitForPortForward reads the forward's output until kubectl exits and
+// reports its last line, which explains why it stopped.
+func waitForPortForward(name string, lines <-chan string, errc <-chan error, done chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		last := ""
+		for line := range lines {
+			if line = strings.TrimSpace(line); line != "" {
+				last = line
+			}
+		}
+		err := <-errc
+		close(done)
+		return portForwardEndedMsg{name: name, done: done, output: last, err: err}
+	}
+}
+
+// forgetPortForward drops a forward from the running ones. The map is
+// replaced rather than updated, as StopPortForwards may read the old one.
+func (m Model) forgetPortForward(name string) Model {
+	running := make(map[string]runningForward, len(m.runningForwards))
+	for n, r := range m.runningForwards {
+		if n != name {
+			running[n] = r
+		}
+	}
+	m.runningForwards = running
+	return m
+}
+
+// deletePortForward stops the highlighted forward and removes it from the
+// saved ones.
+func (m Model) deletePortForward() Model {
+	if m.portForwardStore == nil {
+		return m
+	}
+	idx := m.list.Index()
+	f, ok := m.portForwardStore.Get(idx)
+	if !ok {
+		return m
+	}
+	if running, ok := m.runningForwards[f.Name]; ok {
+		running.cancel()
+		m = m.forgetPortForward(f.Name)
+	}
+	if err := m.portForwardStore.Delete(idx); err != nil {
+		m.err = fmt.Errorf("failed to delete port forward: %v", err)
+	} else {
+		m.err = fmt.Errorf("✓ Deleted port forward %s", f.Name)
+	}
+	return m.navigateToPortForwards()
+}
+
+// StopPortForwards stops the port-forwards still running and waits briefly
+// for their kubectl processes to exit. It is called once the program ends.
+func (m Model) StopPortForwards() {
+	for _, r := range m.runningForwards {
+		r.cancel()
+	}
+	for _, r := range m.runningForwards {
+		select {
+		case <-r.done:
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
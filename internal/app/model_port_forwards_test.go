@@ -0,0 +1,124 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/portforwards"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParsePortForwardCommand(t *testing.T) {
+	tests := []struct {
+		cmd     string
+		want    portforwards.Forward
+		wantErr bool
+	}{
+		{
+			cmd:  "kubectl port-forward svc/web 8080:80",
+			want: portforwards.Forward{Name: "svc/web 8080:80", Resource: "svc/web", Ports: "8080:80"},
+		},
+		{
+			cmd:  "kubectl port-forward pod/api -n shop 9000:9000 9090",
+			want: portforwards.Forward{Name: "pod/api 9000:9000 9090 (shop)", Resource: "pod/api", Ports: "9000:9000 9090", Namespace: "shop"},
+		},
+		{
+			cmd:  "kubectl port-forward --namespace=shop svc/db 5432",
+			want: portforwards.Forward{Name: "svc/db 5432 (shop)", Resource: "svc/db", Ports: "5432", Namespace: "shop"},
+		},
+		{
+			cmd: "kubectl --context prod port-forward svc/db 5432 -n shop",
+			want: portforwards.Forward{Name: "svc/db 5432 (shop, context prod)", Resource: "svc/db", Ports: "5432",
+				Namespace: "shop", Context: "prod"},
+		},
+		{
+			cmd:  "kubectl port-forward svc/web 8080:80 --context=staging",
+			want: portforwards.Forward{Name: "svc/web 8080:80 (context staging)", Resource: "svc/web", Ports: "8080:80", Context: "staging"},
+		},
+		{cmd: "kubectl port-forward svc/web", wantErr: true},
+		{cmd: "kubectl port-forward --address 0.0.0.0 svc/web 8080:80", wantErr: true},
+		{cmd: "kubectl get pods", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parsePortForwardCommand(tt.cmd)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePortForwardCommand(%q) error = %v, wantErr %v", tt.cmd, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parsePortForwardCommand(%q) = %+v, want %+v", tt.cmd, got, tt.want)
+		}
+	}
+
+	// The saved context is used when the forward is started
+	f := portforwards.Forward{Resource: "svc/db", Ports: "5432", Namespace: "shop", Context: "prod"}
+	if got, want := f.Command(), "kubectl port-forward svc/db 5432 -n shop --context prod"; got != want {
+		t.Errorf("Command() = %q, want %q", got, want)
+	}
+}
+
+// Test that a saved port-forward starts in the background, shows as running
+// and reports why it stopped when kubectl exits on its own.
+func TestSavedPortForwardLifecycle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$2\" in\n" +
+		"  svc/web) echo 'Forwarding from 127.0.0.1:8080 -> 80'; exec sleep 30 ;;\n" +
+		"  *) echo 'error: unable to listen on any of the requested ports' >&2; exit 1 ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	store, err := portforwards.NewStore()
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	m := Model{kubectlClient: kubectl.NewClient(), portForwardStore: store, keys: defaultKeyMap(), textInput: textinput.New(),
+		width: 80, height: 40, currentScreen: CommandPreviewScreen, currentCommand: "kubectl port-forward svc/web 8080:80"}
+
+	updated, _ := m.savePortForward()
+	m = updated.(Model)
+	if m.currentScreen != PortForwardsScreen || len(store.List()) != 1 {
+		t.Fatalf("expected the forward to be saved and listed, got %s with %d saved", m.currentScreen, len(store.List()))
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, wait := m.Update(cmd())
+	m = updated.(Model)
+	defer m.StopPortForwards()
+	if desc := m.list.SelectedItem().(ui.SimpleItem).Description(); !strings.HasPrefix(desc, "● Running") {
+		t.Fatalf("expected the forward to be running, got %q", desc)
+	}
+
+	// Stopping it from the list is not reported again when kubectl exits
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(wait())
+	m = updated.(Model)
+	if len(m.runningForwards) != 0 || m.err == nil || !strings.HasPrefix(m.err.Error(), "✓ Stopped") {
+		t.Fatalf("expected the forward to be stopped, got %v", m.err)
+	}
+
+	// A forward whose port is taken stops straight away
+	broken := portforwards.Forward{Name: "svc/broken 8080:80", Resource: "svc/broken", Ports: "8080:80"}
+	updated, wait = m.Update(m.startPortForward(broken)())
+	m = updated.(Model)
+	updated, _ = m.Update(wait())
+	m = updated.(Model)
+	if len(m.runningForwards) != 0 || m.err == nil || !strings.Contains(m.err.Error(), "unable to listen") {
+		t.Errorf("expected kubectl's reason for stopping, got %v", m.err)
+	}
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Protected contexts: kube contexts matching a protected_contexts pattern,
+// such as production clusters, must be confirmed by typing their name before
+// the wizard switches to them or runs a command against them. A context only
+// has to be confirmed once per session.
+
+// compileProtectedContexts compiles the protected context patterns. Each
+// pattern must match the whole context name. An invalid pattern protects
+// every context, so a typo never leaves a cluster unprotected.
+func compileProtectedContexts(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return []*regexp.Regexp{regexp.MustCompile(".*")}, fmt.Errorf("invalid protected_contexts pattern %q: %v", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// isProtectedContext reports whether name matches a protected pattern.
+func (m Model) isProtectedContext(name string) bool {
+	for _, re := range m.protectedContexts {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsContextConfirmation reports whether name is protected and has not
+// been confirmed yet this session.
+func (m Model) needsContextConfirmation(name string) bool {
+	return name != "" && m.isProtectedContext(name) && !m.confirmedContexts[name]
+}
+
+// protectedCommandContext returns the context cmd runs in, the one named
+// with --context or else the current context, when it must be confirmed
+// before the command runs. cmd is expected with its variables expanded.
+// Nothing is looked up unless protected patterns are configured.
+func (m Model) protectedCommandContext(cmd string) string {
+	if len(m.protectedContexts) == 0 {
+		return ""
+	}
+	name := m.commandRunContext(cmd)
+	if name == "" || !m.needsContextConfirmation(name) {
+		return ""
+	}
+	return name
+}
+
+// confirmContextThen runs action, a change to the current context's cluster
+// such as creating a namespace, once the context is confirmed if it is
+// protected.
+func (m Model) confirmContextThen(action tea.Cmd) (Model, tea.Cmd) {
+	if name := m.protectedCommandContext(""); name != "" {
+		m = m.navigateToContextConfirmation(name, false)
+		m.confirmingAction = action
+		return m, nil
+	}
+	return m, action
+}
+
+// navigateToContextConfirmation asks for the name of a protected context to
+// be typed. With runCommand the current command runs once it is confirmed,
+// otherwise the wizard switches to the context.
+func (m Model) navigateToContextConfirmation(name string, runCommand bool) Model {
+	m.confirmingContext = name
+	m.confirmingRun = runCommand
+	m.confirmingAction = nil
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = name
+	m.textInput.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = ContextConfirmationScreen
+	return m
+}
+
+func (m Model) handleContextConfirmation() (tea.Model, tea.Cmd) {
+	name := m.confirmingContext
+	if strings.TrimSpace(m.textInput.Value()) != name {
+		m.err = fmt.Errorf("type %s exactly to continue, or press Esc to cancel", name)
+		return m, nil
+	}
+
+	// Replaced rather than updated, as running commands may still read the old map
+	confirmed := make(map[string]bool, len(m.confirmedContexts)+1)
+	for c := range m.confirmedContexts {
+		confirmed[c] = true
+	}
+	confirmed[name] = true
+	m.confirmedContexts = confirmed
+
+	m.err = nil
+	m.confirmingContext = ""
+	m.textInput.Blur()
+	if action := m.confirmingAction; action != nil {
+		m.confirmingAction = nil
+		return m.navigateToContextsAndNamespacesMenu(), action
+	}
+	if m.confirmingRun {
+		m = m.navigateToCommandPreview()
+		return m, m.executeCommand()
+	}
+	return m, m.switchContext(name)
+}
+
+// navigateBackFromContextConfirmation returns to where the confirmation was
+// asked for.
+func (m Model) navigateBackFromContextConfirmation() Model {
+	m.confirmingContext = ""
+	m.textInput.Blur()
+	if m.confirmingAction != nil {
+		m.confirmingAction = nil
+		return m.navigateToContextsAndNamespacesMenu()
+	}
+	if m.confirmingRun {
+		return m.navigateToCommandPreview()
+	}
+	if m.previousScreen == RecentContextsScreen {
+		return m.navigateToRecentContexts()
+	}
+	return m.navigateToContextsList()
+}
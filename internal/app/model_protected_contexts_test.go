@@ -0,0 +1,237 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func TestCompileProtectedContexts(t *testing.T) {
+	patterns, err := compileProtectedContexts([]string{".*prod.*", " ", "staging-eu"})
+	if err != nil {
+		t.Fatalf("compileProtectedContexts() error = %v", err)
+	}
+	m := Model{protectedContexts: patterns}
+	for name, want := range map[string]bool{
+		"prod":          true,
+		"eks-prod-west": true,
+		"staging-eu":    true,
+		"staging-eu-2":  false,
+		"dev":           false,
+	} {
+		if got := m.isProtectedContext(name); got != want {
+			t.Errorf("isProtectedContext(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	patterns, err = compileProtectedContexts([]string{"dev", "prod(", "staging"})
+	if err == nil || !strings.Contains(err.Error(), "prod(") {
+		t.Errorf("expected an error naming the invalid pattern, got %v", err)
+	}
+	// Failing closed, every context is protected
+	m = Model{protectedContexts: patterns}
+	if !m.isProtectedContext("prod-eu") || !m.isProtectedContext("anything") {
+		t.Errorf("expected an invalid pattern to protect every context")
+	}
+
+	cfg := config.Default()
+	cfg.ProtectedContexts = []string{"prod("}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "prod(") {
+		t.Errorf("expected the config to be invalid, got %v", err)
+	}
+}
+
+// Test that a protected context is only switched to, and only runs commands,
+// once its name has been typed.
+func TestProtectedContextRequiresTypedName(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *get-contexts*) echo dev; echo prod ;;\n" +
+		"  *current-context*) echo prod ;;\n" +
+		"  *use-context*) ;;\n" +
+		"  *) echo ran ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	patterns, _ := compileProtectedContexts([]string{".*prod.*"})
+	m := Model{
+		kubectlClient:     kubectl.NewClient(),
+		keys:              defaultKeyMap(),
+		textInput:         textinput.New(),
+		width:             80,
+		height:            40,
+		protectedContexts: patterns,
+		currentScreen:     ContextsListScreen,
+	}
+	m.list = ui.NewList([]list.Item{ui.NewSimpleItem("prod", "")}, "Contexts", 80, 36)
+
+	updated, cmd := m.handleContextSelection()
+	m = updated.(Model)
+	if m.currentScreen != ContextConfirmationScreen || cmd != nil {
+		t.Fatalf("expected confirmation before switching, got screen %v", m.currentScreen)
+	}
+
+	m.textInput.SetValue("prd")
+	updated, cmd = m.handleContextConfirmation()
+	m = updated.(Model)
+	if cmd != nil || m.err == nil || m.currentScreen != ContextConfirmationScreen {
+		t.Fatalf("expected a mistyped name to be refused, err %v", m.err)
+	}
+
+	m.textInput.SetValue("prod")
+	updated, cmd = m.handleContextConfirmation()
+	m = updated.(Model)
+	if msg, ok := cmd().(contextSwitchedMsg); !ok || msg.err != nil || msg.newContext != "prod" {
+		t.Fatalf("expected a switch to prod, got %+v", msg)
+	}
+	if !m.confirmedContexts["prod"] {
+		t.Error("expected prod to be confirmed for the session")
+	}
+
+	// A confirmed context runs commands straight away
+	m.currentCommand = "kubectl get pods"
+	if _, ok := m.executeCommand()().(contextConfirmationNeededMsg); ok {
+		t.Error("a confirmed context should not be confirmed again")
+	}
+
+	// Starting out in an unconfirmed protected context asks before running
+	m.confirmedContexts = nil
+	msg, ok := m.executeCommand()().(contextConfirmationNeededMsg)
+	if !ok || msg.context != "prod" {
+		t.Fatalf("expected a confirmation request, got %#v", msg)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if m.currentScreen != ContextConfirmationScreen || !m.confirmingRun {
+		t.Fatalf("expected the confirmation screen for running, got %v", m.currentScreen)
+	}
+	m.textInput.SetValue("prod")
+	updated, cmd = m.handleContextConfirmation()
+	m = updated.(Model)
+	if m.currentScreen != CommandPreviewScreen || cmd == nil {
+		t.Errorf("expected the command to run from the preview, got screen %v", m.currentScreen)
+	}
+}
+
+// Test that a command naming a protected context with --context is
+// confirmed even when the current context is not protected.
+func TestProtectedContextFlagRequiresConfirmation(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"  *) echo ran ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	patterns, _ := compileProtectedContexts([]string{".*prod.*"})
+	m := Model{kubectlClient: kubectl.NewClient(), protectedContexts: patterns}
+
+	for _, cmd := range []string{"kubectl get pods --context=prod", "kubectl --context prod delete pod web"} {
+		m.currentCommand = cmd
+		if msg, ok := m.executeCommand()().(contextConfirmationNeededMsg); !ok || msg.context != "prod" {
+			t.Errorf("%q: expected a confirmation request for prod, got %#v", cmd, msg)
+		}
+	}
+
+	for _, cmd := range []string{"kubectl get pods", "kubectl get pods --context=dev", "kubectl exec -it web -- env --context=prod"} {
+		m.currentCommand = cmd
+		if m.protectedCommandContext(cmd) != "" {
+			t.Errorf("%q: expected no confirmation", cmd)
+		}
+	}
+}
+
+// Test that the checks before running a command see allowed variables
+// expanded, as the command runs with them.
+func TestProtectedContextFromExpandedVariable(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"  *) echo ran ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("CTX", "prod-eu")
+	t.Setenv("VERB", "delete")
+
+	client := kubectl.NewClient()
+	client.AllowedEnv = []string{"CTX", "VERB"}
+	patterns, _ := compileProtectedContexts([]string{".*prod.*"})
+	m := Model{kubectlClient: client, protectedContexts: patterns, currentCommand: "kubectl delete pod web --context=${CTX}"}
+	if msg, ok := m.executeCommand()().(contextConfirmationNeededMsg); !ok || msg.context != "prod-eu" {
+		t.Errorf("expected a confirmation request for prod-eu, got %#v", msg)
+	}
+
+	m = Model{kubectlClient: client, readOnly: true, currentCommand: "kubectl $VERB pod web"}
+	if msg, ok := m.executeCommand()().(commandExecutedMsg); !ok || msg.err == nil {
+		t.Errorf("expected read-only mode to refuse the expanded delete, got %#v", msg)
+	}
+}
+
+// Test that creating or deleting a namespace on a protected context waits
+// for the context to be confirmed.
+func TestNamespaceChangesOnProtectedContextRequireConfirmation(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo prod ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	patterns, _ := compileProtectedContexts([]string{"prod"})
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		kubectlClient: kubectl.NewClient(), protectedContexts: patterns}
+
+	m.deletingNamespace = "team-a"
+	m.list = ui.NewList([]list.Item{ui.NewSimpleItem("Cancel", ""), ui.NewSimpleItem("Confirm Delete", "")}, "", 80, 36)
+	m.list.Select(1)
+	updated, cmd := m.handleDeleteNamespaceConfirmation()
+	m = updated.(Model)
+	if cmd != nil || m.currentScreen != ContextConfirmationScreen || m.confirmingAction == nil {
+		t.Fatalf("expected the delete to wait for prod to be confirmed, got %s", m.currentScreen)
+	}
+	m.textInput.SetValue("prod")
+	updated, cmd = m.handleContextConfirmation()
+	if m = updated.(Model); cmd == nil || m.currentScreen != ContextsNamespacesMenuScreen {
+		t.Fatalf("expected the delete to start once prod is confirmed, got %s", m.currentScreen)
+	}
+
+	// Once confirmed, the context is not asked for again this session
+	m = m.navigateToCreateNamespace()
+	m.textInput.SetValue("team-b")
+	if _, cmd = m.handleCreateNamespace(); cmd == nil {
+		t.Errorf("expected the create to start on the confirmed context")
+	}
+
+	m.confirmedContexts = nil
+	m = m.navigateToCreateNamespace()
+	m.textInput.SetValue("team-b")
+	updated, cmd = m.handleCreateNamespace()
+	if m = updated.(Model); cmd != nil || m.currentScreen != ContextConfirmationScreen {
+		t.Fatalf("expected the create to wait for prod to be confirmed, got %s", m.currentScreen)
+	}
+	if m = m.navigateBack(); m.confirmingAction != nil || m.currentScreen != ContextsNamespacesMenuScreen {
+		t.Errorf("expected Esc to drop the create, got %s", m.currentScreen)
+	}
+}
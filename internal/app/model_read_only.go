@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Read-only mode: with read_only set in the config file, actions that change
+// the cluster are left out of the menus and only commands with a verb known
+// to read are run, whether built by the wizard, typed or saved as favourites.
+// Plugins and verbs unknown to the wizard are refused as they may write.
+
+// readOnlyVerbs lists the kubectl verbs that only read the cluster or the
+// local kubeconfig.
+var readOnlyVerbs = map[string]bool{
+	"api-resources": true,
+	"api-versions":  true,
+	"cluster-info":  true,
+	"completion":    true,
+	"describe":      true,
+	"diff":          true,
+	"events":        true,
+	"explain":       true,
+	"get":           true,
+	"help":          true,
+	"kustomize":     true,
+	"logs":          true,
+	"options":       true,
+	"port-forward":  true,
+	"top":           true,
+	"version":       true,
+	"wait":          true,
+}
+
+// readOnlySubcommands lists, for the verbs that group subcommands, those
+// that only read; "rollout restart", "auth reconcile" or "config set" are
+// refused.
+var readOnlySubcommands = map[string]map[string]bool{
+	"auth":    {"can-i": true, "whoami": true},
+	"config":  {"current-context": true, "get-clusters": true, "get-contexts": true, "get-users": true, "view": true},
+	"plugin":  {"list": true},
+	"rollout": {"history": true, "status": true},
+}
+
+// readOnlyMenuTitles are menu entries outside the action menu that change
+// the cluster.
+var readOnlyMenuTitles = map[string]bool{
+	"Create Namespace": true,
+	"Delete Namespace": true,
+	"Plugins":          true,
+}
+
+// isMutatingCommand reports whether cmd can change the cluster, which is
+// assumed unless its verb is known to only read.
+func isMutatingCommand(cmd string) bool {
+	verb := commandVerb(cmd)
+	if subcommands, ok := readOnlySubcommands[verb]; ok {
+		args := commandArgs(cmd)
+		return len(args) < 2 || !subcommands[args[1]]
+	}
+	return !readOnlyVerbs[verb]
+}
+
+// isMutatingAction reports whether a wizard action changes the cluster.
+func isMutatingAction(a Action) bool {
+	switch a {
+	case ActionEdit, ActionEditYAML, ActionDelete, ActionExec, ActionRestart, ActionCordon, ActionUncordon, ActionDrain:
+		return true
+	}
+	return false
+}
+
+// readOnlyError explains why cmd is not run in read-only mode.
+func readOnlyError(cmd string) error {
+	return fmt.Errorf("read-only mode: 'kubectl %s' is not known to only read the cluster and is disabled (read_only is set in the config file)", commandVerb(cmd))
+}
+
+// withoutMutatingItems drops the menu items that change the cluster when
+// read-only mode is on.
+func (m Model) withoutMutatingItems(items []list.Item) []list.Item {
+	if !m.readOnly {
+		return items
+	}
+	kept := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		title := item.(ui.SimpleItem).Title()
+		if readOnlyMenuTitles[title] {
+			continue
+		}
+		if action, ok := actionFromTitle(title); ok && isMutatingAction(action) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
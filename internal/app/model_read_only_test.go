@@ -0,0 +1,108 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func TestIsMutatingCommand(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want bool
+	}{
+		{"kubectl get pods -n default", false},
+		{"kubectl describe deployment web", false},
+		{"kubectl logs web -f", false},
+		{"kubectl port-forward svc/web 8080:80", false},
+		{"kubectl rollout status deployment/web", false},
+		{"kubectl delete pod web", true},
+		{"kubectl edit deployment web", true},
+		{"kubectl exec -it web -- sh", true},
+		{"kubectl scale deployment web --replicas=0", true},
+		{"kubectl drain node-1 --ignore-daemonsets", true},
+		{"kubectl apply -f app.yaml", true},
+		{"kubectl rollout restart deployment/web", true},
+		{"scale deployment web --replicas=3", true},
+		{"kubectl -n prod delete pod web", true},
+		{"kubectl --context=prod delete pod web", true},
+		{"kubectl --context prod --kubeconfig /tmp/kc scale deployment web --replicas=0", true},
+		{"kubectl --namespace=prod -v 6 rollout undo deployment/web", true},
+		{"kubectl -n prod get pods", false},
+		{"kubectl --context prod rollout history deployment/web", false},
+		{"kubectl auth can-i delete pods", false},
+		{"kubectl auth reconcile -f rbac.yaml", true},
+		{"kubectl config get-contexts", false},
+		{"kubectl config set-context prod --namespace=team", true},
+		{"kubectl view-secret db", true},
+		{"kubectl -n prod neat get pod web", true},
+		{"kubectl set image deployment/web web=nginx:1.27", true},
+		{"kubectl", true},
+	}
+	for _, tt := range tests {
+		if got := isMutatingCommand(tt.cmd); got != tt.want {
+			t.Errorf("isMutatingCommand(%q) = %v, want %v", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestReadOnlyHidesMutatingActions(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, readOnly: true, selectedResource: ResourcePods}
+	m = m.navigateToActionSelection()
+
+	var titles []string
+	for _, item := range m.list.Items() {
+		titles = append(titles, item.(ui.SimpleItem).Title())
+	}
+	got := strings.Join(titles, ",")
+	if got != "Get,Top (Metrics),Describe,Debug,Wait,View YAML,Owners,Quick Actions,Logs,Port Forward,Extract Field" {
+		t.Errorf("unexpected read-only actions: %s", got)
+	}
+
+	m = m.navigateToContextsAndNamespacesMenu()
+	for _, item := range m.list.Items() {
+		if title := item.(ui.SimpleItem).Title(); strings.Contains(title, "Namespace") && title != "Set Default Namespace" {
+			t.Errorf("read-only mode should hide %q", title)
+		}
+	}
+
+	m = m.navigateToMainMenu()
+	for _, item := range m.list.Items() {
+		if title := item.(ui.SimpleItem).Title(); title == "Plugins" {
+			t.Error("read-only mode should hide Plugins, which may change the cluster")
+		}
+	}
+
+	m.readOnly = false
+	m = m.navigateToActionSelection()
+	if len(m.list.Items()) <= len(titles) {
+		t.Error("expected all actions without read-only mode")
+	}
+}
+
+func TestReadOnlyRefusesMutatingCommands(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, readOnly: true}
+	m.currentCommand = "kubectl delete pod web"
+
+	msg, ok := m.executeCommand()().(commandExecutedMsg)
+	if !ok || msg.err == nil || !strings.Contains(msg.err.Error(), "read-only mode") {
+		t.Fatalf("expected delete to be refused, got %+v", msg)
+	}
+
+	m.currentScreen = CustomCommandScreen
+	m.textInput.SetValue("scale deployment web --replicas=0")
+	updated, _ := m.handleCustomCommandInput()
+	m = updated.(Model)
+	if m.currentScreen != CustomCommandScreen || m.err == nil || !strings.Contains(m.err.Error(), "kubectl scale") {
+		t.Errorf("expected custom scale to be refused, screen %v err %v", m.currentScreen, m.err)
+	}
+
+	m.err = nil
+	m.textInput.SetValue("get pods")
+	updated, _ = m.handleCustomCommandInput()
+	if m = updated.(Model); m.currentScreen != CommandPreviewScreen {
+		t.Errorf("expected read-only custom command to reach the preview, got %v (err %v)", m.currentScreen, m.err)
+	}
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	noRecentContextsTitle      = "No recent contexts"
+	recentContextsUnavailable  = "Recent contexts unavailable"
+	recentContextsListTitle    = "Recent Contexts (Enter=switch)"
+	recentContextCurrentMarker = "(current)"
+)
+
+// recordContextUse remembers a context for the recent contexts switcher.
+func (m Model) recordContextUse(name string) {
+	if m.prefsStore != nil {
+		if err := m.prefsStore.RecordContext(name); err != nil {
+			logger.Error("Failed to record recent context: %v", err)
+		}
+	}
+}
+
+// navigateToRecentContexts lists the last few contexts used, most recent
+// first, for switching between clusters without the full contexts list.
+func (m Model) navigateToRecentContexts() Model {
+	var items []list.Item
+	if m.prefsStore == nil {
+		items = []list.Item{ui.NewSimpleItem(recentContextsUnavailable, "")}
+	} else {
+		current, _ := m.kubectlClient.GetCurrentContext()
+		for _, name := range m.prefsStore.RecentContexts() {
+			desc := ""
+			if name == current {
+				desc = recentContextCurrentMarker
+			}
+			items = append(items, ui.NewSimpleItem(name, desc))
+		}
+	}
+	if len(items) == 0 {
+		items = []list.Item{
+			ui.NewSimpleItem(noRecentContextsTitle, "Switch context under Contexts & Namespaces to see it here"),
+		}
+	}
+
+	m.list = ui.NewList(items, recentContextsListTitle, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = RecentContextsScreen
+	return m
+}
+
+// handleRecentContextSelection switches to the highlighted context, asking
+// for it to be typed first when it is protected.
+func (m Model) handleRecentContextSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	item := selected.(ui.SimpleItem)
+	switch item.Title() {
+	case noRecentContextsTitle, recentContextsUnavailable:
+		return m, nil
+	}
+	if item.Description() == recentContextCurrentMarker {
+		return m.navigateToMainMenu(), nil
+	}
+	if m.needsContextConfirmation(item.Title()) {
+		return m.navigateToContextConfirmation(item.Title(), false), nil
+	}
+	return m, m.switchContext(item.Title())
+}
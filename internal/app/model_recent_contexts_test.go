@@ -0,0 +1,84 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/prefs"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that switched contexts are listed most recent first by ctrl+k, with
+// the current one marked, and that choosing another switches to it.
+func TestRecentContextsSwitcher(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *get-contexts*) echo dev; echo staging; echo prod ;;\n" +
+		"  *current-context*) echo prod ;;\n" +
+		"  *use-context*) exit 0 ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	store, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create prefs store: %v", err)
+	}
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		kubectlClient: kubectl.NewClient(), prefsStore: store, currentScreen: MainMenuScreen}
+	for _, name := range []string{"dev", "staging", "prod"} {
+		updated, _ := m.Update(contextSwitchedMsg{newContext: name})
+		m = updated.(Model)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	m = updated.(Model)
+	if m.currentScreen != RecentContextsScreen {
+		t.Fatalf("expected the recent contexts, got %s", m.currentScreen)
+	}
+	var titles []string
+	for _, item := range m.list.Items() {
+		titles = append(titles, item.(ui.SimpleItem).Title())
+	}
+	if want := []string{"prod", "staging", "dev"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("got %v, want %v", titles, want)
+	}
+	if desc := m.list.Items()[0].(ui.SimpleItem).Description(); desc != recentContextCurrentMarker {
+		t.Errorf("expected prod to be marked current, got %q", desc)
+	}
+
+	m.list.Select(1)
+	_, cmd := m.handleRecentContextSelection()
+	if cmd == nil {
+		t.Fatalf("expected a context switch")
+	}
+	if msg, ok := cmd().(contextSwitchedMsg); !ok || msg.newContext != "staging" || msg.err != nil {
+		t.Errorf("expected a switch to staging, got %#v", msg)
+	}
+}
+
+// Test that recent contexts are deduplicated and capped.
+func TestRecordContextCapsRecentContexts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create prefs store: %v", err)
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "b"} {
+		if err := store.RecordContext(name); err != nil {
+			t.Fatalf("RecordContext(%q) failed: %v", name, err)
+		}
+	}
+	if got, want := store.RecentContexts(), []string{"b", "f", "e", "d", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
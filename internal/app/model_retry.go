@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// canRetry reports whether the screen shows a failed command that can be
+// run again.
+func (m Model) canRetry() bool {
+	return m.lastRunFailed && (m.currentScreen == CommandOutputScreen || m.currentScreen == ErrorScreen)
+}
+
+// retryHelp lists the retry keys after a failed command, ending with a
+// separator so the screen's own help follows on the same line.
+func (m Model) retryHelp() string {
+	if !m.canRetry() {
+		return ""
+	}
+	return fmt.Sprintf("Press '%s' to retry | '%s' to edit & retry | ", m.keys.Retry.Help().Key, m.keys.EditRetry.Help().Key)
+}
+
+// retryCommand runs the failed command again as it was.
+func (m Model) retryCommand() (tea.Model, tea.Cmd) {
+	if m.loading || m.cancelCommand != nil {
+		m.err = fmt.Errorf("A command is still running")
+		return m, nil
+	}
+	m.err = nil
+	return m.runCommand()
+}
+
+// editAndRetryCommand opens the failed command in Custom Command to be
+// changed before running it again.
+func (m Model) editAndRetryCommand() Model {
+	command := m.currentCommand
+	m = m.navigateToCustomCommand()
+	m.textInput.SetValue(strings.TrimPrefix(command, "kubectl "))
+	m.textInput.CursorEnd()
+	m.err = nil
+	return m
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that a failed command offers Retry and Edit & Retry on its output,
+// and that the keys are left to their other actions after a success.
+func TestRetryFailedCommand(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		viewport: ui.NewViewport(80, 30), currentCommand: "kubectl get pods -n missing"}
+	press := func(m Model, k string) (Model, tea.Cmd) {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return updated.(Model), cmd
+	}
+
+	updated, _ := m.Update(commandExecutedMsg{result: kubectl.CommandResult{Error: "something went wrong"}})
+	m = updated.(Model)
+	if m.currentScreen != CommandOutputScreen {
+		t.Fatalf("expected the output screen, got %v", m.currentScreen)
+	}
+	if !strings.Contains(m.View(), "'t' to retry | 'e' to edit & retry") {
+		t.Errorf("expected the retry keys in the help, got:\n%s", m.View())
+	}
+
+	retried, cmd := press(m, "t")
+	if cmd == nil || retried.currentCommand != "kubectl get pods -n missing" || retried.theme != m.theme {
+		t.Errorf("expected t to run the command again instead of toggling the theme")
+	}
+
+	edited, _ := press(m, "e")
+	if edited.currentScreen != CustomCommandScreen {
+		t.Fatalf("expected e to open Custom Command, got %v", edited.currentScreen)
+	}
+	if got := edited.textInput.Value(); got != "get pods -n missing" {
+		t.Errorf("expected the failed command to be loaded, got %q", got)
+	}
+
+	updated, _ = m.Update(commandExecutedMsg{result: kubectl.CommandResult{Output: "NAME\nweb\n"}})
+	m = updated.(Model)
+	if strings.Contains(m.View(), "to retry") {
+		t.Errorf("expected no retry keys after a successful command")
+	}
+	if toggled, _ := press(m, "t"); toggled.theme == m.theme {
+		t.Errorf("expected t to toggle the theme after a successful command")
+	}
+}
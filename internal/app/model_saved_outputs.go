@@ -11,6 +11,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/diff"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 )
 
@@ -106,8 +107,46 @@ func (m Model) deleteSavedOutputGroup(base string) tea.Cmd {
 	}
 }
 
+func (m Model) navigateToDeleteSavedOutputConfirmation(base string) Model {
+	count := len(m.savedOutputsByBase[base])
+	noun := "versions"
+	if count == 1 {
+		noun = "version"
+	}
+
+	items := []list.Item{
+		ui.NewSimpleItem("Cancel", "Go back without deleting"),
+		ui.NewSimpleItem("Confirm Delete", fmt.Sprintf("Permanently delete %d %s of %s", count, noun, base)),
+	}
+	title := fmt.Sprintf("⚠️  CONFIRM DELETION: %s (%d %s)", base, count, noun)
+	m.deletingSavedOutputBase = base
+	m.list = ui.NewList(items, title, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = DeleteSavedOutputConfirmationScreen
+	return m
+}
+
+func (m Model) handleDeleteSavedOutputConfirmation() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	base := m.deletingSavedOutputBase
+	m.deletingSavedOutputBase = ""
+
+	if selected.(ui.SimpleItem).Title() == "Confirm Delete" && base != "" {
+		return m, m.deleteSavedOutputGroup(base)
+	}
+
+	// Cancel - go back to the saved outputs list
+	return m.navigateToSavedOutputsGroups(), nil
+}
+
 func (m Model) navigateToSaveOutputName() Model {
-	m.textInput.SetValue("")
+	// Pre-fill a name derived from the command; it can still be edited
+	m.textInput.SetValue(suggestOutputName(m.currentCommand))
+	m.textInput.CursorEnd()
 	m.textInput.Placeholder = "Enter name (e.g. pods-output)"
 	m.textInput.Focus()
 	m.previousScreen = m.currentScreen
@@ -118,7 +157,7 @@ func (m Model) navigateToSaveOutputName() Model {
 func (m Model) navigateToSavedOutputsList() Model {
 	m.list = ui.NewList([]list.Item{
 		ui.NewSimpleItem("Loading...", ""),
-	}, "Saved Outputs", m.width, m.height-4)
+	}, "Saved Outputs", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = SavedOutputsListScreen
 	return m
@@ -140,7 +179,7 @@ func (m Model) navigateToSavedOutputsGroups() Model {
 			items = append(items, ui.NewSimpleItem(base, fmt.Sprintf("%d versions", len(m.savedOutputsByBase[base]))))
 		}
 	}
-	m.list = ui.NewList(items, "Saved Outputs (Enter=versions, 'd'=delete, 'r'=rename)", m.width, m.height-4)
+	m.list = ui.NewList(items, "Saved Outputs (Enter=versions, 'd'=delete, 'r'=rename)", m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = SavedOutputsListScreen
 	return m
@@ -174,7 +213,7 @@ func (m Model) navigateToSavedOutputVersions(base string) Model {
 			items = append(items, ui.NewSimpleItem(v, fmt.Sprintf("v%d", n)))
 		}
 	}
-	m.list = ui.NewList(items, fmt.Sprintf("Saved Outputs: %s (Enter=view, 'd'=delete)", base), m.width, m.height-4)
+	m.list = ui.NewList(items, fmt.Sprintf("Saved Outputs: %s (Enter=view, 'd'=delete)", base), m.width, m.listHeight())
 	m.previousScreen = m.currentScreen
 	m.currentScreen = SavedOutputVersionsScreen
 	return m
@@ -182,7 +221,9 @@ func (m Model) navigateToSavedOutputVersions(base string) Model {
 
 func (m Model) navigateToSavedOutputView(filename string, content string) Model {
 	m.selectedSavedOutput = filename
-	m.viewport.SetContent(content)
+	m.liveComparison = ""
+	m.liveComparisonDiff = ""
+	m.viewport.SetContent(ui.WrapContent(content, m.width))
 	// When viewing a saved output, keep its full content in sync as well
 	m.currentOutputContent = content
 	m.previousScreen = m.currentScreen
@@ -389,6 +430,10 @@ func (m Model) saveOutput(name string) tea.Cmd {
 			return outputSavedMsg{filename: "", err: err}
 		}
 
+		if err := pruneSavedOutputVersions(dir, baseName, m.maxSavedVersions); err != nil {
+			return outputSavedMsg{filename: "", err: err}
+		}
+
 		if err := m.setSavedOutputBaseNameForCommand(m.currentCommand, baseName); err != nil {
 			return outputSavedMsg{filename: "", err: err}
 		}
@@ -397,6 +442,63 @@ func (m Model) saveOutput(name string) tea.Cmd {
 	}
 }
 
+// pruneSavedOutputVersions deletes the oldest versions of base in dir so that at
+// most maxVersions remain. Version numbers are left untouched, so the next save
+// still continues from the highest remaining version. A non-positive
+// maxVersions disables pruning.
+func pruneSavedOutputVersions(dir string, base string, maxVersions int) error {
+	if maxVersions <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type savedVersion struct {
+		name    string
+		version int
+	}
+
+	versionRe := regexp.MustCompile(`^(.*)_v(\d+)$`)
+	var versions []savedVersion
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+		if name == base {
+			// The unsuffixed file is always the first version
+			versions = append(versions, savedVersion{name: name, version: 1})
+			continue
+		}
+		if matches := versionRe.FindStringSubmatch(name); matches != nil && matches[1] == base {
+			v, convErr := strconv.Atoi(matches[2])
+			if convErr != nil {
+				continue
+			}
+			versions = append(versions, savedVersion{name: name, version: v})
+		}
+	}
+
+	if len(versions) <= maxVersions {
+		return nil
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].version < versions[j].version
+	})
+
+	for _, v := range versions[:len(versions)-maxVersions] {
+		if err := os.Remove(fmt.Sprintf("%s/%s.txt", dir, v.name)); err != nil {
+			return fmt.Errorf("failed to prune saved output %s: %w", v.name, err)
+		}
+	}
+
+	return nil
+}
+
 func (m Model) deleteSavedOutput(filename string) tea.Cmd {
 	return func() tea.Msg {
 		filepath := fmt.Sprintf("saved_cmd/%s.txt", filename)
@@ -634,3 +736,117 @@ func (m Model) updateSavedOutputsIndexOnRename(oldName string, newName string) e
 	}
 	return m.saveSavedOutputsIndex(index)
 }
+
+// maxSuggestedNameLength keeps suggested output names readable
+const maxSuggestedNameLength = 60
+
+var unsafeNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// suggestOutputName derives a saved-output name from a command, e.g.
+// "kubectl get pods -n foo" becomes "get-pods-foo". Flags are dropped but the
+// values of --flag=value are kept. Returns "output" when nothing usable is left.
+func suggestOutputName(cmd string) string {
+	fields := strings.Fields(strings.TrimSpace(cmd))
+	if len(fields) > 0 && fields[0] == "kubectl" {
+		fields = fields[1:]
+	}
+
+	parts := []string{}
+	for _, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			idx := strings.Index(field, "=")
+			if idx < 0 {
+				continue
+			}
+			field = field[idx+1:]
+		}
+		field = strings.ReplaceAll(field, "/", "-")
+		field = strings.Trim(unsafeNameChars.ReplaceAllString(field, ""), "-._")
+		if field != "" {
+			parts = append(parts, field)
+		}
+	}
+
+	name := strings.Join(parts, "-")
+	if len(name) > maxSuggestedNameLength {
+		name = name[:maxSuggestedNameLength]
+	}
+	name = strings.Trim(name, "-._")
+	if !ValidateSafeName(name) {
+		return "output"
+	}
+	return name
+}
+
+// savedOutputCommand returns the command whose output is saved under base,
+// as recorded in the saved outputs index.
+func (m Model) savedOutputCommand(base string) (string, bool, error) {
+	index, err := m.loadSavedOutputsIndex()
+	if err != nil {
+		return "", false, err
+	}
+	for cmd, b := range index {
+		if b == base {
+			return cmd, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// compareWithLive re-runs the command a saved output came from so its
+// current output can be diffed against the saved one.
+func (m Model) compareWithLive() (tea.Model, tea.Cmd) {
+	cmd, ok, err := m.savedOutputCommand(m.selectedSavedOutputBase)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	if !ok {
+		m.err = fmt.Errorf("no command is recorded for %s, so it cannot be compared", m.selectedSavedOutputBase)
+		return m, nil
+	}
+	if isInteractiveCommand(cmd) || isStreamingCommand(cmd) {
+		m.err = fmt.Errorf("%s does not finish on its own, so it cannot be compared", cmd)
+		return m, nil
+	}
+
+	saved := m.currentOutputContent
+	return m, withSpinner("Running "+cmd+"…", func() tea.Msg {
+		result, err := m.kubectlClient.ExecuteRaw(cmd)
+		if err == nil && result.Error != "" {
+			err = fmt.Errorf(result.Error)
+		}
+		return liveComparedMsg{command: cmd, lines: diff.Lines(saved, result.Output), err: err}
+	})
+}
+
+// isStreamingCommand reports whether cmd keeps running until stopped, like
+// "kubectl logs -f" or "kubectl get pods --watch".
+func isStreamingCommand(cmd string) bool {
+	for _, f := range strings.Fields(cmd) {
+		switch f {
+		case "-f", "--follow", "--follow=true", "-w", "--watch", "--watch=true":
+			return true
+		}
+	}
+	return false
+}
+
+// renderDiff shows a diff with "+"/"-" markers, coloured by change.
+func (m Model) renderDiff(lines []diff.Line) string {
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch l.Op {
+		case diff.Insert:
+			b.WriteString(m.GetSuccessStyle().Render("+ " + l.Text))
+		case diff.Delete:
+			b.WriteString(m.GetErrorStyle().Render("- " + l.Text))
+		default:
+			b.WriteString("  " + l.Text)
+		}
+	}
+	return b.String()
+}
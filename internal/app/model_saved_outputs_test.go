@@ -0,0 +1,149 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/diff"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that saving more versions than maxSavedVersions prunes the oldest ones
+// and keeps the newest versions on disk.
+func TestSaveOutputPrunesOldestVersions(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	m := Model{
+		currentCommand:       "kubectl get pods",
+		currentOutputContent: "Output:\npod-a",
+		maxSavedVersions:     10,
+	}
+
+	for i := 0; i < 12; i++ {
+		msg, ok := m.saveOutput("pods")().(outputSavedMsg)
+		if !ok {
+			t.Fatalf("expected outputSavedMsg")
+		}
+		if msg.err != nil {
+			t.Fatalf("save %d failed: %v", i+1, msg.err)
+		}
+	}
+
+	entries, err := os.ReadDir("saved_cmd")
+	if err != nil {
+		t.Fatalf("failed to read saved_cmd: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, entry := range entries {
+		got[entry.Name()] = true
+	}
+
+	for _, pruned := range []string{"pods.txt", "pods_v2.txt"} {
+		if got[pruned] {
+			t.Errorf("expected %s to be pruned", pruned)
+		}
+	}
+
+	kept := []string{"pods_v3.txt", "pods_v4.txt", "pods_v5.txt", "pods_v6.txt", "pods_v7.txt",
+		"pods_v8.txt", "pods_v9.txt", "pods_v10.txt", "pods_v11.txt", "pods_v12.txt"}
+	for _, name := range kept {
+		if !got[name] {
+			t.Errorf("expected %s to be kept", name)
+		}
+	}
+}
+
+func TestSuggestOutputName(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want string
+	}{
+		{"kubectl get pods -n foo", "get-pods-foo"},
+		{"kubectl get pods -o yaml", "get-pods-yaml"},
+		{"kubectl logs deployment/web --tail=100", "logs-deployment-web-100"},
+		{"kubectl describe node worker-1.example.com", "describe-node-worker-1.example.com"},
+		{"get svc -A", "get-svc"},
+		{"kubectl", "output"},
+		{"", "output"},
+	}
+
+	for _, tt := range tests {
+		got := suggestOutputName(tt.cmd)
+		if got != tt.want {
+			t.Errorf("suggestOutputName(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+		if !ValidateSafeName(got) {
+			t.Errorf("suggestOutputName(%q) = %q is not a safe name", tt.cmd, got)
+		}
+	}
+}
+
+func TestSuggestOutputNameIsTruncated(t *testing.T) {
+	got := suggestOutputName("kubectl get pods " + strings.Repeat("very-long-name ", 10))
+	if len(got) > maxSuggestedNameLength {
+		t.Errorf("expected at most %d characters, got %d (%q)", maxSuggestedNameLength, len(got), got)
+	}
+	if !ValidateSafeName(got) {
+		t.Errorf("truncated name %q is not a safe name", got)
+	}
+}
+
+func TestSaveOutputNameIsPrefilled(t *testing.T) {
+	m := Model{textInput: textinput.New(), currentCommand: "kubectl get pods -n foo"}
+
+	m = m.navigateToSaveOutputName()
+	if got := m.textInput.Value(); got != "get-pods-foo" {
+		t.Errorf("expected prefilled name %q, got %q", "get-pods-foo", got)
+	}
+}
+
+// Test that the command a saved output came from is found in the index and
+// that a changed live output is shown as a diff.
+func TestCompareSavedOutputWithLive(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	m := Model{currentCommand: "kubectl get pods", currentOutputContent: "pod-a\npod-b", viewport: ui.NewViewport(80, 20)}
+	if msg := m.saveOutput("pods")().(outputSavedMsg); msg.err != nil {
+		t.Fatalf("save failed: %v", msg.err)
+	}
+
+	if cmd, ok, err := m.savedOutputCommand("pods"); err != nil || !ok || cmd != "kubectl get pods" {
+		t.Fatalf("savedOutputCommand() = %q, %v, %v", cmd, ok, err)
+	}
+
+	m = m.navigateToSavedOutputView("pods", "pod-a\npod-b")
+	updated, _ := m.Update(liveComparedMsg{command: "kubectl get pods", lines: diff.Lines("pod-a\npod-b", "pod-a\npod-c")})
+	m = updated.(Model)
+	if !strings.Contains(m.liveComparison, "1 line(s) added, 1 removed") {
+		t.Errorf("unexpected comparison summary %q", m.liveComparison)
+	}
+	if !strings.Contains(m.viewport.View(), "+ pod-c") {
+		t.Errorf("expected the diff in the viewport, got %q", m.viewport.View())
+	}
+
+	// Resizing the terminal keeps showing the diff under its banner
+	m.list = ui.NewList(nil, "Saved Outputs", 80, 20)
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+	if m.liveComparison == "" || !strings.Contains(m.viewport.View(), "+ pod-c") {
+		t.Errorf("expected the diff to survive a resize, got %q", m.viewport.View())
+	}
+}
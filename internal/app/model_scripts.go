@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Exporting executed commands as shell scripts.
+
+// scriptsDir is where session and history scripts are written, next to saved_cmd.
+const scriptsDir = "saved_scripts"
+
+// scriptHeader is written at the top of every exported script.
+func scriptHeader(title string) string {
+	return fmt.Sprintf("#!/bin/sh\n# %s\n# Exported by kube-wizard on %s\n\n", title, time.Now().Format("2006-01-02 15:04:05"))
+}
+
+// writeScript writes content atomically and marks the script as executable.
+func writeScript(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := storage.WriteAtomic(path, []byte(content)); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0755)
+}
+
+// sessionScriptPath returns the script that commands of this session are appended to.
+func (m Model) sessionScriptPath() string {
+	return filepath.Join(scriptsDir, "session_"+m.sessionStarted.Format("20060102_150405")+".sh")
+}
+
+// appendToSessionScript appends the last executed command to the session script,
+// creating the script with a shebang on first use.
+func (m Model) appendToSessionScript() tea.Cmd {
+	command := strings.TrimSpace(m.currentCommand)
+	path := m.sessionScriptPath()
+	return func() tea.Msg {
+		if command == "" {
+			return scriptWrittenMsg{err: fmt.Errorf("no command to append")}
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return scriptWrittenMsg{err: err}
+			}
+			content = []byte(scriptHeader("kube-wizard session"))
+		}
+
+		updated := string(content) + command + "\n"
+		if err := writeScript(path, updated); err != nil {
+			return scriptWrittenMsg{err: err}
+		}
+		return scriptWrittenMsg{path: path, appended: true}
+	}
+}
+
+// exportHistoryScript writes the whole command history, oldest first, as a shell script.
+func (m Model) exportHistoryScript() tea.Cmd {
+	return func() tea.Msg {
+		if m.historyStore == nil {
+			return scriptWrittenMsg{err: fmt.Errorf("command history is unavailable")}
+		}
+		entries := m.historyStore.List()
+		if len(entries) == 0 {
+			return scriptWrittenMsg{err: fmt.Errorf("command history is empty")}
+		}
+
+		var sb strings.Builder
+		sb.WriteString(scriptHeader("kube-wizard command history"))
+		// History is listed newest first; scripts should replay in order
+		for i := len(entries) - 1; i >= 0; i-- {
+			sb.WriteString(fmt.Sprintf("# %s\n", entries[i].Timestamp.Format("2006-01-02 15:04:05")))
+			sb.WriteString(entries[i].Command + "\n")
+		}
+
+		path := filepath.Join(scriptsDir, "history_"+time.Now().Format("20060102_150405")+".sh")
+		if err := writeScript(path, sb.String()); err != nil {
+			return scriptWrittenMsg{err: err}
+		}
+		return scriptWrittenMsg{path: path}
+	}
+}
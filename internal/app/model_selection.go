@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/favourites"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 )
 
@@ -28,17 +30,30 @@ func (m Model) handleMainMenuSelection() (tea.Model, tea.Cmd) {
 		m = m.navigateToClusterInfo()
 		return m, m.loadClusterInfo()
 	case "Favourites":
+		m.favouriteGroup = ""
 		return m.navigateToFavouritesList(), nil
 	case "Command History":
 		return m.navigateToCommandHistory(), nil
 	case "Saved Outputs":
 		return m.loadSavedOutputs()
+	case "Pinned Outputs":
+		return m.navigateToPinnedOutputs(), nil
 	case "Hotkeys":
 		return m.navigateToHotkeysList(), nil
 	case "Contexts & Namespaces":
 		return m.navigateToContextsAndNamespacesMenu(), nil
 	case "Check Cluster Connectivity":
 		return m, m.checkClusterConnectivity()
+	case "Watch Events":
+		return m.navigateToEventsStream(), m.startEventsStream()
+	case "Port Forwards":
+		return m.navigateToPortForwards(), nil
+	case "Plugins":
+		return m, m.loadPlugins()
+	case "View Logs":
+		return m, m.loadLogs()
+	case "Settings":
+		return m.navigateToSettings(0), nil
 	case "Exit":
 		return m, tea.Quit
 	}
@@ -52,28 +67,81 @@ func (m Model) handleResourceSelection() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	title := selected.(ui.SimpleItem).Title()
+	title := stripCheckbox(selected.(ui.SimpleItem).Title())
 
-	switch title {
-	case "Pods":
-		m.selectedResource = ResourcePods
-	case "Deployments":
-		m.selectedResource = ResourceDeployments
-	case "Services":
-		m.selectedResource = ResourceServices
-	case "Nodes":
-		m.selectedResource = ResourceNodes
-	case "ConfigMaps":
-		m.selectedResource = ResourceConfigMaps
-	case "Secrets":
-		m.selectedResource = ResourceSecrets
-	case "Ingress":
-		m.selectedResource = ResourceIngress
-	default:
+	// Kinds ticked with Space are combined into a single get
+	if len(m.selectedResources) > 0 {
+		for _, r := range m.selectedResources {
+			m.recordResourceUse(r.String())
+		}
+		m.selectedResource = m.selectedResources[0]
+		m.selectedAction = ActionGet
+		return m.navigateToFlagsSelection(), nil
+	}
+
+	resource, ok := parseResourceType(title)
+	if !ok {
 		return m, nil
 	}
+	m.selectedResource = resource
+	m.recordResourceUse(title)
+
+	return m.navigateToActionSelection(), m.checkActionPermissions()
+}
 
-	return m.navigateToActionSelection(), nil
+func (m Model) recordResourceUse(title string) {
+	if m.prefsStore != nil {
+		if err := m.prefsStore.RecordResourceUse(title); err != nil {
+			logger.Error("Failed to record resource usage: %v", err)
+		}
+	}
+}
+
+// toggleResourceSelection ticks or unticks the highlighted resource type for
+// a combined get such as "kubectl get pods,services".
+func (m Model) toggleResourceSelection() Model {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m
+	}
+	item := selected.(ui.SimpleItem)
+	title := stripCheckbox(item.Title())
+	resource, ok := parseResourceType(title)
+	if !ok {
+		return m
+	}
+
+	ticked := false
+	for i, r := range m.selectedResources {
+		if r == resource {
+			m.selectedResources = append(m.selectedResources[:i:i], m.selectedResources[i+1:]...)
+			ticked = true
+			break
+		}
+	}
+	if ticked {
+		m.list.SetItem(m.list.Index(), ui.NewSimpleItem(title, item.Description()))
+	} else {
+		m.selectedResources = append(m.selectedResources, resource)
+		m.list.SetItem(m.list.Index(), ui.NewSimpleItem("[x] "+title, item.Description()))
+	}
+	return m
+}
+
+// buildSelectedCommand builds the command for the wizard selections, joining
+// the ticked resource kinds when several are combined into one get.
+func (m Model) buildSelectedCommand() (string, error) {
+	if m.selectedAction == ActionGet && len(m.selectedResources) > 1 {
+		return buildGetCommand(m.selectedResources, m.selectedFlags), nil
+	}
+	flags := m.selectedFlags
+	if m.selectedAction == ActionWait && m.waitCondition != "" {
+		flags = append([]string{"--for=condition=" + m.waitCondition}, flags...)
+	}
+	if m.selectedAction == ActionLogs && m.logsContainer != "" {
+		flags = append(containerFlags(m.logsContainer), flags...)
+	}
+	return buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, flags)
 }
 
 func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
@@ -83,6 +151,11 @@ func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
 	}
 
 	title := selected.(ui.SimpleItem).Title()
+	if action, ok := actionFromTitle(title); ok {
+		if refused, denied := m.refuseDeniedAction(action); denied {
+			return refused, nil
+		}
+	}
 
 	switch title {
 	case "Get":
@@ -103,6 +176,22 @@ func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
 		}
 		return m, m.fetchResourceNames()
 
+	case "View YAML":
+		m.selectedAction = ActionViewYAML
+		return m, m.fetchResourceNames()
+
+	case "Owners":
+		m.selectedAction = ActionOwners
+		return m, m.fetchResourceNames()
+
+	case "Debug":
+		m.selectedAction = ActionDebug
+		return m, m.fetchResourceNames()
+
+	case "Quick Actions":
+		m.selectedAction = ActionQuickActions
+		return m, m.fetchResourceNames()
+
 	case "Extract Field":
 		m.selectedAction = ActionExtractField
 		// Need to fetch resource names for selection
@@ -112,10 +201,30 @@ func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
 		m.selectedAction = ActionEdit
 		return m, m.fetchResourceNames()
 
+	case "Edit YAML":
+		m.selectedAction = ActionEditYAML
+		return m, m.fetchResourceNames()
+
 	case "Delete":
 		m.selectedAction = ActionDelete
 		return m, m.fetchResourceNames()
 
+	case "Restart":
+		m.selectedAction = ActionRestart
+		return m, m.fetchResourceNames()
+
+	case "Cordon":
+		m.selectedAction = ActionCordon
+		return m, m.fetchResourceNames()
+
+	case "Uncordon":
+		m.selectedAction = ActionUncordon
+		return m, m.fetchResourceNames()
+
+	case "Drain":
+		m.selectedAction = ActionDrain
+		return m, m.fetchResourceNames()
+
 	case "Exec":
 		m.selectedAction = ActionExec
 		return m, m.fetchResourceNames()
@@ -127,6 +236,14 @@ func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
 	case "Top (Metrics)":
 		m.selectedAction = ActionTop
 		return m.navigateToFlagsSelection(), nil
+
+	case "Wait":
+		m.selectedAction = ActionWait
+		return m, m.fetchResourceNames()
+
+	case "Show Pods":
+		m.selectedAction = ActionShowPods
+		return m, m.fetchResourceNames()
 	}
 
 	return m, nil
@@ -134,24 +251,103 @@ func (m Model) handleActionSelection() (tea.Model, tea.Cmd) {
 
 func (m Model) handleResourceNameSelection() (tea.Model, tea.Cmd) {
 	selected := m.list.SelectedItem()
-	if selected == nil {
+	if selected == nil || m.noResourceNames {
 		return m, nil
 	}
 
-	m.selectedResourceName = selected.(ui.SimpleItem).Title()
+	name := strings.TrimSpace(stripCheckbox(selected.(ui.SimpleItem).Title()))
+	if name == "" {
+		return m, nil
+	}
+	m.selectedResourceName = name
+
+	// Ticked names take precedence over the highlighted one for bulk delete
+	// and describe
+	if bulkSelects(m.selectedAction) {
+		if names := m.bulkSelectedNames(); len(names) > 0 {
+			m.selectedResourceName = strings.Join(names, " ")
+		}
+	}
 
 	if m.selectedAction == ActionExtractField {
-		return m, m.fetchSecretKeys()
+		return m, m.fetchFieldKeys()
+	}
+
+	if m.selectedAction == ActionOwners {
+		return m, m.loadOwners()
+	}
+
+	if m.selectedAction == ActionDebug {
+		return m.navigateToPodDebug(), m.loadPodDebug()
+	}
+
+	if m.selectedAction == ActionQuickActions {
+		return m, m.loadPodQuickActions()
+	}
+
+	if m.selectedAction == ActionEditYAML {
+		return m, m.loadYAMLForEdit()
+	}
+
+	if m.selectedAction == ActionWait {
+		return m.navigateToWaitConditions(), nil
+	}
+
+	if m.selectedAction == ActionShowPods {
+		return m, m.loadDeploymentPods()
 	}
 
-	if m.selectedAction == ActionDelete {
-		return m.navigateToDeleteConfirmation(), nil
+	if m.selectedAction == ActionLogs && m.selectedResource == ResourcePods {
+		m.logsContainer = ""
+		return m, m.loadLogsContainers()
+	}
+
+	// Viewing YAML is read-only, so it runs straight away
+	if m.selectedAction == ActionViewYAML {
+		cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, nil)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.currentCommand = cmd + m.fieldNamespaceFlag("")
+		m = m.applyStrictNamespace()
+		return m, m.executeCommand()
+	}
+
+	if m.selectedAction == ActionDelete || m.selectedAction == ActionRestart {
+		return m.confirmOrRun()
 	}
 
 	if m.selectedAction == ActionPortForward {
 		return m.navigateToPortInput(), nil
 	}
 
+	// Edit and exec take no flags; they hand the terminal over from the preview
+	if m.selectedAction == ActionEdit || m.selectedAction == ActionExec {
+		var flags []string
+		if ns := strings.TrimSpace(m.fieldNamespaceFlag("")); ns != "" {
+			flags = append(flags, ns)
+		}
+		cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, flags)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.currentCommand = cmd
+		return m.navigateToCommandPreview(), nil
+	}
+
+	// Cordon and uncordon take no flags and are easily reverted
+	if m.selectedAction == ActionCordon || m.selectedAction == ActionUncordon {
+		cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, nil)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.currentCommand = cmd
+		return m.navigateToCommandPreview(), nil
+	}
+
 	// Go to flags selection
 	return m.navigateToFlagsSelection(), nil
 }
@@ -168,19 +364,32 @@ func (m Model) handleFlagsSelection() (tea.Model, tea.Cmd) {
 	if title == "Done (Continue)" {
 		// Check if namespace input is needed
 		if m.needsNamespaceInput {
-			return m.navigateToNamespaceInput(), nil
+			return m.navigateToNamespaceInput().startNamespaceSuggestions()
 		}
 
 		// If a default namespace is configured and the user hasn't explicitly
 		// chosen a namespace or all-namespaces flag, apply it implicitly.
-		if m.defaultNamespace != "" && !m.hasExplicitNamespaceFlag() {
+		implicit := m.defaultNamespace != "" && !m.hasExplicitNamespaceFlag()
+		if implicit {
 			m.selectedFlags = append(m.selectedFlags, "-n "+m.defaultNamespace)
 		}
 
 		// Build command with selected flags (including any implicit namespace)
-		m.currentCommand = buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
+		cmd, err := m.buildSelectedCommand()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.currentCommand = cmd
+		if implicit {
+			m.implicitNamespaceCommand = cmd
+		}
+		// Drain evicts pods, so it is confirmed like a delete instead of previewed
+		if m.selectedAction == ActionDrain {
+			return m.confirmOrRun()
+		}
 		// Navigate to command preview
-		return m.navigateToCommandPreview(), nil
+		return m.navigateToCommandPreview(), m.checkPreviousLogs()
 	}
 
 	// Ignore separator
@@ -188,10 +397,79 @@ func (m Model) handleFlagsSelection() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if title == customColumnsItemTitle {
+		return m, m.fetchColumnFields()
+	}
+
+	if title == contextFlagItemTitle {
+		return m.navigateToContextFlagSelection(), nil
+	}
+
 	// Toggle flag selection (space bar will call this via handleKeyPress)
 	return m.toggleFlag(), nil
 }
 
+// defaultFlagsKey identifies the current resource type and action in the
+// saved default flags, e.g. "Pods/Get".
+func (m Model) defaultFlagsKey() string {
+	return m.selectedResource.String() + "/" + m.selectedAction.String()
+}
+
+// applyDefaultFlags ticks the flags saved as defaults for the current
+// resource type and action. Defaults without an entry in the list, such as
+// custom columns, get one. Without saved defaults, Get has the output format
+// of default_get_output ticked.
+func (m Model) applyDefaultFlags() Model {
+	if len(m.list.Items()) == 0 {
+		return m
+	}
+
+	var defaults []string
+	if m.prefsStore != nil {
+		defaults = m.prefsStore.DefaultFlags(m.defaultFlagsKey())
+	}
+	if len(defaults) == 0 && m.selectedAction == ActionGet && m.defaultGetOutput != "" {
+		defaults = []string{"-o " + m.defaultGetOutput}
+	}
+
+	for _, flag := range defaults {
+		found := false
+		for i, item := range m.list.Items() {
+			si := item.(ui.SimpleItem)
+			if stripCheckbox(si.Title()) == flag {
+				m.list.SetItem(i, ui.NewSimpleItem("[x] "+flag, si.Description()))
+				found = true
+				break
+			}
+		}
+		if !found {
+			m.list.InsertItem(len(m.list.Items()), ui.NewSimpleItem("[x] "+flag, describeFlag(flag)))
+		}
+		m.selectedFlags = append(m.selectedFlags, flag)
+	}
+	return m
+}
+
+// rememberDefaultFlags saves the ticked flags as the defaults for the
+// current resource type and action; ticking none clears them.
+func (m Model) rememberDefaultFlags() Model {
+	if m.prefsStore == nil {
+		return m
+	}
+
+	target := strings.ToLower(m.selectedResource.String() + " " + m.selectedAction.String())
+	if err := m.prefsStore.SetDefaultFlags(m.defaultFlagsKey(), m.selectedFlags); err != nil {
+		m.err = fmt.Errorf("failed to save default flags: %v", err)
+		return m
+	}
+	if len(m.selectedFlags) == 0 {
+		m.err = fmt.Errorf("✓ Cleared the default flags for %s", target)
+	} else {
+		m.err = fmt.Errorf("✓ Saved %s as the default flags for %s", strings.Join(m.selectedFlags, " "), target)
+	}
+	return m
+}
+
 // toggleFlag toggles the selection state of the current flag.
 func (m Model) toggleFlag() Model {
 	selected := m.list.SelectedItem()
@@ -201,8 +479,8 @@ func (m Model) toggleFlag() Model {
 
 	title := selected.(ui.SimpleItem).Title()
 
-	// Ignore Done and separator
-	if title == "Done (Continue)" || title == "---" {
+	// Ignore Done, separator and the custom columns builder
+	if title == "Done (Continue)" || title == "---" || title == customColumnsItemTitle || title == contextFlagItemTitle {
 		return m
 	}
 
@@ -212,6 +490,16 @@ func (m Model) toggleFlag() Model {
 		flag = title[4:] // Remove "[ ] " or "[x] "
 	}
 
+	// The grep filter is applied to the output rather than passed to kubectl
+	if flag == grepFlagLabel {
+		return m.toggleGrepFilter()
+	}
+
+	// The jsonpath flag asks for its expression
+	if strings.HasPrefix(flag, jsonPathFlagPrefix) {
+		return m.toggleJSONPathFlag()
+	}
+
 	// Special handling for namespace flag
 	if flag == "-n <namespace>" {
 		// Get current index in list
@@ -294,11 +582,20 @@ func (m Model) handleCommandPreviewSelection() (tea.Model, tea.Cmd) {
 
 	switch title {
 	case "Execute":
-		return m, m.executeCommand()
+		return m.runCommand()
+	case "Run and Save":
+		m.saveAfterRun = true
+		return m.runCommand()
+	case "Dry Run":
+		return m, m.loadDryRun()
+	case "Append Args":
+		return m.navigateToAppendArgs(), nil
 	case "Help":
 		return m, m.loadCommandHelp()
 	case "Save as Favourite":
 		return m.navigateToSaveFavourite(), nil
+	case "Save Port Forward":
+		return m.savePortForward()
 	case "Back":
 		return m.navigateBack(), nil
 	}
@@ -324,7 +621,18 @@ func (m Model) handleFavouriteSelection() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	idx := m.list.Index()
+	// Entering a group lists its favourites
+	if selected := m.list.SelectedItem(); m.favouriteGroup == "" && selected != nil {
+		if title := selected.(ui.SimpleItem).Title(); strings.HasPrefix(title, favouriteGroupMarker) {
+			m.favouriteGroup = strings.TrimPrefix(title, favouriteGroupMarker)
+			return m.navigateToFavouritesList(), nil
+		}
+	}
+
+	idx, ok := m.selectedFavouriteIndex()
+	if !ok {
+		return m, nil
+	}
 	fav, ok := m.favStore.Get(idx)
 	if !ok {
 		return m, nil
@@ -333,18 +641,18 @@ func (m Model) handleFavouriteSelection() (tea.Model, tea.Cmd) {
 	// Check if user pressed 'd' to delete
 	// This is handled in the key handler, so here we just execute
 	m.currentCommand = fav.Command
-	return m, m.executeCommand()
+	return m.runCommand()
 }
 
 func (m Model) handleSaveFavourite() (tea.Model, tea.Cmd) {
-	name := m.textInput.Value()
+	group, name, grouped := splitFavouriteName(m.textInput.Value())
 	if name == "" {
 		return m, nil
 	}
 
-	// Validate favourite name
-	if !ValidateSafeName(name) {
-		m.err = fmt.Errorf("invalid favourite name: alphanumeric, spaces, dashes, dots, underscores only")
+	// Validate favourite name and group
+	if !ValidateSafeName(name) || (grouped && !ValidateSafeName(group)) {
+		m.err = fmt.Errorf("invalid favourite name: alphanumeric, spaces, dashes, dots, underscores only, with an optional group/ in front")
 		return m, nil
 	}
 
@@ -353,28 +661,45 @@ func (m Model) handleSaveFavourite() (tea.Model, tea.Cmd) {
 		return m.navigateToMainMenu(), nil
 	}
 
+	// Warn once about a likely typo; Enter again saves anyway
+	if problem := commandProblem(m.currentCommand, m.vocabulary); problem != "" && !m.favouriteWarned {
+		// Plugins and the cluster's own types may know better than the built-in lists
+		if m.vocabulary == nil {
+			return m, m.loadCommandVocabulary()
+		}
+		m.favouriteWarned = true
+		m.err = fmt.Errorf("This command may be invalid: %s. Press Enter again to save anyway, or Esc to go back", problem)
+		return m, nil
+	}
+
 	fav := favourites.NewFavourite(name, m.currentCommand)
+	if group != favourites.DefaultGroup {
+		fav.Group = group
+	}
 	return m, m.saveFavourite(fav)
 }
 
 func (m Model) handleRenameFavourite() (tea.Model, tea.Cmd) {
-	newName := m.textInput.Value()
+	group, newName, grouped := splitFavouriteName(m.textInput.Value())
 	if newName == "" {
 		return m, nil
 	}
 
-	// Validate favourite name
-	if !ValidateSafeName(newName) {
-		m.err = fmt.Errorf("invalid favourite name: alphanumeric, spaces, dashes, dots, underscores only")
+	// Validate favourite name and group
+	if !ValidateSafeName(newName) || (grouped && !ValidateSafeName(group)) {
+		m.err = fmt.Errorf("invalid favourite name: alphanumeric, spaces, dashes, dots, underscores only, with an optional group/ in front")
 		return m, nil
 	}
+	if !grouped {
+		group = ""
+	}
 
 	if m.favStore == nil {
 		m.err = fmt.Errorf("favourites store not available")
 		return m.navigateToMainMenu(), nil
 	}
 
-	return m, m.renameFavourite(m.renamingFavouriteIdx, newName)
+	return m, m.renameFavourite(m.renamingFavouriteIdx, newName, group)
 }
 
 
@@ -392,18 +717,28 @@ func (m Model) handleNamespaceInput() (tea.Model, tea.Cmd) {
 
 	// Store the namespace value
 	m.customNamespace = namespace
+	m = m.stopNamespaceSuggestions()
 
 	// Add the namespace flag to selected flags
 	m.selectedFlags = append(m.selectedFlags, "-n "+namespace)
 
 	// Build command with all flags including namespace
-	m.currentCommand = buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
+	cmd, err := m.buildSelectedCommand()
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.currentCommand = cmd
 
 	// Navigate to command preview
-	return m.navigateToCommandPreview(), nil
+	return m.navigateToCommandPreview(), m.checkPreviousLogs()
 }
 
 func (m Model) hasExplicitNamespaceFlag() bool {
+	// Names picked from the all-namespaces listing carry their own namespace
+	if strings.Contains(m.selectedResourceName, "/") {
+		return true
+	}
 	for _, f := range m.selectedFlags {
 		if f == "-A" || strings.HasPrefix(f, "-n ") || strings.HasPrefix(f, "-n=") {
 			return true
@@ -412,7 +747,7 @@ func (m Model) hasExplicitNamespaceFlag() bool {
 	return false
 }
 
-func (m Model) handleSecretFieldSelection() (tea.Model, tea.Cmd) {
+func (m Model) handleFieldSelection() (tea.Model, tea.Cmd) {
 	selected := m.list.SelectedItem()
 	if selected == nil {
 		return m, nil
@@ -428,6 +763,15 @@ func (m Model) handleSecretFieldSelection() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	namespace, name := splitNamespacedName(m.selectedResourceName)
+
+	// Non-secret fields are plain JSONPath lookups
+	if m.selectedResource != ResourceSecrets {
+		m.currentCommand = fmt.Sprintf("kubectl get %s %s -o jsonpath=%s",
+			getResourceShortName(m.selectedResource), name, jsonPathExpr(title)) + m.fieldNamespaceFlag(namespace)
+		return m.navigateToCommandPreview(), nil
+	}
+
 	// Build the command to extract the field
 	// Different handling for data fields (base64 encoded) vs metadata fields (plain text)
 	var templateStr string
@@ -465,13 +809,7 @@ func (m Model) handleSecretFieldSelection() (tea.Model, tea.Cmd) {
 		templateStr = fmt.Sprintf("{{index .data \"%s\" | base64decode}}", escapedTitle)
 	}
 
-	m.currentCommand = fmt.Sprintf("kubectl get secret %s -o go-template='%s'", m.selectedResourceName, templateStr)
-
-	if m.customNamespace != "" {
-		m.currentCommand += " -n " + m.customNamespace
-	} else if m.defaultNamespace != "" && !m.hasExplicitNamespaceFlag() {
-		m.currentCommand += " -n " + m.defaultNamespace
-	}
+	m.currentCommand = fmt.Sprintf("kubectl get secret %s -o go-template='%s'", name, templateStr) + m.fieldNamespaceFlag(namespace)
 
 	return m.navigateToCommandPreview(), nil
 }
@@ -484,15 +822,39 @@ func (m Model) handleDeleteConfirmationSelection() (tea.Model, tea.Cmd) {
 
 	title := selected.(ui.SimpleItem).Title()
 
-	if title == "Confirm Delete" {
-		m.currentCommand = buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
-		return m, m.executeCommand()
+	if title == "Confirm Delete" || title == "Confirm Restart" || title == "Confirm Drain" {
+		return m.runConfirmedAction()
 	}
 
-	// Cancel - go back to name selection
+	// Cancel - go back to name selection, or to the actions of a pod picked
+	// through Show Pods, which has no name selection to go back to
+	if m.followedPod != "" {
+		return m.navigateToActionSelection(), nil
+	}
 	return m, m.fetchResourceNames()
 }
 
+// confirmOrRun asks before a delete, restart or drain, or runs it straight
+// away when confirmations are turned off in Settings.
+func (m Model) confirmOrRun() (tea.Model, tea.Cmd) {
+	if m.skipConfirmations {
+		return m.runConfirmedAction()
+	}
+	return m.navigateToDeleteConfirmation(), nil
+}
+
+// runConfirmedAction builds and runs the delete, restart or drain.
+func (m Model) runConfirmedAction() (tea.Model, tea.Cmd) {
+	cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.currentCommand = cmd
+	m = m.applyStrictNamespace()
+	return m, m.executeCommand()
+}
+
 func (m Model) handlePortInput() (tea.Model, tea.Cmd) {
 	ports := m.textInput.Value()
 	if ports == "" {
@@ -500,8 +862,12 @@ func (m Model) handlePortInput() (tea.Model, tea.Cmd) {
 	}
 
 	// Build command with ports
-	m.currentCommand = buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
-	m.currentCommand += " " + ports
+	cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, m.selectedFlags)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.currentCommand = cmd + " " + ports
 
 	// Navigate to command preview
 	return m.navigateToCommandPreview(), nil
@@ -512,6 +878,7 @@ func (m Model) handleCustomCommandInput() (tea.Model, tea.Cmd) {
 	if input == "" {
 		return m, nil
 	}
+	m = m.clearCommandSuggestions()
 
 	// Allow users to type either full "kubectl ..." or just the arguments.
 	if strings.HasPrefix(input, "kubectl ") {
@@ -519,6 +886,89 @@ func (m Model) handleCustomCommandInput() (tea.Model, tea.Cmd) {
 	} else {
 		m.currentCommand = "kubectl " + input
 	}
+	if m.readOnly && isMutatingCommand(m.currentCommand) {
+		m.err = readOnlyError(m.currentCommand)
+		return m, nil
+	}
 
 	return m.navigateToCommandPreview(), nil
 }
+
+// noResourceNamesTitle describes an empty resource name list, e.g.
+// "No pods found in namespace default".
+func (m Model) noResourceNamesTitle(namespace string) string {
+	resource := strings.ToLower(m.selectedResource.String())
+	if m.listsAllNamespaces() {
+		return fmt.Sprintf("No %s found in any namespace", resource)
+	}
+	if namespace == "" {
+		return fmt.Sprintf("No %s found", resource)
+	}
+	return fmt.Sprintf("No %s found in namespace %s", resource, namespace)
+}
+
+// bulkNameItems renders resource names with checkboxes for multi-select.
+func bulkNameItems(names []string, selected map[string]bool, description string) []list.Item {
+	items := make([]list.Item, 0, len(names))
+	for _, name := range names {
+		items = append(items, bulkNameItem(name, selected[name], description))
+	}
+	return items
+}
+
+func bulkNameItem(name string, checked bool, description string) ui.SimpleItem {
+	if checked {
+		return ui.NewSimpleItem("[x] "+name, description)
+	}
+	return ui.NewSimpleItem("[ ] "+name, "")
+}
+
+// stripCheckbox removes the "[ ] "/"[x] " prefix used by multi-select lists.
+func stripCheckbox(title string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(title, "[ ] "), "[x] ")
+}
+
+// toggleBulkSelection ticks or unticks the highlighted resource name.
+func (m Model) toggleBulkSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	name := stripCheckbox(selected.(ui.SimpleItem).Title())
+	if m.bulkSelected[name] {
+		delete(m.bulkSelected, name)
+	} else {
+		m.bulkSelected[name] = true
+	}
+	cmd := m.list.SetItem(m.list.Index(), bulkNameItem(name, m.bulkSelected[name], bulkSelectionDescription(m.selectedAction)))
+	return m, cmd
+}
+
+// bulkSelectedNames returns the ticked names in list order.
+func (m Model) bulkSelectedNames() []string {
+	names := []string{}
+	for _, item := range m.list.Items() {
+		name := stripCheckbox(item.(ui.SimpleItem).Title())
+		if m.bulkSelected[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// listsAllNamespaces reports whether resource names are listed across all
+// namespaces. Nodes are cluster-scoped and always listed as is.
+func (m Model) listsAllNamespaces() bool {
+	return m.allNamespaces && m.selectedResource != ResourceNodes
+}
+
+// toggleAllNamespaces switches the resource name list between the current
+// namespace and all namespaces and reloads it.
+func (m Model) toggleAllNamespaces() (tea.Model, tea.Cmd) {
+	if m.selectedResource == ResourceNodes {
+		return m, nil
+	}
+	m.allNamespaces = !m.allNamespaces
+	return m, m.fetchResourceNames()
+}
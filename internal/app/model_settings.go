@@ -0,0 +1,178 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Settings rows, in the order they are listed
+const (
+	settingTheme            = "Theme"
+	settingDefaultGetOutput = "Default Get Output"
+	settingHistorySize      = "History Size"
+	settingConfirmations    = "Confirmations"
+	settingReadOnly         = "Read-Only"
+)
+
+// defaultGetOutputs are the values Default Get Output cycles through; ""
+// ticks no output format.
+var defaultGetOutputs = []string{"", "wide", "yaml", "json"}
+
+// WithConfigPath sets the config file the Settings screen saves to, as
+// given with --config. Empty saves to the default location.
+func (m Model) WithConfigPath(path string) Model {
+	m.configPath = path
+	return m
+}
+
+// themeFromConfig returns the theme named in the config file, dark unless
+// it is "light".
+func themeFromConfig(name string) Theme {
+	if name == "light" {
+		return ThemeLight
+	}
+	return ThemeDark
+}
+
+// onOff shows a boolean setting.
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// settingsItems lists each setting with its current value.
+func (m Model) settingsItems() []list.Item {
+	output := m.defaultGetOutput
+	if output == "" {
+		output = "none"
+	}
+	historySize := m.config.HistorySize
+	if historySize <= 0 {
+		historySize = config.DefaultHistorySize
+	}
+	return []list.Item{
+		ui.NewSimpleItem(settingTheme+": "+strings.ToLower(m.theme.String()), "Switch between the dark and light theme"),
+		ui.NewSimpleItem(settingDefaultGetOutput+": "+output, "Output format ticked for Get: none, wide, yaml or json"),
+		ui.NewSimpleItem(settingHistorySize+": "+strconv.Itoa(historySize), "How many commands Command History keeps"),
+		ui.NewSimpleItem(settingConfirmations+": "+onOff(!m.skipConfirmations), "Ask before deleting, restarting or draining"),
+		ui.NewSimpleItem(settingReadOnly+": "+onOff(m.readOnly), "Hide actions that change the cluster and refuse mutating commands"),
+	}
+}
+
+// navigateToSettings lists the settings, with the row at index selected.
+func (m Model) navigateToSettings(index int) Model {
+	m.list = ui.NewList(m.settingsItems(), "Settings", m.width, m.listHeight())
+	m.list.Select(index)
+	if m.currentScreen != SettingsScreen {
+		m.previousScreen = m.currentScreen
+	}
+	m.currentScreen = SettingsScreen
+	return m
+}
+
+// handleSettingsSelection toggles the selected setting, or cycles through
+// its values, and saves the config. History Size is typed instead.
+func (m Model) handleSettingsSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	index := m.list.Index()
+	name, _, _ := strings.Cut(selected.(ui.SimpleItem).Title(), ":")
+
+	switch name {
+	case settingTheme:
+		if m.theme == ThemeDark {
+			m.theme = ThemeLight
+		} else {
+			m.theme = ThemeDark
+		}
+		m.config.Theme = strings.ToLower(m.theme.String())
+	case settingDefaultGetOutput:
+		next := 0
+		for i, o := range defaultGetOutputs {
+			if o == m.defaultGetOutput {
+				next = (i + 1) % len(defaultGetOutputs)
+			}
+		}
+		m.defaultGetOutput = defaultGetOutputs[next]
+		m.config.DefaultGetOutput = m.defaultGetOutput
+	case settingHistorySize:
+		m.editingSetting = name
+		m.textInput.SetValue("")
+		m.textInput.Placeholder = fmt.Sprintf("Number of commands (e.g. %d)", config.DefaultHistorySize)
+		m.textInput.Focus()
+		m.previousScreen = m.currentScreen
+		m.currentScreen = SettingsInputScreen
+		return m, nil
+	case settingConfirmations:
+		m.skipConfirmations = !m.skipConfirmations
+		m.config.SkipConfirmations = m.skipConfirmations
+	case settingReadOnly:
+		m.readOnly = !m.readOnly
+		m.config.ReadOnly = m.readOnly
+	default:
+		return m, nil
+	}
+
+	m = m.saveSettings(name)
+	return m.navigateToSettings(index), nil
+}
+
+// handleSettingsInput applies the value typed for the setting being edited
+// and returns to the settings.
+func (m Model) handleSettingsInput() (tea.Model, tea.Cmd) {
+	input := strings.TrimSpace(m.textInput.Value())
+	if input == "" {
+		return m, nil
+	}
+
+	switch m.editingSetting {
+	case settingHistorySize:
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 {
+			m.err = fmt.Errorf("history size must be a whole number of at least 1")
+			return m, nil
+		}
+		if m.historyStore != nil {
+			if err := m.historyStore.SetMaxEntries(n); err != nil {
+				m.err = fmt.Errorf("failed to trim history: %v", err)
+				return m, nil
+			}
+		}
+		m.config.HistorySize = n
+	}
+
+	m.textInput.Blur()
+	m = m.saveSettings(m.editingSetting)
+	return m.navigateToSettings(settingIndex(m.editingSetting)), nil
+}
+
+// settingIndex returns the row a setting is listed at.
+func settingIndex(name string) int {
+	for i, s := range []string{settingTheme, settingDefaultGetOutput, settingHistorySize, settingConfirmations, settingReadOnly} {
+		if s == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// saveSettings writes the edited config back to its file. A failed save
+// leaves the change in place for this session.
+func (m Model) saveSettings(name string) Model {
+	if err := config.Save(m.configPath, m.config); err != nil {
+		m.err = fmt.Errorf("%s changed for this session only: %v", name, err)
+		return m
+	}
+	m.err = fmt.Errorf("✓ Saved %s", name)
+	return m
+}
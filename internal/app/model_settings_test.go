@@ -0,0 +1,112 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/history"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that toggling and editing settings applies them to the session and
+// saves them to the config file they were loaded from.
+func TestSettingsSavedToConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.json")
+	store, err := history.NewStore(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cmd := range []string{"kubectl get pods", "kubectl get nodes", "kubectl get svc"} {
+		if err := store.Add(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		config: config.Default(), historyStore: store}
+	m = m.WithConfigPath(path).navigateToSettings(0)
+
+	choose := func(m Model, name string) Model {
+		m.list.Select(settingIndex(name))
+		updated, _ := m.handleSettingsSelection()
+		return updated.(Model)
+	}
+	m = choose(m, settingReadOnly)
+	m = choose(m, settingDefaultGetOutput)
+	m = choose(m, settingDefaultGetOutput)
+	m = choose(m, settingConfirmations)
+	if !m.readOnly || m.defaultGetOutput != "yaml" || !m.skipConfirmations {
+		t.Errorf("expected the settings to apply to the session, got read-only %t, output %q, skip %t", m.readOnly, m.defaultGetOutput, m.skipConfirmations)
+	}
+	if title := m.list.SelectedItem().(ui.SimpleItem).Title(); title != "Confirmations: off" {
+		t.Errorf("expected the edited row to stay selected, got %q", title)
+	}
+
+	m = choose(m, settingHistorySize)
+	if m.currentScreen != SettingsInputScreen {
+		t.Fatalf("expected History Size to be typed, got %s", m.currentScreen)
+	}
+	m.textInput.SetValue("0")
+	updated, _ := m.handleSettingsInput()
+	if m = updated.(Model); m.currentScreen != SettingsInputScreen || m.err == nil {
+		t.Errorf("expected a size of 0 to be refused")
+	}
+	m.textInput.SetValue("2")
+	updated, _ = m.handleSettingsInput()
+	if m = updated.(Model); m.currentScreen != SettingsScreen {
+		t.Fatalf("expected to return to the settings, got %s", m.currentScreen)
+	}
+	if n := len(store.List()); n != 2 {
+		t.Errorf("expected history to be trimmed to 2 commands, got %d", n)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.ReadOnly || cfg.DefaultGetOutput != "yaml" || !cfg.SkipConfirmations || cfg.HistorySize != 2 {
+		t.Errorf("unexpected saved config %+v", cfg)
+	}
+}
+
+// Test that a theme switched with the hotkey is kept when the settings are
+// saved afterwards.
+func TestThemeHotkeyKeptBySettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		config: config.Default(), theme: ThemeDark}
+	m = m.WithConfigPath(path).navigateToSettings(0)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updated.(Model)
+	m.list.Select(settingIndex(settingReadOnly))
+	m.handleSettingsSelection()
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("expected the light theme to be saved, got %q", cfg.Theme)
+	}
+}
+
+// Test that a delete runs without the confirmation screen once
+// confirmations are turned off.
+func TestSkipConfirmationsRunsDelete(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourcePods, selectedAction: ActionDelete, selectedResourceName: "web"}
+	updated, _ := m.confirmOrRun()
+	if m := updated.(Model); m.currentScreen != DeleteConfirmationScreen {
+		t.Errorf("expected the confirmation screen, got %s", m.currentScreen)
+	}
+
+	m.skipConfirmations = true
+	updated, cmd := m.confirmOrRun()
+	if m = updated.(Model); m.currentScreen == DeleteConfirmationScreen || cmd == nil || m.currentCommand != "kubectl delete pod web" {
+		t.Errorf("expected the delete to run, got %q on %s", m.currentCommand, m.currentScreen)
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadDeploymentPods looks up the selected deployment's label selector and
+// lists the pods matching it.
+func (m Model) loadDeploymentPods() tea.Cmd {
+	deployment := m.selectedResourceName
+	namespace, name := splitNamespacedName(deployment)
+	flagNamespace := strings.TrimPrefix(m.fieldNamespaceFlag(namespace), " -n ")
+
+	return withSpinner(fmt.Sprintf("Loading pods of %s…", name), func() tea.Msg {
+		selector, err := m.kubectlClient.GetDeploymentSelector(name, flagNamespace)
+		if err != nil {
+			return deploymentPodsLoadedMsg{err: fmt.Errorf("failed to read the selector of %s: %w", name, err)}
+		}
+		pods, err := m.kubectlClient.ListPodNamesBySelector(selector, flagNamespace)
+		if err != nil {
+			return deploymentPodsLoadedMsg{err: err}
+		}
+		// Keep the deployment's namespace on its pods when it was listed with one
+		if namespace != "" {
+			for i, pod := range pods {
+				pods[i] = namespace + "/" + pod
+			}
+		}
+		return deploymentPodsLoadedMsg{deployment: deployment, selector: selector, pods: pods}
+	})
+}
+
+// navigateToDeploymentPods lists the pods of the followed deployment. The
+// deployment stays selected, so Esc returns to its actions.
+func (m Model) navigateToDeploymentPods() Model {
+	m.selectedResource = ResourceDeployments
+	m.selectedAction = ActionShowPods
+	m.selectedResourceName = m.followedDeployment
+	m.followedPod = ""
+
+	items := make([]list.Item, 0, len(m.followedPods))
+	for _, pod := range m.followedPods {
+		items = append(items, ui.NewSimpleItem(pod, "Pick an action for this pod"))
+	}
+	m.noResourceNames = len(items) == 0
+	if m.noResourceNames {
+		items = []list.Item{ui.NewSimpleItem("No pods match "+m.followedSelector, "Press Esc to go back")}
+	}
+	title := fmt.Sprintf("Pods of deployment %s (-l %s)", m.followedDeployment, m.followedSelector)
+	m.list = ui.NewList(items, title, m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = DeploymentPodsScreen
+	return m
+}
+
+// handleDeploymentPodSelection switches to the pod's actions; any of them
+// that needs a pod name uses this one.
+func (m Model) handleDeploymentPodSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil || m.noResourceNames {
+		return m, nil
+	}
+	m.followedPod = selected.(ui.SimpleItem).Title()
+	m.selectedResource = ResourcePods
+	m.selectedAction = 0
+	m.selectedResourceName = ""
+	m = m.navigateToActionSelection()
+	return m, m.checkActionPermissions()
+}
+
+// actionSelectionTitle names the pod when its actions were reached through
+// its deployment.
+func (m Model) actionSelectionTitle() string {
+	if m.followedPod != "" && m.selectedResource == ResourcePods {
+		return fmt.Sprintf("Select Action: %s › pod %s", m.followedDeployment, m.followedPod)
+	}
+	return "Select Action"
+}
+
+// clearFollowedPods ends a Show Pods drill-down.
+func (m Model) clearFollowedPods() Model {
+	m.followedDeployment = ""
+	m.followedSelector = ""
+	m.followedPods = nil
+	m.followedPod = ""
+	return m
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that Show Pods lists a deployment's pods, that a pod picked there is
+// used by its actions without asking for the name again, and that Esc goes
+// back up the breadcrumb.
+func TestShowPodsOfDeployment(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourceDeployments, selectedAction: ActionShowPods, selectedResourceName: "web"}
+	key := func(m Model, k tea.KeyType) Model {
+		updated, _ := m.Update(tea.KeyMsg{Type: k})
+		return updated.(Model)
+	}
+
+	updated, _ := m.Update(deploymentPodsLoadedMsg{deployment: "web", selector: "app=web", pods: []string{"web-1", "web-2"}})
+	m = updated.(Model)
+	if m.currentScreen != DeploymentPodsScreen || len(m.list.Items()) != 2 {
+		t.Fatalf("expected the two pods, got %s with %d items", m.currentScreen, len(m.list.Items()))
+	}
+	if want := "Pods of deployment web (-l app=web)"; m.list.Title != want {
+		t.Errorf("expected title %q, got %q", want, m.list.Title)
+	}
+
+	m.list.Select(1)
+	m = key(m, tea.KeyEnter)
+	if m.currentScreen != ActionSelectionScreen || m.selectedResource != ResourcePods {
+		t.Fatalf("expected the pod actions, got %s", m.currentScreen)
+	}
+	if want := "Select Action: web › pod web-2"; m.list.Title != want {
+		t.Errorf("expected title %q, got %q", want, m.list.Title)
+	}
+
+	for i, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "Describe" {
+			m.list.Select(i)
+		}
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.(Model).Update(cmd())
+	described := updated.(Model)
+	if described.currentScreen != FlagsSelectionScreen || described.selectedResourceName != "web-2" {
+		t.Errorf("expected the describe flags for web-2, got %s for %q", described.currentScreen, described.selectedResourceName)
+	}
+
+	m = key(m, tea.KeyEsc)
+	if m.currentScreen != DeploymentPodsScreen || m.selectedResource != ResourceDeployments {
+		t.Errorf("expected Esc to return to the deployment's pods, got %s", m.currentScreen)
+	}
+	if m = key(m, tea.KeyEsc); m.currentScreen != ActionSelectionScreen || m.list.Title != "Select Action" {
+		t.Errorf("expected Esc to return to the deployment's actions, got %s (%q)", m.currentScreen, m.list.Title)
+	}
+
+	updated, _ = m.Update(deploymentPodsLoadedMsg{deployment: "web", selector: "app=web"})
+	if m = key(updated.(Model), tea.KeyEnter); m.currentScreen != DeploymentPodsScreen {
+		t.Errorf("expected Enter to do nothing without pods, got %s", m.currentScreen)
+	}
+}
+
+// Test that cancelling a delete of a pod picked through Show Pods returns to
+// its actions instead of opening the same confirmation again.
+func TestShowPodsCancelDeleteReturnsToActions(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourceDeployments, selectedAction: ActionShowPods, selectedResourceName: "web"}
+	updated, _ := m.Update(deploymentPodsLoadedMsg{deployment: "web", selector: "app=web", pods: []string{"web-1"}})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	for i, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "Delete" {
+			m.list.Select(i)
+		}
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.currentScreen != DeleteConfirmationScreen {
+		t.Fatalf("expected the delete confirmation, got %s", m.currentScreen)
+	}
+
+	m.list.Select(0)
+	if title := m.list.SelectedItem().(ui.SimpleItem).Title(); title != "Cancel" {
+		t.Fatalf("expected Cancel first, got %s", title)
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != ActionSelectionScreen || cmd != nil {
+		t.Errorf("expected Cancel to return to the pod's actions, got %s", m.currentScreen)
+	}
+	if want := "Select Action: web › pod web-1"; m.list.Title != want {
+		t.Errorf("expected title %q, got %q", want, m.list.Title)
+	}
+}
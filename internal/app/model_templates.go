@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// placeholderRe matches {name} placeholders in command templates such as
+// "kubectl logs {pod} -n {ns}".
+var placeholderRe = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// commandPlaceholders returns the distinct placeholder names in cmd in order
+// of first use. Braces that belong to go-templates ("{{end}}") are ignored.
+func commandPlaceholders(cmd string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, loc := range placeholderRe.FindAllStringSubmatchIndex(cmd, -1) {
+		start, end := loc[0], loc[1]
+		if (start > 0 && cmd[start-1] == '{') || (end < len(cmd) && cmd[end] == '}') {
+			continue
+		}
+		name := cmd[loc[2]:loc[3]]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// fillPlaceholders substitutes every {name} placeholder that has a value.
+func fillPlaceholders(cmd string, values map[string]string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringSubmatchIndex(cmd, -1) {
+		start, end := loc[0], loc[1]
+		if (start > 0 && cmd[start-1] == '{') || (end < len(cmd) && cmd[end] == '}') {
+			continue
+		}
+		value, ok := values[cmd[loc[2]:loc[3]]]
+		if !ok {
+			continue
+		}
+		b.WriteString(cmd[last:start])
+		b.WriteString(value)
+		last = end
+	}
+	b.WriteString(cmd[last:])
+	return b.String()
+}
+
+// runCommand executes currentCommand, first prompting for the value of each
+// {name} placeholder it contains.
+func (m Model) runCommand() (tea.Model, tea.Cmd) {
+	placeholders := commandPlaceholders(m.currentCommand)
+	if len(placeholders) == 0 {
+		return m, m.executeCommand()
+	}
+
+	m.templateCommand = m.currentCommand
+	m.templatePlaceholders = placeholders
+	m.templateValues = map[string]string{}
+	m.templateReturnScreen = m.currentScreen
+	return m.navigateToTemplateInput(), nil
+}
+
+// navigateToTemplateInput prompts for the next unfilled placeholder.
+func (m Model) navigateToTemplateInput() Model {
+	name := m.templatePlaceholders[len(m.templateValues)]
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = fmt.Sprintf("Value for {%s}", name)
+	m.textInput.Focus()
+	if m.currentScreen != TemplateInputScreen {
+		m.previousScreen = m.currentScreen
+	}
+	m.currentScreen = TemplateInputScreen
+	return m
+}
+
+// handleTemplateInput records the value typed for the current placeholder
+// and runs the filled-in command once every placeholder has a value.
+func (m Model) handleTemplateInput() (tea.Model, tea.Cmd) {
+	value := SanitizeInput(m.textInput.Value())
+	if value == "" {
+		return m, nil
+	}
+	if strings.ContainsAny(value, " \t") {
+		m.err = fmt.Errorf("placeholder values must not contain spaces")
+		return m, nil
+	}
+
+	m.err = nil
+	m.templateValues[m.templatePlaceholders[len(m.templateValues)]] = value
+	if len(m.templateValues) < len(m.templatePlaceholders) {
+		return m.navigateToTemplateInput(), nil
+	}
+
+	m.currentCommand = fillPlaceholders(m.templateCommand, m.templateValues)
+	m.textInput.Blur()
+	return m, m.executeCommand()
+}
+
+// templateProgress describes which placeholder is being asked for, e.g.
+// "{pod} (1 of 2)".
+func (m Model) templateProgress() string {
+	idx := len(m.templateValues)
+	if idx >= len(m.templatePlaceholders) {
+		return ""
+	}
+	return fmt.Sprintf("{%s} (%d of %d)", m.templatePlaceholders[idx], idx+1, len(m.templatePlaceholders))
+}
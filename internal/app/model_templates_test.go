@@ -0,0 +1,55 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func TestCommandPlaceholders(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want []string
+	}{
+		{"kubectl logs {pod} -n {ns}", []string{"pod", "ns"}},
+		{"kubectl get pod {pod} -n {ns} -o yaml {pod}", []string{"pod", "ns"}},
+		{"kubectl get pods -o jsonpath={.items[0].metadata.name}", nil},
+		{"kubectl get secret s -o go-template='{{range $k, $v := .data}}{{$k}}{{end}}'", nil},
+		{"kubectl get pods", nil},
+	}
+
+	for _, tt := range tests {
+		if got := commandPlaceholders(tt.cmd); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("commandPlaceholders(%q) = %v, want %v", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+// Test that running a template prompts for each placeholder in turn and
+// substitutes the values into the command.
+func TestRunCommandPromptsForPlaceholders(t *testing.T) {
+	m := Model{
+		textInput:      textinput.New(),
+		currentScreen:  FavouritesListScreen,
+		currentCommand: "kubectl logs {pod} -n {ns} --tail=10",
+	}
+
+	updated, cmd := m.runCommand()
+	m = updated.(Model)
+	if cmd != nil || m.currentScreen != TemplateInputScreen {
+		t.Fatalf("expected a prompt for placeholders, got screen %s", m.currentScreen)
+	}
+
+	for _, value := range []string{"web-1", "shop"} {
+		m.textInput.SetValue(value)
+		updated, cmd = m.handleTemplateInput()
+		m = updated.(Model)
+	}
+	if cmd == nil {
+		t.Errorf("expected the filled-in command to be executed")
+	}
+	if want := "kubectl logs web-1 -n shop --tail=10"; m.currentCommand != want {
+		t.Errorf("expected command %q, got %q", want, m.currentCommand)
+	}
+}
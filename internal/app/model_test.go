@@ -1,9 +1,18 @@
 package app
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/config"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/prefs"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Test that when a command finishes executing, the full output that will be
@@ -29,3 +38,408 @@ func TestCommandExecutedStoresFullOutputForSaving(t *testing.T) {
 	}
 }
 
+// Test that a dry run leaves the output of the last command alone, so saving,
+// pinning or uploading it later does not pick up the dry-run text.
+func TestDryRunKeepsCommandOutput(t *testing.T) {
+	m := Model{currentScreen: CommandPreviewScreen, currentOutputContent: "Output:\npods"}
+
+	updated, _ := m.Update(dryRunLoadedMsg{command: "kubectl delete pod web --dry-run=server", result: kubectl.CommandResult{Output: "pod deleted"}})
+	m = updated.(Model)
+	if m.currentScreen != DryRunScreen || !strings.Contains(m.dryRunOutput, "pod deleted") {
+		t.Fatalf("expected the dry run to be shown, got %v with %q", m.currentScreen, m.dryRunOutput)
+	}
+	if m.currentOutputContent != "Output:\npods" {
+		t.Errorf("expected the command output to be kept, got %q", m.currentOutputContent)
+	}
+}
+
+
+// Test that node details are rendered as a table on wide terminals and fall
+// back to the vertical layout on narrow ones.
+func TestFormatClusterInfoNodeLayoutDependsOnWidth(t *testing.T) {
+	info := &kubectl.ClusterInfo{
+		Context: "test",
+		Nodes: []kubectl.NodeInfo{{
+			Name:              "node-1",
+			Status:            "Ready",
+			Roles:             "control-plane",
+			Age:               "10d",
+			CPUAllocatable:    "4",
+			MemoryAllocatable: "8Gi",
+			PodCount:          "12",
+			PodCapacity:       "110",
+		}},
+	}
+
+	wide := formatClusterInfoForDisplay(info, nil, 160)
+	if !strings.Contains(wide, "NAME") || !strings.Contains(wide, "12 / 110") {
+		t.Errorf("expected table layout on wide terminal, got:\n%s", wide)
+	}
+	if strings.Contains(wide, "Roles:") {
+		t.Errorf("did not expect vertical layout on wide terminal")
+	}
+
+	narrow := formatClusterInfoForDisplay(info, nil, 80)
+	if strings.Contains(narrow, "NAME") || !strings.Contains(narrow, "Roles:       control-plane") {
+		t.Errorf("expected vertical layout on narrow terminal, got:\n%s", narrow)
+	}
+}
+
+// Test that an empty resource name list shows a placeholder that cannot be
+// selected, so no command is built with an empty resource name.
+func TestEmptyResourceNamesShowPlaceholder(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionDescribe}
+
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: nil, namespace: "staging"})
+	m = updated.(Model)
+
+	if m.currentScreen != ResourceNameSelectionScreen {
+		t.Fatalf("expected resource name selection screen, got %s", m.currentScreen)
+	}
+	items := m.list.Items()
+	if len(items) != 1 || items[0].(ui.SimpleItem).Title() != "No pods found in namespace staging" {
+		t.Fatalf("unexpected placeholder items: %v", items)
+	}
+
+	updated, _ = m.handleResourceNameSelection()
+	m = updated.(Model)
+	if m.selectedResourceName != "" || m.currentScreen != ResourceNameSelectionScreen {
+		t.Errorf("expected placeholder selection to be ignored, got name %q on %s", m.selectedResourceName, m.currentScreen)
+	}
+}
+
+// Test that ticking several names for Delete confirms and deletes all of them.
+func TestBulkDeleteSelectsMultipleNames(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionDelete}
+
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"a", "b", "c"}})
+	m = updated.(Model)
+
+	for _, idx := range []int{0, 2} {
+		m.list.Select(idx)
+		updated, _ = m.toggleBulkSelection()
+		m = updated.(Model)
+	}
+
+	updated, _ = m.handleResourceNameSelection()
+	m = updated.(Model)
+	if m.currentScreen != DeleteConfirmationScreen {
+		t.Fatalf("expected delete confirmation screen, got %s", m.currentScreen)
+	}
+	if m.selectedResourceName != "a c" {
+		t.Fatalf("expected selected names %q, got %q", "a c", m.selectedResourceName)
+	}
+
+	cmd, err := buildCommand(m.selectedResource, m.selectedAction, m.selectedResourceName, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd != "kubectl delete pod a c" {
+		t.Errorf("got command %q", cmd)
+	}
+}
+
+// Test that clicking a list row selects it and clicking it again activates it.
+func TestMouseClickSelectsAndActivatesListRow(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionDescribe, ready: true, width: 80, height: 40}
+
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"a", "b", "c"}})
+	m = updated.(Model)
+
+	// Title bar takes two rows, then each item two rows plus a gap
+	click := tea.MouseMsg{X: 4, Y: 5, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+	updated, _ = m.Update(click)
+	m = updated.(Model)
+	if m.list.Index() != 1 || m.currentScreen != ResourceNameSelectionScreen {
+		t.Fatalf("expected row 1 to be selected on the name screen, got index %d on %s", m.list.Index(), m.currentScreen)
+	}
+
+	updated, _ = m.Update(click)
+	m = updated.(Model)
+	if m.selectedResourceName != "b" {
+		t.Errorf("expected second click to choose %q, got %q", "b", m.selectedResourceName)
+	}
+
+	wheel := tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}
+	m = Model{list: ui.NewList(ui.StringsToItems([]string{"a", "b"}), "Test", 80, 20)}
+	updated, _ = m.Update(wheel)
+	if got := updated.(Model).list.Index(); got != 1 {
+		t.Errorf("expected wheel to move the cursor down, got index %d", got)
+	}
+}
+
+// Test that clicks are mapped to rows below the text a screen shows above its
+// list, matching where the list is drawn.
+func TestMouseClickBelowPreviewHeader(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), ready: true, width: 80, height: 40, currentCommand: "kubectl get pods -n team -o wide"}
+	m = m.navigateToCommandPreview()
+	m.err = fmt.Errorf("✓ Saved")
+
+	// The view is taller than the terminal, so its first lines are cut
+	view := m.View()
+	top := strings.Count(view[:strings.Index(view, m.list.View())], "\n")
+	top -= strings.Count(view, "\n") + 1 - m.height
+	for row := 0; row < m.list.Height(); row++ {
+		want, wantOK := ui.ListIndexAt(m.list, row)
+		got, ok := m.listIndexAt(top + row)
+		if got != want || ok != wantOK {
+			t.Errorf("row %d: listIndexAt = %d, %t; want %d, %t", row, got, ok, want, wantOK)
+		}
+	}
+}
+
+// Test that the resource type list puts the most used types first and keeps
+// the default order for ties.
+func TestResourceSelectionOrderedByUsage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create prefs store: %v", err)
+	}
+	for _, name := range []string{"Services", "Nodes", "Services"} {
+		if err := store.RecordResourceUse(name); err != nil {
+			t.Fatalf("failed to record usage: %v", err)
+		}
+	}
+
+	m := Model{prefsStore: store}.navigateToResourceSelection()
+
+	var got []string
+	for _, item := range m.list.Items()[:4] {
+		got = append(got, item.(ui.SimpleItem).Title())
+	}
+	want := []string{"Services", "Nodes", "Pods", "Deployments"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected order %v, got %v", want, got)
+	}
+}
+
+// Test that streamed event lines are appended to the events screen, Warning
+// events are highlighted and lines from a stopped stream are dropped.
+func TestEventsStreamAppendsLines(t *testing.T) {
+	m := Model{ready: true, width: 120, height: 40, viewport: ui.NewViewport(120, 30)}
+	m = m.navigateToEventsStream()
+
+	lines := make(chan string)
+	errc := make(chan error)
+	cancelled := false
+	updated, _ := m.Update(eventsStreamStartedMsg{lines: lines, errc: errc, cancel: func() { cancelled = true }})
+	m = updated.(Model)
+
+	updated, _ = m.Update(eventLinesMsg{source: lines, lines: []string{
+		"default   1s   Normal    Scheduled   pod/web   Successfully assigned",
+		"default   1s   Warning   BackOff     pod/web   Back-off restarting failed container",
+	}})
+	m = updated.(Model)
+	if len(m.eventLines) != 2 {
+		t.Fatalf("expected 2 event lines, got %d", len(m.eventLines))
+	}
+	if !isWarningEvent(m.eventLines[1]) || isWarningEvent(m.eventLines[0]) {
+		t.Errorf("expected only the second line to be a Warning event")
+	}
+
+	updated, _ = m.Update(eventLinesMsg{source: make(chan string), lines: []string{"stale"}})
+	m = updated.(Model)
+	if len(m.eventLines) != 2 {
+		t.Errorf("expected lines from another stream to be dropped, got %v", m.eventLines)
+	}
+
+	m = m.navigateBack()
+	if !cancelled || m.stopEvents != nil {
+		t.Errorf("expected leaving the screen to stop the stream")
+	}
+}
+
+// Test that resource kinds ticked with Space are combined into one get and
+// skip the action selection.
+func TestResourceSelectionCombinesTickedKindsIntoGet(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40}.navigateToResourceSelection()
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	updated, _ := m.Update(space) // Pods
+	m = updated.(Model)
+	m.list.Select(2) // Services
+	updated, _ = m.Update(space)
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != FlagsSelectionScreen || m.selectedAction != ActionGet {
+		t.Fatalf("expected the get flags, got %s with action %s", m.currentScreen, m.selectedAction)
+	}
+
+	m.list.Select(0) // Done (Continue)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if want := "kubectl get pods,services"; m.currentCommand != want {
+		t.Errorf("command = %q, want %q", m.currentCommand, want)
+	}
+}
+
+// Test that an installed plugin can be picked and previewed with arguments.
+func TestPluginRunsWithTypedArguments(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40}
+
+	updated, _ := m.Update(pluginsLoadedMsg{plugins: []string{"neat", "tree"}})
+	m = updated.(Model)
+	if m.currentScreen != PluginsListScreen {
+		t.Fatalf("expected the plugins list, got %s", m.currentScreen)
+	}
+
+	m.list.Select(1)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != PluginArgsScreen {
+		t.Fatalf("expected the plugin arguments input, got %s", m.currentScreen)
+	}
+
+	m.textInput.SetValue("deployment web")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.currentScreen != CommandPreviewScreen || m.currentCommand != "kubectl tree deployment web" {
+		t.Errorf("expected a preview of the plugin command, got %q on %s", m.currentCommand, m.currentScreen)
+	}
+}
+
+// Test that flags saved with 'D' are ticked the next time the same resource
+// type and action are picked, including after a restart.
+func TestDefaultFlagsArePreTicked(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create prefs store: %v", err)
+	}
+
+	m := Model{keys: defaultKeyMap(), prefsStore: store, selectedResource: ResourcePods, selectedAction: ActionGet}
+	m = m.navigateToFlagsSelection()
+	for i, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "[ ] -o wide" {
+			m.list.Select(i)
+		}
+	}
+	m = m.toggleFlag()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = updated.(Model)
+	if m.err == nil || !strings.HasPrefix(m.err.Error(), "✓") {
+		t.Fatalf("expected a confirmation, got %v", m.err)
+	}
+
+	reloaded, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to reload prefs store: %v", err)
+	}
+	m = Model{prefsStore: reloaded, selectedResource: ResourcePods, selectedAction: ActionGet}.navigateToFlagsSelection()
+	if strings.Join(m.selectedFlags, ",") != "-o wide" {
+		t.Errorf("expected -o wide to be selected, got %v", m.selectedFlags)
+	}
+	ticked := false
+	for _, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "[x] -o wide" {
+			ticked = true
+		}
+	}
+	if !ticked {
+		t.Error("expected the -o wide item to be ticked")
+	}
+
+	m = Model{prefsStore: reloaded, selectedResource: ResourceServices, selectedAction: ActionGet}.navigateToFlagsSelection()
+	if len(m.selectedFlags) != 0 {
+		t.Errorf("expected no defaults for services, got %v", m.selectedFlags)
+	}
+}
+
+// Test that default_get_output ticks its format for Get unless defaults were
+// saved for the resource type.
+func TestDefaultGetOutputIsPreTicked(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, err := prefs.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create prefs store: %v", err)
+	}
+
+	m := Model{prefsStore: store, defaultGetOutput: "wide", selectedResource: ResourcePods, selectedAction: ActionGet}.navigateToFlagsSelection()
+	if strings.Join(m.selectedFlags, ",") != "-o wide" {
+		t.Errorf("expected -o wide to be selected, got %v", m.selectedFlags)
+	}
+	ticked := false
+	for _, item := range m.list.Items() {
+		if item.(ui.SimpleItem).Title() == "[x] -o wide" {
+			ticked = true
+		}
+	}
+	if !ticked {
+		t.Error("expected the -o wide item to be ticked")
+	}
+
+	if err := store.SetDefaultFlags("Pods/Get", []string{"-o yaml"}); err != nil {
+		t.Fatalf("failed to save default flags: %v", err)
+	}
+	m = Model{prefsStore: store, defaultGetOutput: "wide", selectedResource: ResourcePods, selectedAction: ActionGet}.navigateToFlagsSelection()
+	if strings.Join(m.selectedFlags, ",") != "-o yaml" {
+		t.Errorf("expected the saved defaults to win, got %v", m.selectedFlags)
+	}
+
+	m = Model{defaultGetOutput: "json", selectedResource: ResourcePods, selectedAction: ActionDescribe}.navigateToFlagsSelection()
+	if len(m.selectedFlags) != 0 {
+		t.Errorf("expected nothing ticked for describe, got %v", m.selectedFlags)
+	}
+}
+
+// Test that an unreachable cluster found by the startup check is flagged on
+// the main menu, and that the banner goes once the cluster responds.
+func TestCurrentContextCheckShowsBannerOnMainMenu(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, currentScreen: MainMenuScreen}
+	m.list = ui.NewList(nil, "Kubernetes Wizard", 80, 36)
+
+	updated, _ := m.Update(currentContextCheckedMsg{context: "prod", status: kubectl.ContextUnreachable})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "Cluster for context prod is unreachable") {
+		t.Errorf("expected an unreachable banner on the main menu, got:\n%s", m.View())
+	}
+
+	updated, _ = m.Update(currentContextCheckedMsg{context: "prod", status: kubectl.ContextReachable})
+	m = updated.(Model)
+	if strings.Contains(m.View(), "unreachable") {
+		t.Errorf("expected the banner to be cleared once the cluster responds")
+	}
+}
+
+// Test that NewModel wires the config into the kubectl client it exposes and
+// starts on the main menu with the dark theme and configured namespace.
+func TestNewModelInitializesClientAndTheme(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := NewModel(config.Config{Retries: 2, ExpandEnv: []string{"NS"}, DefaultNamespace: " team-a "})
+	client := m.GetKubectlClient()
+	if client == nil || client.Retries != 2 || len(client.AllowedEnv) != 1 {
+		t.Errorf("GetKubectlClient() = %+v, want the client configured from the config", client)
+	}
+	if m.theme != ThemeDark || m.currentScreen != MainMenuScreen || m.defaultNamespace != "team-a" {
+		t.Errorf("NewModel() starts with theme %s on %s with default namespace %q", m.theme, m.currentScreen, m.defaultNamespace)
+	}
+}
+
+// Test that the menu shown at startup is the full main menu, not an older
+// copy missing entries such as Contexts & Namespaces.
+func TestStartupMainMenuMatchesNavigation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	titles := func(items []list.Item) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.(ui.SimpleItem).Title())
+		}
+		return out
+	}
+
+	m := NewModel(config.Default())
+	startup := titles(m.list.Items())
+	for _, want := range []string{"Custom Command", "Cluster Info", "Contexts & Namespaces"} {
+		if !containsString(startup, want) {
+			t.Errorf("startup main menu %v is missing %q", startup, want)
+		}
+	}
+	if again := titles(m.navigateToMainMenu().list.Items()); !reflect.DeepEqual(startup, again) {
+		t.Errorf("startup main menu = %v, want the same as after navigating back: %v", startup, again)
+	}
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -8,15 +10,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/diff"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/hotkeys"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// Init initializes the model (required by Bubble Tea).
+// Init initializes the model (required by Bubble Tea). It runs the command
+// a --goto deep link needs, if any, and checks the cluster is reachable.
 func (m Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.checkCurrentContext(), m.initCmd)
 }
 
 // Update handles messages and updates the model (required by Bubble Tea).
@@ -24,18 +31,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		logger.Debug("Key pressed: %s (Screen: %s)", msg.String(), m.currentScreen.String())
-		return m.handleKeyPress(msg)
+		return resumeConnectivityRecheck(m.handleKeyPress(msg))
+
+	case tea.MouseMsg:
+		return resumeConnectivityRecheck(m.handleMouse(msg))
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
 		// Update list dimensions
-		m.list.SetSize(msg.Width, msg.Height-4)
+		m.list.SetSize(msg.Width, m.listHeight())
 
 		// Update viewport dimensions
 		m.viewport.Width = msg.Width
-		m.viewport.Height = msg.Height - 6 // Leave more space for header/footer
+		m.viewport.Height = m.viewportHeight() // Leave more space for header/footer
+
+		// Reflow width-dependent content without refetching it
+		switch m.currentScreen {
+		case ClusterInfoScreen:
+			if m.clusterInfo != nil {
+				m.viewport.SetContent(formatClusterInfoForDisplay(m.clusterInfo, m.nodeUsage, m.width))
+			}
+		case SavedOutputViewScreen:
+			if m.liveComparisonDiff != "" {
+				// The comparison stays on screen with its banner
+				m.viewport.SetContent(m.liveComparisonDiff)
+			} else {
+				m.viewport.SetContent(ui.WrapContent(m.currentOutputContent, m.width))
+			}
+		case YAMLEditorScreen:
+			m.yamlEditor.SetWidth(msg.Width)
+			m.yamlEditor.SetHeight(m.viewportHeight())
+		}
 
 		if !m.ready {
 			m.ready = true
@@ -43,32 +71,204 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case loadingStartedMsg:
+		m.loading = true
+		m.loadingLabel = msg.label
+		return m, m.spinner.Tick
+
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case liveComparedMsg:
+		m.loading = false
+		if m.currentScreen != SavedOutputViewScreen {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		inserted, deleted := diff.Stats(msg.lines)
+		if inserted == 0 && deleted == 0 {
+			m.liveComparison = ""
+			m.liveComparisonDiff = ""
+			m.err = fmt.Errorf("✓ No changes: %s prints the same as when this output was saved", msg.command)
+			return m, nil
+		}
+		m.liveComparison = fmt.Sprintf("Changed since saved: %d line(s) added, %d removed (live output of %s)", inserted, deleted, msg.command)
+		m.liveComparisonDiff = m.renderDiff(msg.lines)
+		m.viewport.SetContent(m.liveComparisonDiff)
+		m.viewport.GotoTop()
+		return m, nil
+
+	case completionNamesLoadedMsg:
+		// Names fetched before the cluster changed are not kept
+		if !m.completionFetching[msg.resource] {
+			return m, nil
+		}
+		delete(m.completionFetching, msg.resource)
+		if msg.err != nil {
+			logger.Debug("Failed to fetch %s for completion: %v", msg.resource, msg.err)
+		}
+		// Kept even once the input is left, so the next visit need not
+		// fetch them; failed fetches are cached as empty so they are not
+		// retried on every key
+		if m.completionNames == nil {
+			m.completionNames = map[string][]string{}
+		}
+		m.completionNames[msg.resource] = append([]string{}, msg.names...)
+		switch m.currentScreen {
+		case NamespaceInputScreen:
+			m.textInput.SetSuggestions(m.completionNames["namespaces"])
+		case CustomCommandScreen:
+			m, cmd := m.updateCommandSuggestions()
+			return m, cmd
+		}
+		return m, nil
+
 	case resourceNamesLoadedMsg:
+		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
 
-		// Create list of resource names
+		// Create list of resource names, with a placeholder when there is nothing to pick
 		items := ui.StringsToItems(msg.names)
 		title := fmt.Sprintf("Select %s", strings.TrimSuffix(m.selectedResource.String(), "s"))
-		m.list = ui.NewList(items, title, m.width, m.height-4)
+		m.bulkSelected = nil
+		if bulkSelects(m.selectedAction) {
+			// Delete and Describe support ticking several names at once
+			m.bulkSelected = map[string]bool{}
+			items = bulkNameItems(msg.names, m.bulkSelected, bulkSelectionDescription(m.selectedAction))
+			if m.selectedAction == ActionDescribe {
+				title = fmt.Sprintf("Select %s to describe (Space=toggle, Enter=continue)", strings.ToLower(m.selectedResource.String()))
+			} else {
+				title = fmt.Sprintf("Select %s to delete (Space=toggle, Enter=confirm)", strings.ToLower(m.selectedResource.String()))
+			}
+		}
+		if m.listsAllNamespaces() {
+			title += " [all namespaces]"
+		}
+		m.noResourceNames = len(msg.names) == 0
+		if m.noResourceNames {
+			items = []list.Item{ui.NewSimpleItem(m.noResourceNamesTitle(msg.namespace), "Press Esc to go back")}
+		}
+		m.list = ui.NewList(items, title, m.width, m.listHeight())
 		m.currentScreen = ResourceNameSelectionScreen
+		if m.followedPod != "" && m.selectedResource == ResourcePods {
+			return m.handleResourceNameSelection()
+		}
 		return m, nil
 
+	case commandStartedMsg:
+		m.cancelCommand = msg.cancel
+		if msg.command != "" {
+			if m.fromYAMLEditor() {
+				m.stdinCommand = msg.command
+			}
+			m.currentCommand = msg.command
+		}
+		m.loading = true
+		m.loadingLabel = "Running command… (ctrl+x to cancel)"
+		m.commandStarted = now()
+		return m, m.spinner.Tick
+
 	case commandExecutedMsg:
+		m.cancelCommand = nil
+		m.loading = false
+		m.commandDuration = 0
+		if !m.commandStarted.IsZero() {
+			m.commandDuration = now().Sub(m.commandStarted)
+			m.commandStarted = time.Time{}
+		}
+		saveAfterRun := m.saveAfterRun
+		m.saveAfterRun = false
+		if errors.Is(msg.err, context.Canceled) {
+			m.err = fmt.Errorf("Command cancelled")
+			return m, nil
+		}
+		m.lastRunFailed = msg.err != nil || msg.result.Error != ""
+
+		// Common errors get a suggestion; the metrics-server one is explained in place
+		suggestion := ""
+		if msg.result.Error != "" {
+			explained := explainTopError(m.currentCommand, msg.result.Error)
+			if explained == msg.result.Error {
+				suggestion = interpretKubectlError(msg.result.Error)
+			}
+			msg.result.Error = explained
+		}
+
+		// Re-running a get reports what changed since its last run
+		changes := ""
+		if msg.err == nil && msg.result.Error == "" {
+			m, changes = m.recordGetOutput(msg.context, m.currentCommand, msg.result.Output)
+		}
+
 		// Display command output
 		output := msg.result.Output
+		m.outputFilterSummary = ""
+		if m.outputFilter != "" && msg.result.Error == "" {
+			output, m.outputFilterSummary = filterOutputLines(output, m.outputFilter)
+			msg.result.Output = output
+		}
+		body := output
+		if msg.result.Error != "" {
+			body = strings.TrimRight(msg.result.Error, "\n") + "\n" + output
+		}
+		m.currentOutputSize = outputSize(body, m.outputFilterSummary)
 		if msg.result.Error != "" {
 			output = "Error:\n" + msg.result.Error + "\n\nOutput:\n" + output
 		} else {
 			output = "Output:\n" + output
 		}
 
-		m.viewport.SetContent(output)
+		// Highlighting and tables only change what is shown, not what is saved
+		content := output
+		if msg.result.Error == "" {
+			if isYAMLCommand(m.currentCommand) {
+				content = "Output:\n" + m.highlightYAML(msg.result.Output)
+			} else if tables, ok := parseTables(msg.result.Output); ok && isTableCommand(m.currentCommand) {
+				content = "Output:\n" + m.renderTables(tables, m.viewport.Width)
+			} else if separated, ok := separateDescribeOutputs(m.currentCommand, msg.result.Output); ok {
+				content = "Output:\n" + separated
+			}
+			// Pod counts by status, so problems show before scrolling
+			if summary := podStatusSummary(m.currentCommand, msg.result.Output); summary != "" {
+				content = m.GetHighlightStyle().Render(summary) + "\n\n" + content
+			}
+			if changes != "" {
+				content = m.GetHighlightStyle().Render("🔄 "+changes) + "\n\n" + content
+			}
+		}
+		m.viewport.SetContent(content)
+		m.currentOutputView = content
 		// Preserve the full command output separately for saving, independent of viewport rendering
 		m.currentOutputContent = output
+		m.currentOutputContext = msg.context
 		m.currentScreen = CommandOutputScreen
+
+		if suggestion != "" {
+			m = m.navigateToErrorScreen(msg.result.Error, suggestion)
+			if saveAfterRun {
+				m.err = fmt.Errorf("Command failed, output was not saved")
+			}
+			return m, nil
+		}
+
+		if saveAfterRun {
+			if msg.err != nil || msg.result.Error != "" {
+				m.err = fmt.Errorf("Command failed, output was not saved")
+				return m, nil
+			}
+			return m, m.saveOutput(suggestOutputName(m.currentCommand))
+		}
 		return m, nil
 
 	case commandHelpLoadedMsg:
@@ -84,6 +284,68 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentScreen = CommandHelpScreen
 		return m, nil
 
+	case dryRunLoadedMsg:
+		if msg.err != nil && msg.result.Error == "" {
+			m.err = msg.err
+			return m, nil
+		}
+		output := msg.result.Output
+		if msg.result.Error != "" {
+			output = "❌ Dry run failed:\n" + msg.result.Error + "\n\nOutput:\n" + output
+		} else {
+			output = "✅ Dry run succeeded. No changes were applied.\n\nOutput:\n" + output
+		}
+		m.viewport.SetContent(output)
+		m.dryRunOutput = output
+		m.lastDryRunCommand = msg.command
+		m.previousScreen = m.currentScreen
+		m.currentScreen = DryRunScreen
+		return m, nil
+
+	case podRestartsCheckedMsg:
+		if msg.err != nil {
+			// The warning is best-effort; kubectl will report real problems on execute
+			logger.Debug("Failed to check pod restarts: %v", msg.err)
+			return m, nil
+		}
+		if msg.restarts == 0 {
+			m.previewWarning = "Pod has never restarted, so there is likely no previous container log (--previous will fail)"
+			if msg.container != "" {
+				m.previewWarning = fmt.Sprintf("Container %s has never restarted, so there is likely no previous log (--previous will fail)", msg.container)
+			}
+			m.previewWarningCommand = msg.command
+		}
+		return m, nil
+
+	case scriptWrittenMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if msg.appended {
+			m.err = fmt.Errorf("✓ Appended command to %s", msg.path)
+		} else {
+			m.err = fmt.Errorf("✓ Exported history to %s", msg.path)
+		}
+		return m, nil
+
+	case logsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		content := msg.content
+		if strings.TrimSpace(content) == "" {
+			content = "Log file is empty."
+		} else if msg.truncated {
+			content = fmt.Sprintf("… showing the last %d KB of the log …\n\n", maxLogViewBytes/1024) + content
+		}
+		m.viewport.SetContent(content)
+		m.viewport.GotoBottom()
+		m.previousScreen = m.currentScreen
+		m.currentScreen = LogViewerScreen
+		return m, nil
+
 	case clusterConnectivityCheckedMsg:
 		output := msg.result.Output
 		if msg.result.Error != "" {
@@ -92,6 +354,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if strings.Contains(output, "Unable to connect to the server") {
 				output = "Cluster Connectivity:\n\n❌ Cannot connect to the Kubernetes cluster.\n\n" + output
 			} else {
+				m.clusterWarning = ""
 				// Show a concise connected status and include basic info
 				lines := strings.Split(output, "\n")
 				var summary []string
@@ -101,7 +364,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						summary = append(summary, line)
 					}
 				}
-				output = "Cluster Connectivity:\n\n✅ Connected to the Kubernetes cluster.\n\n" + strings.Join(summary, "\n")
+				header := "Cluster Connectivity:\n\n✅ Connected to the Kubernetes cluster.\n\n"
+				if msg.summary != nil {
+					header += fmt.Sprintf("Nodes:          %d total, %d ready\n", msg.summary.TotalNodes, msg.summary.ReadyNodes)
+					if msg.summary.Version != "" {
+						header += fmt.Sprintf("Server Version: %s\n", msg.summary.Version)
+					}
+					header += "\n"
+				}
+				output = header + strings.Join(summary, "\n")
 			}
 		}
 		m.viewport.SetContent(output)
@@ -111,12 +382,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case contextSwitchedMsg:
+		if msg.missing {
+			previous := m.previousScreen
+			m = m.navigateToContextsList()
+			m.previousScreen = previous
+			m.err = fmt.Errorf("context %s no longer exists", msg.newContext)
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
 		m.err = fmt.Errorf("✓ Switched context to %s", msg.newContext)
-		return m.navigateToMainMenu(), nil
+		m.recordContextUse(msg.newContext)
+		m = m.forgetCompletionNames()
+		m.vocabulary = nil
+		m.clusterWarning = ""
+		m.permissionsContext = msg.newContext
+		m.contextSwitchID++
+		return m.navigateToMainMenu(), m.checkCurrentContext()
+
+	case contextConfirmationNeededMsg:
+		return m.navigateToContextConfirmation(msg.context, true), nil
+
+	case currentContextCheckedMsg:
+		// A check that finished after switching away is about the old context.
+		// The rechecks carry on rather than waiting for a check forever.
+		if msg.switchID != m.contextSwitchID {
+			return m.scheduleConnectivityRecheck()
+		}
+		m.clusterWarning = clusterWarningFor(msg)
+		if msg.err == nil {
+			m.permissionsContext = msg.context
+			m.recordContextUse(msg.context)
+		}
+		return m.scheduleConnectivityRecheck()
+
+	case connectivityRecheckMsg:
+		return m.handleConnectivityRecheck(msg)
+
+	case permissionsCheckedMsg:
+		return m.storePermissions(msg), nil
+
+	case namespaceChangedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if msg.deleted {
+			if m.defaultNamespace == msg.name {
+				m.defaultNamespace = ""
+			}
+			m.err = fmt.Errorf("✓ Deleted namespace %s", msg.name)
+		} else {
+			m.err = fmt.Errorf("✓ Created namespace %s", msg.name)
+		}
+		m = m.navigateToNamespacesList()
+		m.previousScreen = ContextsNamespacesMenuScreen
+		if !msg.deleted && len(m.namespaces) > 0 {
+			m.list = m.buildNamespacesList(msg.name)
+		}
+		return m, nil
+
+	case contextsProbedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.contextHealth = msg.statuses
+		m.err = nil
+		if m.currentScreen == ContextsListScreen {
+			idx := m.list.Index()
+			m = m.navigateToContextsList()
+			m.list.Select(idx)
+		}
+		return m, nil
 
 	case favouriteSavedMsg:
 		if msg.err != nil {
@@ -145,6 +486,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m.loadSavedOutputs()
 
+	case outputUploadedMsg:
+		m.loading = false
+		m.err = uploadStatus(msg)
+		return m, nil
+
 	case outputSavedMsg:
 		if msg.err != nil {
 			m.err = fmt.Errorf("Failed to save output: %v", msg.err)
@@ -152,6 +498,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Show success message and return to main menu
 		m.err = fmt.Errorf("✓ Output saved to: %s", msg.filename)
+		// "Run and Save" keeps the freshly run output on screen
+		if m.currentScreen == CommandOutputScreen {
+			return m, nil
+		}
 		return m.navigateToMainMenu(), nil
 
 	case savedOutputsLoadedMsg:
@@ -208,14 +558,170 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.savedOutputsReturnVersionIdx = 0
 		return m.navigateToSavedOutputsGroups(), nil
 
-	case secretKeysLoadedMsg:
+	case fieldKeysLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m.navigateToFieldSelection(msg.keys), nil
+
+	case portForwardStartedMsg:
+		if _, ok := m.runningForwards[msg.name]; ok {
+			// Started twice before the first one was reported
+			msg.cancel()
+			return m, nil
+		}
+		done := make(chan struct{})
+		running := make(map[string]runningForward, len(m.runningForwards)+1)
+		for n, r := range m.runningForwards {
+			running[n] = r
+		}
+		running[msg.name] = runningForward{cancel: msg.cancel, done: done}
+		m.runningForwards = running
+		m.err = fmt.Errorf("✓ Started port forward %s", msg.name)
+		if m.currentScreen == PortForwardsScreen {
+			m = m.navigateToPortForwards()
+		}
+		return m, waitForPortForward(msg.name, msg.lines, msg.errc, done)
+
+	case portForwardEndedMsg:
+		if running, ok := m.runningForwards[msg.name]; msg.done != nil && (!ok || running.done != msg.done) {
+			// Stopped from the list, which already said so
+			return m, nil
+		}
+		m = m.forgetPortForward(msg.name)
+		reason := msg.output
+		if reason == "" && msg.err != nil {
+			reason = msg.err.Error()
+		}
+		if reason == "" {
+			reason = "kubectl exited"
+		}
+		m.err = fmt.Errorf("Port forward %s stopped: %s", msg.name, reason)
+		if m.currentScreen == PortForwardsScreen {
+			m = m.navigateToPortForwards()
+		}
+		return m, nil
+
+	case eventsStreamStartedMsg:
+		if m.currentScreen != EventsStreamScreen {
+			// The screen was left before the watch started
+			msg.cancel()
+			return m, nil
+		}
+		m.eventsSource = msg.lines
+		m.eventsErrc = msg.errc
+		m.stopEvents = msg.cancel
+		return m, waitForEvents(msg.lines, msg.errc)
+
+	case eventLinesMsg:
+		if msg.source != m.eventsSource {
+			return m, nil
+		}
+		if m.eventsPaused {
+			m = m.holdEventLines(msg.lines)
+			return m, waitForEvents(m.eventsSource, m.eventsErrc)
+		}
+		m = m.appendEventLines(msg.lines)
+		return m, waitForEvents(m.eventsSource, m.eventsErrc)
+
+	case eventsStreamEndedMsg:
+		if msg.lines != m.eventsSource || m.currentScreen != EventsStreamScreen {
+			return m, nil
+		}
+		m = m.stopEventsStream()
+		m.err = eventsStreamStopped(msg.err)
+		return m, nil
+
+	case columnFieldsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m.navigateToCustomColumns(msg.keys), nil
+
+	case podDebugLoadedMsg:
+		m.loading = false
+		if m.currentScreen != PodDebugScreen {
+			return m, nil
+		}
+		m.viewport.SetContent(m.renderPodDebug(msg))
+		return m, nil
+
+	case yamlForEditLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		return m.navigateToYAMLEditor(msg.yaml), nil
+
+	case podInfoLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		return m.navigateToPodQuickActions(msg.name, msg.info), nil
+
+	case deploymentPodsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.followedDeployment = msg.deployment
+		m.followedSelector = msg.selector
+		m.followedPods = msg.pods
+		return m.navigateToDeploymentPods(), nil
+
+	case podContainersLoadedMsg:
+		m.loading = false
+		return m.handlePodContainersLoaded(msg), nil
+
+	case ownersLoadedMsg:
+		m.loading = false
+		if msg.err != nil && len(msg.chain) <= 1 {
+			m.err = msg.err
+			return m, nil
+		}
+		m.ownerChain = msg.chain
+		m.ownerNamespace = msg.namespace
+		m.err = nil
+		if msg.err != nil {
+			m.err = fmt.Errorf("could not look up the owner of %s: %v", msg.chain[len(msg.chain)-1].name, msg.err)
+		}
+		return m.navigateToOwners(), nil
+
+	case commandVocabularyLoadedMsg:
+		m.loading = false
+		m.vocabulary = &msg.vocabulary
+		// Saving goes on with the fuller check unless the name input was left
+		if m.currentScreen == SaveFavouriteScreen {
+			return m.handleSaveFavourite()
+		}
+		return m, nil
+
+	case pluginsLoadedMsg:
+		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		return m.navigateToSecretFieldSelection(msg.keys), nil
+		m.plugins = msg.plugins
+		m = m.navigateToPluginsList(msg.plugins)
+		if msg.warnings != "" {
+			// The first line names a plugin; the last only counts the warnings
+			warning, _, _ := strings.Cut(msg.warnings, "\n")
+			m.err = fmt.Errorf("Some plugins may not run: %s", warning)
+		}
+		return m, nil
 
 	case clusterInfoLoadedMsg:
+		m.loading = false
 		if msg.err != nil {
 			m.err = fmt.Errorf("Failed to load cluster info: %v", msg.err)
 			m.viewport.SetContent(fmt.Sprintf("Error loading cluster information:\n\n%v\n\nPress 'Esc' to go back", msg.err))
@@ -223,9 +729,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Format and display cluster info
-		content := formatClusterInfoForDisplay(msg.info, m.width)
+		m.clusterInfo = msg.info
+		m.nodeUsage = nil
+		m.nodeUsagePaused = false
+		content := formatClusterInfoForDisplay(msg.info, nil, m.width)
 		m.viewport.SetContent(content)
-		return m, nil
+		m.nodeUsagePollID++
+		return m, m.sampleNodeUsage(m.nodeUsagePollID)
+
+	case nodeUsageSampledMsg:
+		return m.handleNodeUsageSample(msg)
+
+	case nodeUsagePollMsg:
+		if msg.id != m.nodeUsagePollID || m.currentScreen != ClusterInfoScreen {
+			return m, nil
+		}
+		return m, m.sampleNodeUsage(msg.id)
 
 	case clearErrorMsg:
 		// Clear the error message
@@ -243,6 +762,8 @@ func (m Model) toggleTheme() (Model, tea.Cmd) {
 	} else {
 		m.theme = ThemeDark
 	}
+	// Kept in the config too, so saving the settings does not undo it
+	m.config.Theme = strings.ToLower(m.theme.String())
 	// Set a temporary success message that will be cleared automatically
 	m.err = fmt.Errorf("✓ Switched to %s theme", m.theme.String())
 	// Return a command to clear the error after 5 seconds
@@ -273,20 +794,51 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 			if binding, ok := m.hotkeyStore.Get(hk); ok {
 				m.currentCommand = binding.Command
-				return m, m.executeCommand()
+				return m.runCommand()
 			}
 		}
 	}
 
-	switch msg.String() {
-	case "ctrl+c", "q":
+	// The editor takes every key, including Enter, apart from its own shortcuts
+	if m.currentScreen == YAMLEditorScreen {
+		return m.handleYAMLEditorKey(msg)
+	}
+
+	// Typing the start of a name jumps to it in the contexts and namespaces lists
+	jumped, ok := m.typeToJump(msg)
+	m = jumped
+	if ok {
+		return m, nil
+	}
+
+	switch {
+	case m.isTextInputScreen() && msg.Type == tea.KeyRunes:
+		// Typed characters belong to the input, not to single-key shortcuts
+
+	case key.Matches(msg, m.keys.Cancel):
+		// Cancel the in-flight command, if any
+		if m.cancelCommand != nil {
+			m.cancelCommand()
+			m.cancelCommand = nil
+			m.err = fmt.Errorf("Cancelling command...")
+			return m, nil
+		}
+
+	case key.Matches(msg, m.keys.Rerun) && !m.isTextInputScreen():
+		return m.rerunLastCommand()
+
+	case key.Matches(msg, m.keys.RecentContexts) && !m.isTextInputScreen():
+		// Text inputs keep ctrl+k for deleting to the end of the line
+		return m.navigateToRecentContexts(), nil
+
+	case key.Matches(msg, m.keys.Quit):
 		if m.currentScreen == MainMenuScreen {
 			return m, tea.Quit
 		}
 		// Return to main menu from other screens
 		return m.navigateToMainMenu(), nil
 
-	case "esc":
+	case key.Matches(msg, m.keys.Back):
 		if m.currentScreen == HotkeyBindScreen {
 			m.hotkeyBindingPending = false
 			return m.navigateToFavouritesList(), nil
@@ -300,16 +852,30 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Go back to previous screen
 		return m.navigateBack(), nil
 
-	case "enter":
+	case key.Matches(msg, m.keys.Select):
 		return m.handleEnterKey()
 
-	case " ":
+	case key.Matches(msg, m.keys.Toggle):
 		// Space bar toggles flags in flags selection screen
 		if m.currentScreen == FlagsSelectionScreen {
 			return m.toggleFlag(), nil
 		}
+		if m.currentScreen == CustomColumnsScreen {
+			return m.toggleCustomColumn(), nil
+		}
+		if m.currentScreen == ResourceSelectionScreen {
+			return m.toggleResourceSelection(), nil
+		}
+		// and ticks names for bulk delete and describe
+		if m.currentScreen == ResourceNameSelectionScreen && m.bulkSelected != nil && !m.noResourceNames {
+			return m.toggleBulkSelection()
+		}
+		// and pauses or resumes live screens
+		if m.currentScreen == ClusterInfoScreen || m.currentScreen == EventsStreamScreen {
+			return m.toggleWatchPause()
+		}
 
-	case "left":
+	case key.Matches(msg, m.keys.Prev):
 		if m.currentScreen == SavedOutputVersionsScreen {
 			versions := m.savedOutputsByBase[m.selectedSavedOutputBase]
 			if len(versions) == 0 {
@@ -323,7 +889,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-	case "right":
+	case key.Matches(msg, m.keys.Next):
 		if m.currentScreen == SavedOutputVersionsScreen {
 			versions := m.savedOutputsByBase[m.selectedSavedOutputBase]
 			if len(versions) == 0 {
@@ -337,11 +903,16 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-	case "d":
+	case key.Matches(msg, m.keys.Delete) && m.currentScreen == PortForwardsScreen:
+		return m.deletePortForward(), nil
+
+	case key.Matches(msg, m.keys.Delete) && m.currentScreen == PinnedOutputsListScreen:
+		return m.unpinOutput(), nil
+
+	case key.Matches(msg, m.keys.Delete):
 		// Delete favourite if in favourites list
 		if m.currentScreen == FavouritesListScreen && m.favStore != nil {
-			idx := m.list.Index()
-			if idx >= 0 && idx < len(m.favStore.List()) {
+			if idx, ok := m.selectedFavouriteIndex(); ok {
 				return m, m.deleteFavourite(idx)
 			}
 		}
@@ -383,8 +954,8 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			selected := m.list.SelectedItem()
 			if selected != nil {
 				base := selected.(ui.SimpleItem).Title()
-				if base != "No saved outputs" {
-					return m, m.deleteSavedOutputGroup(base)
+				if base != "No saved outputs" && base != "Loading..." {
+					return m.navigateToDeleteSavedOutputConfirmation(base), nil
 				}
 			}
 		}
@@ -407,7 +978,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.deleteSavedOutput(filename)
 		}
 
-	case "s":
+	case key.Matches(msg, m.keys.Save):
 		// Save output if in command output screen
 		if m.currentScreen == CommandOutputScreen {
 			baseName, ok, err := m.getSavedOutputBaseNameForCommand(m.currentCommand)
@@ -430,16 +1001,18 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "r":
-		// Refresh cluster info if in cluster info screen
-		if m.currentScreen == ClusterInfoScreen {
-			m.viewport.SetContent("Refreshing cluster information...\n\nThis may take a few moments.")
-			return m, m.loadClusterInfo()
-		}
+	case key.Matches(msg, m.keys.Refresh) && m.currentScreen == ClusterInfoScreen:
+		// Refresh cluster info
+		m.viewport.SetContent("Refreshing cluster information...\n\nThis may take a few moments.")
+		return m, m.loadClusterInfo()
+
+	case key.Matches(msg, m.keys.Refresh) && m.currentScreen == PodDebugScreen:
+		return m, m.loadPodDebug()
+
+	case key.Matches(msg, m.keys.Rename):
 		// Rename favourite if in favourites list
 		if m.currentScreen == FavouritesListScreen && m.favStore != nil {
-			idx := m.list.Index()
-			if idx >= 0 && idx < len(m.favStore.List()) {
+			if idx, ok := m.selectedFavouriteIndex(); ok {
 				return m.navigateToRenameFavourite(idx), nil
 			}
 		}
@@ -458,12 +1031,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "h":
+	case key.Matches(msg, m.keys.BindHotkey):
 		// Start hotkey bind flow from favourites list
 		if m.currentScreen == FavouritesListScreen && m.favStore != nil && m.hotkeyStore != nil {
-			idx := m.list.Index()
-			fav, ok := m.favStore.Get(idx)
-			if ok {
+			idx, ok := m.selectedFavouriteIndex()
+			fav, found := m.favStore.Get(idx)
+			if ok && found {
 				m.hotkeyBindingFavourite = fav
 				m.hotkeyBindingPending = true
 				m.previousScreen = m.currentScreen
@@ -472,18 +1045,88 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "t":
+	case key.Matches(msg, m.keys.CompareLive) && m.currentScreen == SavedOutputViewScreen:
+		// Diff the saved output against what its command prints now
+		return m.compareWithLive()
+
+	case key.Matches(msg, m.keys.CheckContexts):
+		// Probe reachability of all contexts
+		if m.currentScreen == ContextsListScreen {
+			m.err = fmt.Errorf("Checking context reachability...")
+			return m, m.probeContexts()
+		}
+
+	case key.Matches(msg, m.keys.Retry) && m.canRetry():
+		// Share keys with other actions, so only offered after a failure
+		return m.retryCommand()
+
+	case key.Matches(msg, m.keys.EditRetry) && m.canRetry():
+		return m.editAndRetryCommand(), nil
+
+	case key.Matches(msg, m.keys.Upload) && m.pasteClient != nil && (m.currentScreen == CommandOutputScreen || m.currentScreen == SavedOutputViewScreen):
+		return m.navigateToUploadConfirmation(), nil
+
+	case key.Matches(msg, m.keys.AppendScript):
+		// Append the executed command to this session's script
+		if m.currentScreen == CommandOutputScreen {
+			return m, m.appendToSessionScript()
+		}
+
+	case key.Matches(msg, m.keys.ExportScript):
+		// Export the whole history as a shell script
+		if m.currentScreen == CommandHistoryScreen {
+			return m, m.exportHistoryScript()
+		}
+
+	case key.Matches(msg, m.keys.AllNamespaces):
+		// Switch the name list between the current and all namespaces
+		if m.currentScreen == ResourceNameSelectionScreen {
+			return m.toggleAllNamespaces()
+		}
+
+	case key.Matches(msg, m.keys.ToggleTimes):
+		// Switch history timestamps between relative and absolute
+		if m.currentScreen == CommandHistoryScreen {
+			idx := m.list.Index()
+			m.historyAbsoluteTime = !m.historyAbsoluteTime
+			previous := m.previousScreen
+			m = m.navigateToCommandHistory()
+			m.previousScreen = previous
+			m.list.Select(idx)
+			return m, nil
+		}
+
+	case key.Matches(msg, m.keys.RememberFlags) && m.currentScreen == FlagsSelectionScreen:
+		return m.rememberDefaultFlags(), nil
+
+	case key.Matches(msg, m.keys.Pin) && m.currentScreen == CommandOutputScreen:
+		// Keep the output in memory to come back to from the main menu
+		return m.pinCurrentOutput(), nil
+
+	case key.Matches(msg, m.keys.Pin):
+		// Pin or unpin the highlighted namespace
+		if m.currentScreen == NamespacesListScreen {
+			return m.togglePinnedNamespace(), nil
+		}
+
+	case key.Matches(msg, m.keys.Theme):
 		// Toggle theme
 		return m.toggleTheme()
 	}
 
 	// Pass other keys to the active component
 	switch m.currentScreen {
-	case SaveFavouriteScreen, RenameFavouriteScreen, RenameSavedOutputScreen, NamespaceInputScreen, SaveOutputNameScreen:
+	case SaveFavouriteScreen, RenameFavouriteScreen, RenameSavedOutputScreen, NamespaceInputScreen, SaveOutputNameScreen, KubeconfigInputScreen,
+		TemplateInputScreen, PluginArgsScreen, CreateNamespaceScreen, GrepPatternInputScreen, JSONPathInputScreen, ContextConfirmationScreen, AppendArgsScreen, SettingsInputScreen:
+		m.textInput, cmd = m.textInput.Update(msg)
+	case CustomCommandScreen:
 		m.textInput, cmd = m.textInput.Update(msg)
+		var fetch tea.Cmd
+		m, fetch = m.updateCommandSuggestions()
+		cmd = tea.Batch(cmd, fetch)
 	case CommandOutputScreen, SavedOutputViewScreen:
 		m.viewport, cmd = ui.UpdateViewport(m.viewport, msg)
-	case CommandHelpScreen:
+	case CommandHelpScreen, DryRunScreen, LogViewerScreen, EventsStreamScreen, PodDebugScreen, PinnedOutputViewScreen, ErrorScreen:
 		m.viewport, cmd = ui.UpdateViewport(m.viewport, msg)
 	case ClusterConnectivityScreen:
 		m.viewport, cmd = ui.UpdateViewport(m.viewport, msg)
@@ -518,6 +1161,60 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 	case FlagsSelectionScreen:
 		return m.handleFlagsSelection()
 
+	case CustomColumnsScreen:
+		return m.handleCustomColumnsSelection()
+
+	case ContextFlagSelectionScreen:
+		return m.handleContextFlagSelection()
+
+	case OwnersScreen:
+		return m.handleOwnerSelection()
+
+	case PodQuickActionsScreen:
+		return m.handlePodQuickActionSelection()
+
+	case PortForwardsScreen:
+		return m.togglePortForward()
+
+	case RecentContextsScreen:
+		return m.handleRecentContextSelection()
+
+	case WaitConditionScreen:
+		return m.handleWaitConditionSelection()
+
+	case ContainerSelectionScreen:
+		return m.handleContainerSelection()
+
+	case DeploymentPodsScreen:
+		return m.handleDeploymentPodSelection()
+
+	case UploadConfirmationScreen:
+		return m.handleUploadConfirmation()
+
+	case GrepPatternInputScreen:
+		return m.handleGrepPatternInput()
+
+	case JSONPathInputScreen:
+		return m.handleJSONPathInput()
+
+	case AppendArgsScreen:
+		return m.handleAppendArgsInput()
+
+	case SettingsScreen:
+		return m.handleSettingsSelection()
+
+	case SettingsInputScreen:
+		return m.handleSettingsInput()
+
+	case TemplateInputScreen:
+		return m.handleTemplateInput()
+
+	case PluginsListScreen:
+		return m.handlePluginSelection()
+
+	case PluginArgsScreen:
+		return m.handlePluginArgs()
+
 	case CommandPreviewScreen:
 		return m.handleCommandPreviewSelection()
 
@@ -560,14 +1257,35 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 	case CustomCommandScreen:
 		return m.handleCustomCommandInput()
 
-	case SecretFieldSelectionScreen:
-		return m.handleSecretFieldSelection()
+	case FieldSelectionScreen:
+		return m.handleFieldSelection()
 
 	case DeleteConfirmationScreen:
 		return m.handleDeleteConfirmationSelection()
 
 	case PortInputScreen:
 		return m.handlePortInput()
+
+	case DeleteSavedOutputConfirmationScreen:
+		return m.handleDeleteSavedOutputConfirmation()
+
+	case KubeconfigInputScreen:
+		return m.handleKubeconfigInput()
+
+	case CreateNamespaceScreen:
+		return m.handleCreateNamespace()
+
+	case ContextConfirmationScreen:
+		return m.handleContextConfirmation()
+
+	case DeleteNamespaceSelectionScreen:
+		return m.handleDeleteNamespaceSelection()
+
+	case DeleteNamespaceConfirmationScreen:
+		return m.handleDeleteNamespaceConfirmation()
+
+	case PinnedOutputsListScreen:
+		return m.handlePinnedOutputSelection()
 	}
 
 	return m, nil
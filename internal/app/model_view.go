@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
 )
 
@@ -16,34 +17,98 @@ func (m Model) View() string {
 	}
 
 	var s strings.Builder
-
-	// Show error if present
-	if m.err != nil {
-		s.WriteString(m.GetErrorStyle().Render(fmt.Sprintf("⚠️  Error: %v\n\n", m.err)))
-	}
+	s.WriteString(m.viewHeader())
 
 	// Render current screen
 	switch m.currentScreen {
 	case CommandOutputScreen:
 		s.WriteString(m.GetHeaderStyle().Render("Command Output") + "\n")
-		s.WriteString(m.GetBorderStyle().Render(strings.Repeat("─", m.width)) + "\n")
-		s.WriteString(fmt.Sprintf("Command: %s\n\n", m.currentCommand))
+		s.WriteString(m.borderRule())
+		s.WriteString(fmt.Sprintf("Command: %s", m.currentCommand))
+		if m.currentOutputContext != "" {
+			s.WriteString(" | Context: " + m.currentOutputContext)
+		}
+		if m.commandDuration > 0 {
+			s.WriteString(" | Took " + humanizeDuration(m.commandDuration))
+		}
+		s.WriteString("\n" + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString("\n" + m.GetHelpStyle().Render(m.currentOutputSize))
+		s.WriteString("\n" + m.shortHelp(m.retryHelp()+"Press 's' to save output | "+m.uploadHelp()+"'p' to pin it for this session | 'a' to append command to session script | 'q' to return to main menu | ↑↓ to scroll"))
+
+	case ErrorScreen:
+		s.WriteString(m.GetHeaderStyle().Render("Command Failed") + "\n")
+		s.WriteString(m.borderRule())
+		s.WriteString(fmt.Sprintf("Command: %s", m.currentCommand))
+		if m.currentOutputContext != "" {
+			s.WriteString(" | Context: " + m.currentOutputContext)
+		}
+		s.WriteString("\n" + m.blankLine())
 		s.WriteString(m.viewport.View())
-		s.WriteString("\n\nPress 's' to save output | 'q' to return to main menu | ↑↓ to scroll")
+		s.WriteString(m.helpFooter(m.retryHelp() + "Press 'Esc' to go back to the command | 'q' to return to main menu | ↑↓ to scroll"))
+
+	case PinnedOutputViewScreen:
+		p := m.pinnedOutputs[m.viewingPinnedOutput]
+		s.WriteString(m.GetHeaderStyle().Render("Pinned Output") + "\n")
+		s.WriteString(m.borderRule())
+		s.WriteString(fmt.Sprintf("Command: %s", p.command))
+		if p.context != "" {
+			s.WriteString(" | Context: " + p.context)
+		}
+		s.WriteString(" | Pinned " + humanizeSince(p.pinnedAt) + "\n" + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString(m.helpFooter("Press 'Esc' to go back to pinned outputs | ↑↓ to scroll"))
 
 	case CommandHelpScreen:
 		s.WriteString("Command Help\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
-		s.WriteString(fmt.Sprintf("Command: %s --help\n\n", m.currentCommand))
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Command: %s --help\n", m.currentCommand) + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString(m.helpFooter("Press 'Esc' to go back | ↑↓ to scroll"))
+
+	case DryRunScreen:
+		s.WriteString("Dry Run\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Command: %s\n", m.lastDryRunCommand) + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString(m.helpFooter("Press 'Esc' to go back | ↑↓ to scroll"))
+
+	case LogViewerScreen:
+		s.WriteString("Logs\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("File: %s\n", m.logPath) + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString(m.helpFooter("Press 'Esc' to go back | ↑↓ to scroll"))
+
+	case YAMLEditorScreen:
+		s.WriteString("Edit YAML: " + m.selectedResourceName + "\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(m.yamlEditor.View())
+		s.WriteString(m.helpFooter("Press 'ctrl+s' to apply | 'Esc' to go back without applying"))
+
+	case PodDebugScreen:
+		s.WriteString("Pod Debug: " + m.selectedResourceName + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString(m.viewport.View())
-		s.WriteString("\n\nPress 'Esc' to go back | ↑↓ to scroll")
+		s.WriteString(m.helpFooter("Press 'r' to refresh | 'Esc' to go back | ↑↓ to scroll"))
+
+	case EventsStreamScreen:
+		s.WriteString("Cluster Events  " + watchStatus(m.eventsPaused, 0))
+		if len(m.heldEventLines) > 0 {
+			s.WriteString(fmt.Sprintf(" (%s held)", pluralize(len(m.heldEventLines), "new event")))
+		}
+		s.WriteString("\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Command: kubectl get events -A --watch\n" + m.blankLine())
+		s.WriteString(m.viewport.View())
+		s.WriteString(m.helpFooter("Press 'Space' to pause/resume | 'Esc' to stop | ↑↓ to scroll (scroll to the end to follow new events)"))
 
 	case HotkeyBindScreen:
 		s.WriteString("Bind Hotkey\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString("Press F1-F12 to bind the selected favourite\n\n")
 		s.WriteString(fmt.Sprintf("Favourite: %s\n", m.hotkeyBindingFavourite.Name))
-		s.WriteString(fmt.Sprintf("Command: %s\n\n", m.hotkeyBindingFavourite.Command))
+		s.WriteString(fmt.Sprintf("Command: %s\n", m.hotkeyBindingFavourite.Command) + m.blankLine())
 		s.WriteString("Press Esc to cancel")
 
 	case HotkeysListScreen:
@@ -51,9 +116,9 @@ func (m Model) View() string {
 
 	case ClusterConnectivityScreen:
 		s.WriteString("Cluster Connectivity\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString(m.viewport.View())
-		s.WriteString("\n\nPress 'Esc' to go back | ↑↓ to scroll")
+		s.WriteString(m.helpFooter("Press 'Esc' to go back | ↑↓ to scroll"))
 
 	case ClusterInfoScreen:
 		s.WriteString(m.renderClusterInfo())
@@ -63,57 +128,142 @@ func (m Model) View() string {
 
 	case SaveFavouriteScreen:
 		s.WriteString("Save as Favourite\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
-		s.WriteString(fmt.Sprintf("Command: %s\n\n", m.currentCommand))
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Command: %s\n", m.currentCommand) + m.blankLine())
+		s.WriteString(m.implicitNamespaceNote())
+		s.WriteString("Enter a name, or group/name to file it in a group:\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to save, Esc to cancel")
+		s.WriteString(m.helpFooter("Press Enter to save, Esc to cancel"))
 
 	case RenameFavouriteScreen:
 		s.WriteString("Rename Favourite\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
-		s.WriteString("Enter new name:\n\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Enter new name (group/name moves it to another group):\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to save, Esc to cancel")
+		s.WriteString(m.helpFooter("Press Enter to save, Esc to cancel"))
 
 	case RenameSavedOutputScreen:
 		s.WriteString("Rename Saved Output\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString("Enter new name (without extension):\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to save, Esc to cancel")
+		s.WriteString(m.helpFooter("Press Enter to save, Esc to cancel"))
 
 	case NamespaceInputScreen:
 		s.WriteString("Custom Namespace\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString("Enter namespace name:\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to continue, Esc to cancel")
+		if matches := namespaceMatches(m.completionNames["namespaces"], m.textInput.Value()); len(matches) > 0 {
+			s.WriteString("\n\n")
+			for _, name := range matches {
+				s.WriteString("  " + name + "\n")
+			}
+		}
+		s.WriteString(m.helpFooter("Press Tab to complete, Enter to continue, Esc to cancel"))
+
+	case PluginArgsScreen:
+		s.WriteString("Run Plugin\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Enter the arguments for kubectl %s:\n\n", m.selectedPlugin))
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to preview, Esc to cancel"))
+
+	case CreateNamespaceScreen:
+		s.WriteString("Create Namespace\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Enter a name for the new namespace:\n\n")
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to create, Esc to cancel"))
+
+	case ContextConfirmationScreen:
+		s.WriteString("Protected Context\n")
+		s.WriteString(m.rule("─"))
+		if m.confirmingAction != nil {
+			s.WriteString(fmt.Sprintf("⚠️  The current context %s is protected. Type its name to change its namespaces:\n\n", m.confirmingContext))
+		} else if m.confirmingRun {
+			s.WriteString(fmt.Sprintf("⚠️  The current context %s is protected. Type its name to run commands against it:\n\n", m.confirmingContext))
+		} else {
+			s.WriteString(fmt.Sprintf("⚠️  %s is a protected context. Type its name to switch to it:\n\n", m.confirmingContext))
+		}
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to confirm, Esc to cancel"))
+
+	case GrepPatternInputScreen:
+		s.WriteString("Filter Output\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Enter a regular expression; only output lines matching it are shown:\n\n")
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to apply, Esc to cancel"))
+
+	case AppendArgsScreen:
+		s.WriteString("Append Args\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Command: %s\n", m.currentCommand) + m.blankLine())
+		s.WriteString("Enter extra arguments to add to the end of the command:\n\n")
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to append, Esc to cancel"))
+
+	case SettingsInputScreen:
+		s.WriteString("Settings\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Enter a new value for %s:\n\n", m.editingSetting))
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to save, Esc to cancel"))
+
+	case JSONPathInputScreen:
+		s.WriteString("JSONPath Output\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Enter a JSONPath expression, e.g. {.items[*].metadata.name} or {range .items[*]}{.metadata.name}{\"\\n\"}{end}:\n\n")
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to apply, Esc to cancel"))
+
+	case KubeconfigInputScreen:
+		s.WriteString("Kubeconfig File\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString("Enter the path to a kubeconfig file (leave empty to use the default):\n\n")
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to apply, Esc to cancel"))
 
 	case CommandPreviewScreen:
-		s.WriteString("Command Preview\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
-		s.WriteString(fmt.Sprintf("Command: %s\n\n", m.currentCommand))
+		s.WriteString(m.commandPreviewHeader())
 		s.WriteString(m.list.View())
 
 	case SavedOutputViewScreen:
 		s.WriteString("Saved Output: " + m.selectedSavedOutput + "\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
+		if m.liveComparison != "" {
+			s.WriteString(m.GetWarningStyle().Render(m.liveComparison) + "\n\n")
+		}
 		s.WriteString(m.viewport.View())
-		s.WriteString("\n\nPress 'd' to delete | 'q' or 'Esc' to go back | ↑↓ to scroll")
+		s.WriteString("\n" + m.GetHelpStyle().Render(outputSize(strings.TrimPrefix(m.currentOutputContent, "Output:\n"), "")))
+		s.WriteString("\nPress 'c' to compare with live | " + m.uploadHelp() + "'d' to delete | 'q' or 'Esc' to go back | ↑↓ to scroll")
+
+	case DeleteConfirmationScreen:
+		s.WriteString(m.deleteConfirmationHeader())
+		s.WriteString(m.list.View())
 
 	case CustomCommandScreen:
 		s.WriteString("Custom Command\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString("Enter kubectl arguments (without the leading 'kubectl') or a full kubectl command:\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to preview, Esc to cancel")
+		s.WriteString(m.helpFooter("Press Tab to complete, Enter to preview, Esc to cancel"))
 
 	case SaveOutputNameScreen:
 		s.WriteString("Save Output\n")
-		s.WriteString(strings.Repeat("─", m.width) + "\n")
+		s.WriteString(m.rule("─"))
 		s.WriteString("Enter name for saved output (without extension):\n\n")
 		s.WriteString(m.textInput.View())
-		s.WriteString("\n\nPress Enter to save, Esc to cancel")
+		s.WriteString(m.helpFooter("Press Enter to save, Esc to cancel"))
+
+	case TemplateInputScreen:
+		s.WriteString("Fill In Command Template\n")
+		s.WriteString(m.rule("─"))
+		s.WriteString(fmt.Sprintf("Command: %s\n", fillPlaceholders(m.templateCommand, m.templateValues)) + m.blankLine())
+		s.WriteString(fmt.Sprintf("Enter value for %s:\n\n", m.templateProgress()))
+		s.WriteString(m.textInput.View())
+		s.WriteString(m.helpFooter("Press Enter to continue, Esc to cancel"))
 
 	case SavedOutputsListScreen:
 		s.WriteString(m.list.View())
@@ -123,18 +273,12 @@ func (m Model) View() string {
 
 	default:
 		s.WriteString(m.list.View())
+		if m.jumping {
+			s.WriteString("\n" + m.GetHelpStyle().Render("Jump: "+m.jumpPrefix+"  (Enter to select, Esc to stop)"))
+		}
 	}
 
-	// Add context-sensitive help text at the bottom
-	if m.currentScreen == MainMenuScreen {
-		s.WriteString("\n\n")
-		s.WriteString(m.GetHelpStyle().Render("Press 'q' to quit | 't' to toggle theme "))
-		s.WriteString(m.GetHelpStyle().Render(fmt.Sprintf("(Current: %s Mode)", m.theme.String())))
-	} else {
-		s.WriteString("\n\n")
-		s.WriteString(m.GetHelpStyle().Render("Press 'Esc' to go back | 'q' to quit | 't' to toggle theme "))
-		s.WriteString(m.GetHelpStyle().Render(fmt.Sprintf("(Current: %s Mode)", m.theme.String())))
-	}
+	s.WriteString(m.viewFooter())
 
 	return s.String()
 }
@@ -167,7 +311,7 @@ func (m Model) renderSavedOutputVersionsTable() string {
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Saved Outputs: %s\n", m.selectedSavedOutputBase))
-	sb.WriteString(strings.Repeat("─", m.width) + "\n")
+	sb.WriteString(m.rule("─"))
 
 	for i, lbl := range labels {
 		cell := lbl
@@ -191,8 +335,12 @@ func (m Model) renderSavedOutputVersionsTable() string {
 func (m Model) renderClusterInfo() string {
 	var sb strings.Builder
 
-	sb.WriteString("Cluster Information\n")
-	sb.WriteString(strings.Repeat("═", m.width) + "\n\n")
+	sb.WriteString("Cluster Information")
+	if m.clusterInfo != nil {
+		sb.WriteString("  " + watchStatus(m.nodeUsagePaused, nodeUsageInterval))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(m.rule("═") + m.blankLine())
 
 	// Check if we have cluster info in the viewport content
 	if m.viewport.Height == 0 {
@@ -203,13 +351,14 @@ func (m Model) renderClusterInfo() string {
 
 	// Display the viewport content (which contains the formatted cluster info)
 	sb.WriteString(m.viewport.View())
-	sb.WriteString("\n\nPress 'r' to refresh | 'Esc' to go back | ↑↓ to scroll")
+	sb.WriteString(m.helpFooter("Press 'r' to refresh | 'Space' to pause/resume sampling | 'Esc' to go back | ↑↓ to scroll"))
 
 	return sb.String()
 }
 
-// formatClusterInfoForDisplay formats ClusterInfo into a beautiful display string
-func formatClusterInfoForDisplay(info *kubectl.ClusterInfo, width int) string {
+// formatClusterInfoForDisplay formats ClusterInfo into a beautiful display
+// string, with the usage trend of each node when samples were taken
+func formatClusterInfoForDisplay(info *kubectl.ClusterInfo, usage map[string]nodeUsageHistory, width int) string {
 	var sb strings.Builder
 
 	// Header with context
@@ -240,7 +389,10 @@ func formatClusterInfoForDisplay(info *kubectl.ClusterInfo, width int) string {
 	if len(info.Nodes) > 0 {
 		sb.WriteString("🖥️  Node Details\n")
 		sb.WriteString(strings.Repeat("─", width) + "\n")
-
+	}
+	if table, ok := formatNodeTable(info.Nodes, width); ok {
+		sb.WriteString(table)
+	} else if len(info.Nodes) > 0 {
 		for i, node := range info.Nodes {
 			if i > 0 {
 				sb.WriteString("\n")
@@ -280,9 +432,201 @@ func formatClusterInfoForDisplay(info *kubectl.ClusterInfo, width int) string {
 		}
 	}
 
+	if trend := formatNodeUsageTrend(info.Nodes, usage); trend != "" {
+		sb.WriteString("\n📈 Usage Trend (sampled every " + nodeUsageInterval.String() + ")\n")
+		sb.WriteString(strings.Repeat("─", width) + "\n")
+		sb.WriteString(trend)
+	}
+
 	sb.WriteString("\n")
 	sb.WriteString(strings.Repeat("─", width) + "\n")
 	sb.WriteString("💡 Tip: Metrics require metrics-server to be installed in the cluster\n")
 
 	return sb.String()
 }
+
+// wideNodeTableMinWidth is the terminal width from which nodes are rendered as a table
+const wideNodeTableMinWidth = 110
+
+// formatNodeTable renders nodes as an aligned table with one row per node. It
+// returns false when the terminal is too narrow, so callers can fall back to
+// the vertical layout.
+func formatNodeTable(nodes []kubectl.NodeInfo, width int) (string, bool) {
+	if len(nodes) == 0 || width < wideNodeTableMinWidth {
+		return "", false
+	}
+
+	rows := [][]string{{"NAME", "STATUS", "ROLES", "CPU", "MEM", "PODS", "AGE"}}
+	for _, node := range nodes {
+		statusIcon := "✅"
+		if node.Status != "Ready" {
+			statusIcon = "❌"
+		}
+		cpu := node.CPUAllocatable
+		if node.CPUUsage != "" {
+			cpu = node.CPUUsage + " / " + node.CPUAllocatable
+		}
+		mem := node.MemoryAllocatable
+		if node.MemoryUsage != "" {
+			mem = node.MemoryUsage + " / " + node.MemoryAllocatable
+		}
+		rows = append(rows, []string{
+			node.Name,
+			statusIcon + " " + node.Status,
+			node.Roles,
+			cpu,
+			mem,
+			node.PodCount + " / " + node.PodCapacity,
+			node.Age,
+		})
+	}
+
+	// Column widths are measured with lipgloss so emoji are counted correctly
+	colWidths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := lipgloss.Width(cell); w > colWidths[i] {
+				colWidths[i] = w
+			}
+		}
+	}
+
+	const gap = 3
+	total := 0
+	for _, w := range colWidths {
+		total += w + gap
+	}
+	if total-gap > width {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for r, row := range rows {
+		for i, cell := range row {
+			sb.WriteString(cell)
+			if i < len(row)-1 {
+				sb.WriteString(strings.Repeat(" ", colWidths[i]-lipgloss.Width(cell)+gap))
+			}
+		}
+		sb.WriteString("\n")
+		if r == 0 {
+			sb.WriteString(strings.Repeat("─", total-gap) + "\n")
+		}
+	}
+	return sb.String(), true
+}
+
+// viewHeader renders what is shown above every screen: the last error or
+// status, the spinner and the cluster warning.
+func (m Model) viewHeader() string {
+	var s strings.Builder
+
+	// Show error if present
+	if m.err != nil {
+		s.WriteString(m.GetErrorStyle().Render(fmt.Sprintf("⚠️  Error: %v\n", m.err) + m.blankLine()))
+		// Errors of background kubectl calls get the same suggestions as the errors screen
+		if hint := interpretKubectlError(m.err.Error()); hint != "" && !strings.HasPrefix(m.err.Error(), "✓") {
+			s.WriteString(m.GetHelpStyle().Render("💡 "+hint) + "\n\n")
+		}
+	}
+
+	// Show spinner while a kubectl call is in flight
+	if m.loading {
+		label := m.loadingLabel
+		// The spinner's ticks redraw this, so the elapsed time counts up
+		if m.cancelCommand != nil && !m.commandStarted.IsZero() {
+			label = fmt.Sprintf("Running… %s (ctrl+x to cancel)", humanizeDuration(now().Sub(m.commandStarted)))
+		}
+		s.WriteString(m.spinner.View() + " " + label + "\n" + m.blankLine())
+	}
+
+	// Warn before navigating that the cluster did not respond
+	if m.currentScreen == MainMenuScreen && m.clusterWarning != "" {
+		s.WriteString(m.GetWarningStyle().Render(m.clusterWarning) + "\n\n")
+	}
+	return s.String()
+}
+
+// viewFooter renders the help and status lines below every screen.
+func (m Model) viewFooter() string {
+	var s strings.Builder
+
+	// Add context-sensitive help text at the bottom
+	if m.compact() {
+		help := "Esc back | q quit | t theme"
+		if m.currentScreen == MainMenuScreen {
+			help = "q quit | t theme"
+		}
+		s.WriteString("\n")
+		s.WriteString(m.GetHelpStyle().Render(help))
+	} else if m.currentScreen == MainMenuScreen {
+		s.WriteString("\n\n")
+		s.WriteString(m.GetHelpStyle().Render("Press 'q' to quit | 't' to toggle theme "))
+		s.WriteString(m.GetHelpStyle().Render(fmt.Sprintf("(Current: %s Mode)", m.theme.String())))
+	} else {
+		s.WriteString("\n\n")
+		s.WriteString(m.GetHelpStyle().Render("Press 'Esc' to go back | 'q' to quit | 't' to toggle theme "))
+		s.WriteString(m.GetHelpStyle().Render(fmt.Sprintf("(Current: %s Mode)", m.theme.String())))
+	}
+
+	// Status line with the active kubeconfig, if one was chosen explicitly
+	if m.kubectlClient != nil && m.kubectlClient.Kubeconfig() != "" {
+		s.WriteString("\n")
+		s.WriteString(m.GetHelpStyle().Render("Kubeconfig: " + m.kubectlClient.Kubeconfig()))
+	}
+	return s.String()
+}
+
+// commandPreviewHeader renders the command and its notes above the preview's
+// choices.
+func (m Model) commandPreviewHeader() string {
+	var s strings.Builder
+	s.WriteString("Command Preview\n")
+	s.WriteString(m.rule("─"))
+	s.WriteString(fmt.Sprintf("Command: %s\n", m.currentCommand) + m.blankLine())
+	if m.previewWarning != "" && m.previewWarningCommand == m.currentCommand {
+		s.WriteString("⚠️  " + m.previewWarning + "\n\n")
+	}
+	s.WriteString(m.implicitNamespaceNote())
+	if flags := explainFlags(m.currentCommand); len(flags) > 0 {
+		s.WriteString("Flags:\n")
+		for _, line := range flags {
+			s.WriteString("  " + line + "\n")
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// implicitNamespaceNote notes that the default namespace was added to the
+// current command rather than chosen, until the command is changed.
+func (m Model) implicitNamespaceNote() string {
+	if m.implicitNamespaceCommand == "" || m.implicitNamespaceCommand != m.currentCommand {
+		return ""
+	}
+	return fmt.Sprintf("ℹ️  namespace '%s' applied from default\n\n", m.defaultNamespace)
+}
+
+// deleteConfirmationHeader renders what is about to be deleted above the
+// confirmation's choices.
+func (m Model) deleteConfirmationHeader() string {
+	var s strings.Builder
+	if m.selectedAction == ActionDrain {
+		s.WriteString(fmt.Sprintf("Command: %s\n", m.currentCommand) + m.blankLine())
+		s.WriteString("The node is cordoned and all of its pods are evicted. Pods without a controller\n")
+		s.WriteString("are only evicted with --force and will not be recreated.\n\n")
+	}
+	if m.selectedAction == ActionRestart {
+		s.WriteString("The pod will be deleted. If it is managed by a controller (Deployment, ReplicaSet,\n")
+		s.WriteString("StatefulSet, DaemonSet, Job) a replacement is created automatically; a bare pod is gone for good.\n\n")
+	}
+	// List every name when deleting several resources at once
+	if names := strings.Fields(m.selectedResourceName); len(names) > 1 {
+		s.WriteString(fmt.Sprintf("The following %ss will be deleted:\n", getResourceShortName(m.selectedResource)))
+		for _, name := range names {
+			s.WriteString("  • " + name + "\n")
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// waitCondition is a condition offered for the Wait action.
+type waitCondition struct {
+	name        string
+	description string
+}
+
+// waitConditions lists the conditions resource reports, the one usually
+// waited for first. Conditions a resource never reports would only time out,
+// so they are not offered.
+func waitConditions(resource ResourceType) []waitCondition {
+	switch resource {
+	case ResourcePods:
+		return []waitCondition{
+			{"Ready", "All containers are ready and the pod serves traffic"},
+			{"ContainersReady", "All containers are ready"},
+			{"PodScheduled", "The pod has been placed on a node"},
+		}
+	case ResourceDeployments:
+		return []waitCondition{
+			{"Available", "The minimum number of replicas is available"},
+			{"Progressing", "The rollout is making progress or has completed"},
+		}
+	case ResourceNodes:
+		return []waitCondition{
+			{"Ready", "The node is healthy and accepts pods"},
+		}
+	}
+	return nil
+}
+
+// waitForFlag returns the --for flag among flags, or "".
+func waitForFlag(flags []string) string {
+	for _, flag := range flags {
+		if strings.HasPrefix(flag, "--for=") {
+			return flag
+		}
+	}
+	return ""
+}
+
+// navigateToWaitConditions asks which condition the selected resource should
+// be waited on for; the timeout and namespace are chosen as flags next.
+func (m Model) navigateToWaitConditions() Model {
+	var items []list.Item
+	for _, c := range waitConditions(m.selectedResource) {
+		items = append(items, ui.NewSimpleItem(c.name, c.description))
+	}
+	m.list = ui.NewList(items, "Wait for "+m.selectedResourceName+" to be…", m.width, m.listHeight())
+	m.previousScreen = m.currentScreen
+	m.currentScreen = WaitConditionScreen
+	return m
+}
+
+func (m Model) handleWaitConditionSelection() (tea.Model, tea.Cmd) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+	m.waitCondition = selected.(ui.SimpleItem).Title()
+	return m.navigateToFlagsSelection(), nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Test that Wait asks for a condition after the name, then for a timeout,
+// and builds a kubectl wait with the condition before the resource.
+func TestWaitBuildsConditionCommand(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40,
+		selectedResource: ResourceDeployments, selectedAction: ActionWait}
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"web"}})
+	m = updated.(Model)
+
+	updated, _ = m.handleResourceNameSelection()
+	if m = updated.(Model); m.currentScreen != WaitConditionScreen {
+		t.Fatalf("expected the condition choice, got %s", m.currentScreen)
+	}
+	if first := m.list.Items()[0].(ui.SimpleItem).Title(); first != "Available" {
+		t.Errorf("expected Available first for deployments, got %s", first)
+	}
+
+	updated, _ = m.handleWaitConditionSelection()
+	if m = updated.(Model); m.currentScreen != FlagsSelectionScreen {
+		t.Fatalf("expected the flags, got %s", m.currentScreen)
+	}
+	for i, item := range m.list.Items() {
+		if stripCheckbox(item.(ui.SimpleItem).Title()) == "--timeout=2m" {
+			m.list.Select(i)
+		}
+	}
+	m = m.toggleFlag()
+	m.list.Select(0)
+
+	updated, _ = m.handleFlagsSelection()
+	m = updated.(Model)
+	if want := "kubectl wait --for=condition=Available deployment/web --timeout=2m"; m.currentCommand != want {
+		t.Errorf("got %q, want %q", m.currentCommand, want)
+	}
+	if lines := strings.Join(explainFlags(m.currentCommand), "\n"); !strings.Contains(lines, "Until the condition Available is met") {
+		t.Errorf("expected the condition to be explained, got:\n%s", lines)
+	}
+}
+
+// Test that a Wait without a condition is refused rather than built.
+func TestBuildCommandWaitRequiresCondition(t *testing.T) {
+	if cmd, err := buildCommand(ResourcePods, ActionWait, "web", []string{"--timeout=30s"}); err == nil {
+		t.Errorf("expected an error, got %q", cmd)
+	}
+	cmd, err := buildCommand(ResourcePods, ActionWait, "prod/web", []string{"--for=condition=Ready"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "kubectl wait --for=condition=Ready pod/web -n prod"; cmd != want {
+		t.Errorf("got %q, want %q", cmd, want)
+	}
+}
+
+// Test that only conditions a resource reports are offered for it.
+func TestWaitConditionsPerResource(t *testing.T) {
+	names := func(resource ResourceType) string {
+		var n []string
+		for _, c := range waitConditions(resource) {
+			n = append(n, c.name)
+		}
+		return strings.Join(n, ",")
+	}
+	tests := map[ResourceType]string{
+		ResourcePods:        "Ready,ContainersReady,PodScheduled",
+		ResourceDeployments: "Available,Progressing",
+		ResourceNodes:       "Ready",
+	}
+	for resource, want := range tests {
+		if got := names(resource); got != want {
+			t.Errorf("waitConditions(%v) = %s, want %s", resource, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchStatus shows whether a live screen is updating, e.g.
+// "▶ watching (10s)" or "⏸ paused". A zero interval is a stream.
+func watchStatus(paused bool, interval time.Duration) string {
+	switch {
+	case paused:
+		return "⏸ paused"
+	case interval > 0:
+		return fmt.Sprintf("▶ watching (%s)", interval)
+	}
+	return "▶ watching"
+}
+
+// toggleWatchPause pauses or resumes the live updates of Cluster Info or the
+// events stream, keeping what was shown so resuming carries on from there.
+func (m Model) toggleWatchPause() (tea.Model, tea.Cmd) {
+	switch m.currentScreen {
+	case ClusterInfoScreen:
+		if m.clusterInfo == nil {
+			return m, nil
+		}
+		m.nodeUsagePaused = !m.nodeUsagePaused
+		// A new poll ID drops the pending tick, so pausing stops sampling
+		// and resuming samples straight away without a second loop
+		m.nodeUsagePollID++
+		if m.nodeUsagePaused {
+			return m, nil
+		}
+		return m, m.sampleNodeUsage(m.nodeUsagePollID)
+
+	case EventsStreamScreen:
+		m.eventsPaused = !m.eventsPaused
+		if !m.eventsPaused && len(m.heldEventLines) > 0 {
+			m = m.appendEventLines(m.heldEventLines)
+			m.heldEventLines = nil
+		}
+	}
+	return m, nil
+}
+
+// holdEventLines keeps lines received while the events stream is paused,
+// dropping the oldest beyond maxEventLines.
+func (m Model) holdEventLines(lines []string) Model {
+	m.heldEventLines = append(m.heldEventLines, lines...)
+	if over := len(m.heldEventLines) - maxEventLines; over > 0 {
+		m.heldEventLines = m.heldEventLines[over:]
+	}
+	return m
+}
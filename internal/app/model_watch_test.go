@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressSpace(m Model) (Model, tea.Cmd) {
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	return updated.(Model), cmd
+}
+
+// Test that Space pauses node usage sampling on Cluster Info, dropping the
+// pending tick, and that resuming samples again right away.
+func TestPauseNodeUsageSampling(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, viewport: ui.NewViewport(80, 30),
+		currentScreen: ClusterInfoScreen, clusterInfo: &kubectl.ClusterInfo{}, nodeUsagePollID: 1}
+	if !strings.Contains(m.View(), "▶ watching (10s)") {
+		t.Errorf("expected the watching status, got:\n%s", m.View())
+	}
+
+	m, cmd := pressSpace(m)
+	if !m.nodeUsagePaused || cmd != nil {
+		t.Fatalf("expected sampling to pause")
+	}
+	if !strings.Contains(m.View(), "⏸ paused") {
+		t.Errorf("expected the paused status, got:\n%s", m.View())
+	}
+	if _, cmd := m.Update(nodeUsagePollMsg{id: 1}); cmd != nil {
+		t.Errorf("expected the pending tick to be dropped while paused")
+	}
+
+	if m, cmd = pressSpace(m); m.nodeUsagePaused || cmd == nil {
+		t.Errorf("expected resuming to sample again")
+	}
+}
+
+// Test that events received while the stream is paused are held back and
+// shown on resume.
+func TestPauseEventsStream(t *testing.T) {
+	source := make(chan string)
+	m := Model{keys: defaultKeyMap(), width: 80, height: 40, ready: true, viewport: ui.NewViewport(80, 30),
+		currentScreen: EventsStreamScreen, eventsSource: source, eventLines: []string{"first"}}
+
+	m, _ = pressSpace(m)
+	updated, cmd := m.Update(eventLinesMsg{source: source, lines: []string{"second", "third"}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Errorf("expected the stream to keep being read while paused")
+	}
+	if len(m.eventLines) != 1 || !strings.Contains(m.View(), "⏸ paused (2 new events held)") {
+		t.Errorf("expected the new events to be held, got %v and:\n%s", m.eventLines, m.View())
+	}
+
+	m, _ = pressSpace(m)
+	if len(m.eventLines) != 3 || len(m.heldEventLines) != 0 || !strings.Contains(m.View(), "▶ watching") {
+		t.Errorf("expected the held events to be shown on resume, got %v", m.eventLines)
+	}
+}
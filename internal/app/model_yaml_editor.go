@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// applyStdinCommand applies the manifest piped to it; the YAML editor runs it
+// with the edited YAML as stdin.
+const applyStdinCommand = "kubectl apply -f -"
+
+// yamlEditCommand returns the command that fetches the YAML of the selected
+// resource for editing.
+func (m Model) yamlEditCommand() (string, error) {
+	cmd, err := buildCommand(m.selectedResource, ActionViewYAML, m.selectedResourceName, nil)
+	if err != nil {
+		return "", err
+	}
+	return cmd + m.fieldNamespaceFlag(""), nil
+}
+
+func (m Model) loadYAMLForEdit() tea.Cmd {
+	cmd, err := m.yamlEditCommand()
+	if err != nil {
+		return func() tea.Msg { return yamlForEditLoadedMsg{err: err} }
+	}
+	return withSpinner("Fetching YAML to edit…", func() tea.Msg {
+		result, err := m.kubectlClient.ExecuteRaw(cmd)
+		if err == nil && result.Error != "" {
+			err = fmt.Errorf("kubectl error: %s", strings.TrimSpace(result.Error))
+		}
+		return yamlForEditLoadedMsg{yaml: result.Output, err: err}
+	})
+}
+
+// navigateToYAMLEditor opens the editor on the fetched YAML.
+func (m Model) navigateToYAMLEditor(yaml string) Model {
+	m.yamlEditor = textarea.New()
+	m.yamlEditor.CharLimit = 0
+	m.yamlEditor.MaxHeight = 0
+	m.yamlEditor.SetWidth(m.width)
+	m.yamlEditor.SetHeight(m.viewportHeight())
+	m.yamlEditor.SetValue(yaml)
+	m.yamlEditor.Focus()
+	// SetValue leaves the cursor at the end of the text
+	m.yamlEditor, _ = m.yamlEditor.Update(tea.KeyMsg{Type: tea.KeyCtrlHome})
+	m.yamlEditorOriginal = yaml
+	m.yamlEditorWarned = false
+	m.previousScreen = m.currentScreen
+	m.currentScreen = YAMLEditorScreen
+	return m
+}
+
+// returnToYAMLEditor goes back to the editor with its edits after applying
+// them failed, or when leaving the apply's preview.
+func (m Model) returnToYAMLEditor() Model {
+	m.yamlEditor.Focus()
+	m.previousScreen = m.currentScreen
+	m.currentScreen = YAMLEditorScreen
+	return m
+}
+
+// fromYAMLEditor reports whether the current command applies the editor's YAML.
+func (m Model) fromYAMLEditor() bool {
+	return m.commandStdin != "" && m.currentCommand == m.stdinCommand
+}
+
+// handleYAMLEditorKey applies the YAML, leaves the editor or passes the key
+// on to the text area. Leaving with unapplied edits has to be confirmed by
+// pressing Esc a second time.
+func (m Model) handleYAMLEditorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Cancel) && m.cancelCommand != nil:
+		m.cancelCommand()
+		m.cancelCommand = nil
+		m.err = fmt.Errorf("Cancelling command...")
+		return m, nil
+
+	case m.loading:
+		// Edits made while the apply runs would not be applied
+		return m, nil
+
+	case key.Matches(msg, m.keys.Apply):
+		return m.applyEditedYAML()
+
+	case key.Matches(msg, m.keys.Back):
+		if m.yamlEditor.Value() != m.yamlEditorOriginal && !m.yamlEditorWarned {
+			m.yamlEditorWarned = true
+			m.err = fmt.Errorf("The YAML has unapplied edits: press Esc again to discard them")
+			return m, nil
+		}
+		m.err = nil
+		m.commandStdin = ""
+		m.yamlEditor.Blur()
+		return m.navigateToActionSelection(), nil
+	}
+
+	m.yamlEditorWarned = false
+	var cmd tea.Cmd
+	m.yamlEditor, cmd = m.yamlEditor.Update(msg)
+	return m, cmd
+}
+
+// applyEditedYAML runs "kubectl apply -f -" with the edited YAML as stdin.
+func (m Model) applyEditedYAML() (tea.Model, tea.Cmd) {
+	edited := m.yamlEditor.Value()
+	if strings.TrimSpace(edited) == "" {
+		m.err = fmt.Errorf("The YAML is empty: nothing to apply")
+		return m, nil
+	}
+	if edited == m.yamlEditorOriginal {
+		m.err = fmt.Errorf("No changes to apply")
+		return m, nil
+	}
+
+	m.err = nil
+	m.yamlEditor.Blur()
+	m.currentCommand = applyStdinCommand
+	m.commandStdin = edited
+	m.stdinCommand = applyStdinCommand
+	return m, m.executeCommand()
+}
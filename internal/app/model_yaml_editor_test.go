@@ -0,0 +1,128 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test that edits are applied with the YAML as stdin, and that a failed apply
+// returns to the editor with the edits kept.
+func TestYAMLEditorAppliesEdits(t *testing.T) {
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		viewport: ui.NewViewport(80, 30), selectedResource: ResourceDeployments, selectedAction: ActionEditYAML,
+		selectedResourceName: "web"}
+	if cmd, _ := m.yamlEditCommand(); cmd != "kubectl get deployment web -o yaml" {
+		t.Errorf("yamlEditCommand() = %q", cmd)
+	}
+
+	original := "kind: Deployment\nspec:\n  replicas: 1\n"
+	updated, _ := m.Update(yamlForEditLoadedMsg{yaml: original})
+	m = updated.(Model)
+	if m.currentScreen != YAMLEditorScreen {
+		t.Fatalf("expected the YAML editor, got %s", m.currentScreen)
+	}
+
+	// Nothing was edited yet
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m = updated.(Model); cmd != nil || m.err == nil || m.err.Error() != "No changes to apply" {
+		t.Fatalf("expected nothing to apply, got %v", m.err)
+	}
+
+	// Enter and 'q' are typed into the YAML rather than selecting or quitting
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("# q")}, {Type: tea.KeyEnter}} {
+		updated, _ = m.Update(k)
+		m = updated.(Model)
+	}
+	if !strings.HasPrefix(m.yamlEditor.Value(), "# q\nkind: Deployment") {
+		t.Fatalf("expected the typed text at the top, got %q", m.yamlEditor.Value())
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if cmd == nil || m.currentCommand != applyStdinCommand || m.commandStdin != m.yamlEditor.Value() {
+		t.Fatalf("expected %q with the edited YAML as stdin, got %q", applyStdinCommand, m.currentCommand)
+	}
+
+	updated, _ = m.Update(commandExecutedMsg{result: kubectl.CommandResult{Error: "error: unable to decode \"STDIN\""}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.currentScreen != YAMLEditorScreen || !strings.HasPrefix(m.yamlEditor.Value(), "# q\n") {
+		t.Fatalf("expected Esc to return to the edited YAML, got %s", m.currentScreen)
+	}
+
+	// Leaving with unapplied edits has to be confirmed
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentScreen != YAMLEditorScreen || m.err == nil {
+		t.Fatalf("expected a warning about unapplied edits, got %s", m.currentScreen)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.currentScreen != ActionSelectionScreen || m.commandStdin != "" {
+		t.Errorf("expected the second Esc to discard the edits, got %s", m.currentScreen)
+	}
+}
+
+// sequenceCmds returns the commands of a tea.Sequence, to be run in order.
+func sequenceCmds(t *testing.T, cmd tea.Cmd) []tea.Cmd {
+	t.Helper()
+	v := reflect.ValueOf(cmd())
+	if v.Kind() != reflect.Slice {
+		t.Fatalf("expected a sequence, got %T", v.Interface())
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i] = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds
+}
+
+// Test that the edited YAML still reaches kubectl once strict namespace mode
+// has added -n to the apply.
+func TestYAMLEditorAppliesEditsInStrictNamespace(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$*\" in\n" +
+		"  *current-context*) echo dev ;;\n" +
+		"  'apply -f - -n team') while IFS= read -r line; do echo \"$line\"; done ;;\n" +
+		"  *) echo \"unexpected: $*\" >&2; exit 1 ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	m := Model{keys: defaultKeyMap(), textInput: textinput.New(), width: 80, height: 40, ready: true,
+		viewport: ui.NewViewport(80, 30), kubectlClient: kubectl.NewClient(),
+		strictNamespace: true, defaultNamespace: "team"}
+	updated, _ := m.Update(yamlForEditLoadedMsg{yaml: "kind: ConfigMap\n"})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected the edits to be applied")
+	}
+
+	cmds := sequenceCmds(t, cmd)
+	updated, _ = m.Update(cmds[0]())
+	m = updated.(Model)
+	if m.currentCommand != "kubectl apply -f - -n team" || !m.fromYAMLEditor() {
+		t.Fatalf("expected the apply to be pinned to team with the edits, got %q", m.currentCommand)
+	}
+	msg, ok := cmds[1]().(commandExecutedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected the apply to run, got %#v", msg)
+	}
+	if msg.result.Output != "#kind: ConfigMap\n" {
+		t.Errorf("expected the edited YAML on kubectl's stdin, got %q", msg.result.Output)
+	}
+}
@@ -1,5 +1,10 @@
 package app
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Theme represents the color theme for the application
 type Theme int
 
@@ -69,14 +74,78 @@ const (
 	NamespacesListScreen
 	// CustomCommandScreen lets users build an arbitrary kubectl command
 	CustomCommandScreen
-	// SecretFieldSelectionScreen allows selecting a field from a secret
-	SecretFieldSelectionScreen
+	// FieldSelectionScreen allows selecting a field of a resource to extract
+	FieldSelectionScreen
 	// ClusterInfoScreen displays cluster information and metrics
 	ClusterInfoScreen
 	// DeleteConfirmationScreen asks for confirmation before deleting a resource
 	DeleteConfirmationScreen
 	// PortInputScreen allows entering ports for port-forwarding
 	PortInputScreen
+	// DeleteSavedOutputConfirmationScreen asks for confirmation before deleting all versions of a saved output
+	DeleteSavedOutputConfirmationScreen
+	// DryRunScreen shows the result of a dry run of the previewed command
+	DryRunScreen
+	// KubeconfigInputScreen allows entering a kubeconfig file path
+	KubeconfigInputScreen
+	// LogViewerScreen shows the tail of the application log file
+	LogViewerScreen
+	// CustomColumnsScreen picks fields for a -o custom-columns flag
+	CustomColumnsScreen
+	// EventsStreamScreen shows a live feed of cluster events
+	EventsStreamScreen
+	// TemplateInputScreen prompts for the placeholders of a command template
+	TemplateInputScreen
+	// PluginsListScreen lists the installed kubectl plugins
+	PluginsListScreen
+	// PluginArgsScreen asks for the arguments to run a plugin with
+	PluginArgsScreen
+	// CreateNamespaceScreen asks for the name of a namespace to create
+	CreateNamespaceScreen
+	// DeleteNamespaceSelectionScreen picks the namespace to delete
+	DeleteNamespaceSelectionScreen
+	// DeleteNamespaceConfirmationScreen asks for confirmation before deleting a namespace
+	DeleteNamespaceConfirmationScreen
+	// ContextFlagSelectionScreen picks the kube context for a --context flag
+	ContextFlagSelectionScreen
+	// OwnersScreen shows the chain of owners of a pod
+	OwnersScreen
+	// GrepPatternInputScreen asks for the pattern to filter command output with
+	GrepPatternInputScreen
+	// PodDebugScreen shows a pod's get output above its events
+	PodDebugScreen
+	// PinnedOutputsListScreen lists the outputs pinned this session
+	PinnedOutputsListScreen
+	// PinnedOutputViewScreen shows a pinned output
+	PinnedOutputViewScreen
+	// ErrorScreen shows a failed command's error with a suggested fix
+	ErrorScreen
+	// JSONPathInputScreen asks for the expression of the -o jsonpath flag
+	JSONPathInputScreen
+	// ContextConfirmationScreen asks for a protected context's name to be typed
+	ContextConfirmationScreen
+	// PodQuickActionsScreen lists a pod's IP, node and images to copy
+	PodQuickActionsScreen
+	// YAMLEditorScreen edits a resource's YAML in a text area before applying it
+	YAMLEditorScreen
+	// PortForwardsScreen lists saved port-forwards to start and stop in the background
+	PortForwardsScreen
+	// AppendArgsScreen asks for extra args to add to the previewed command
+	AppendArgsScreen
+	// RecentContextsScreen lists the last contexts used for a quick switch
+	RecentContextsScreen
+	// WaitConditionScreen picks the condition a Wait blocks on
+	WaitConditionScreen
+	// SettingsScreen lists the configurable options to toggle or edit
+	SettingsScreen
+	// SettingsInputScreen asks for a new value for a setting
+	SettingsInputScreen
+	// ContainerSelectionScreen picks the container of a multi-container pod to show logs of
+	ContainerSelectionScreen
+	// DeploymentPodsScreen lists the pods of a deployment to pick one to act on
+	DeploymentPodsScreen
+	// UploadConfirmationScreen asks before an output is sent to the paste service
+	UploadConfirmationScreen
 )
 
 // ResourceType represents the type of Kubernetes resource
@@ -105,6 +174,28 @@ const (
 	ActionExec
 	ActionPortForward
 	ActionTop
+	// ActionRestart deletes a pod so that its controller recreates it
+	ActionRestart
+	// ActionCordon marks a node as unschedulable
+	ActionCordon
+	// ActionUncordon marks a node as schedulable again
+	ActionUncordon
+	// ActionDrain evicts all pods from a node for maintenance
+	ActionDrain
+	// ActionViewYAML shows a resource's YAML without opening an editor
+	ActionViewYAML
+	// ActionOwners follows a pod's owner references up to its top-level controller
+	ActionOwners
+	// ActionDebug shows a pod's status and events in one view
+	ActionDebug
+	// ActionQuickActions shows a pod's IP, node and images ready to copy
+	ActionQuickActions
+	// ActionEditYAML edits a resource's YAML in the wizard and applies it
+	ActionEditYAML
+	// ActionWait blocks until a resource reports a condition
+	ActionWait
+	// ActionShowPods lists the pods a deployment manages, to act on one of them
+	ActionShowPods
 )
 
 // String returns the string representation of a ResourceType
@@ -150,6 +241,28 @@ func (a Action) String() string {
 		return "Port Forward"
 	case ActionTop:
 		return "Top (Metrics)"
+	case ActionRestart:
+		return "Restart"
+	case ActionCordon:
+		return "Cordon"
+	case ActionUncordon:
+		return "Uncordon"
+	case ActionDrain:
+		return "Drain"
+	case ActionViewYAML:
+		return "View YAML"
+	case ActionOwners:
+		return "Owners"
+	case ActionDebug:
+		return "Debug"
+	case ActionQuickActions:
+		return "Quick Actions"
+	case ActionEditYAML:
+		return "Edit YAML"
+	case ActionWait:
+		return "Wait"
+	case ActionShowPods:
+		return "Show Pods"
 	default:
 		return "Unknown"
 	}
@@ -208,22 +321,112 @@ func (s Screen) String() string {
 		return "Namespaces List"
 	case CustomCommandScreen:
 		return "Custom Command"
-	case SecretFieldSelectionScreen:
-		return "Secret Field Selection"
+	case FieldSelectionScreen:
+		return "Field Selection"
 	case ClusterInfoScreen:
 		return "Cluster Info"
 	case DeleteConfirmationScreen:
 		return "Delete Confirmation"
 	case PortInputScreen:
 		return "Port Input"
+	case DeleteSavedOutputConfirmationScreen:
+		return "Delete Saved Output Confirmation"
+	case DryRunScreen:
+		return "Dry Run"
+	case KubeconfigInputScreen:
+		return "Kubeconfig Input"
+	case LogViewerScreen:
+		return "Log Viewer"
+	case CustomColumnsScreen:
+		return "Custom Columns"
+	case EventsStreamScreen:
+		return "Events Stream"
+	case TemplateInputScreen:
+		return "Template Input"
+	case PluginsListScreen:
+		return "Plugins List"
+	case PluginArgsScreen:
+		return "Plugin Arguments"
+	case CreateNamespaceScreen:
+		return "Create Namespace"
+	case DeleteNamespaceSelectionScreen:
+		return "Delete Namespace Selection"
+	case DeleteNamespaceConfirmationScreen:
+		return "Delete Namespace Confirmation"
+	case ContextFlagSelectionScreen:
+		return "Context Flag Selection"
+	case OwnersScreen:
+		return "Owners"
+	case GrepPatternInputScreen:
+		return "Grep Pattern Input"
+	case PodDebugScreen:
+		return "Pod Debug"
+	case PinnedOutputsListScreen:
+		return "Pinned Outputs List"
+	case PinnedOutputViewScreen:
+		return "Pinned Output View"
+	case ErrorScreen:
+		return "Error"
+	case JSONPathInputScreen:
+		return "JSONPath Input"
+	case ContextConfirmationScreen:
+		return "Context Confirmation"
+	case PodQuickActionsScreen:
+		return "Pod Quick Actions"
+	case YAMLEditorScreen:
+		return "YAML Editor"
+	case PortForwardsScreen:
+		return "Port Forwards"
+	case AppendArgsScreen:
+		return "Append Args"
+	case RecentContextsScreen:
+		return "Recent Contexts"
+	case WaitConditionScreen:
+		return "Wait Condition"
+	case SettingsScreen:
+		return "Settings"
+	case SettingsInputScreen:
+		return "Settings Input"
+	case ContainerSelectionScreen:
+		return "Container Selection"
+	case DeploymentPodsScreen:
+		return "Deployment Pods"
+	case UploadConfirmationScreen:
+		return "Upload Confirmation"
 	default:
 		return "Unknown"
 	}
 }
 
-// buildCommand constructs the kubectl command string based on selections
-func buildCommand(resource ResourceType, action Action, resourceName string, flags []string) string {
+// requiresResourceName reports whether the action operates on a single named resource
+func (a Action) requiresResourceName() bool {
+	switch a {
+	case ActionDescribe, ActionLogs, ActionExtractField, ActionEdit, ActionDelete, ActionExec, ActionPortForward, ActionRestart,
+		ActionCordon, ActionUncordon, ActionDrain, ActionViewYAML, ActionOwners,
+		ActionDebug, ActionQuickActions, ActionEditYAML, ActionWait, ActionShowPods:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildCommand assembles the kubectl command for the wizard selections. It
+// returns an error instead of an incomplete command when the action needs a
+// resource name and none was selected.
+func buildCommand(resource ResourceType, action Action, resourceName string, flags []string) (string, error) {
+	if action.requiresResourceName() && strings.TrimSpace(resourceName) == "" {
+		return "", fmt.Errorf("%s requires a %s name: select one from the list first",
+			action.String(), strings.ToLower(strings.TrimSuffix(resource.String(), "s")))
+	}
+
+	// Names from the all-namespaces listing are "namespace/name"
+	namespace, resourceName, err := splitNamespacedNames(resourceName)
+	if err != nil {
+		return "", err
+	}
+
 	cmd := "kubectl "
+	var waitFor string
 
 	switch action {
 	case ActionGet:
@@ -274,14 +477,27 @@ func buildCommand(resource ResourceType, action Action, resourceName string, fla
 			cmd += "logs " + resourceName
 		}
 	case ActionExtractField:
-		// This is partially handled in handleSecretFieldSelection, but for consistency:
+		// This is partially handled in handleFieldSelection, but for consistency:
 		if resource == ResourceSecrets {
 			cmd += "get secret " + resourceName + " -o go-template='{{range $k, $v := .data}}{{$k}}: {{$v | base64decode}}{{\"\\n\"}}{{end}}'"
+		} else {
+			cmd += "get " + getResourceShortName(resource) + " " + resourceName + " -o json"
 		}
 	case ActionEdit:
 		cmd += "edit " + getResourceShortName(resource) + " " + resourceName
+	case ActionViewYAML:
+		cmd += "get " + getResourceShortName(resource) + " " + resourceName + " -o yaml"
 	case ActionDelete:
 		cmd += "delete " + getResourceShortName(resource) + " " + resourceName
+	case ActionRestart:
+		// There is no "kubectl restart pod"; deleting it makes its controller recreate it
+		cmd += "delete pod " + resourceName
+	case ActionCordon:
+		cmd += "cordon " + resourceName
+	case ActionUncordon:
+		cmd += "uncordon " + resourceName
+	case ActionDrain:
+		cmd += "drain " + resourceName
 	case ActionExec:
 		if resource == ResourcePods {
 			cmd += "exec -it " + resourceName + " -- /bin/sh"
@@ -296,6 +512,13 @@ func buildCommand(resource ResourceType, action Action, resourceName string, fla
 		} else if resource == ResourceDeployments {
 			cmd += "port-forward deployment/" + resourceName
 		}
+	case ActionWait:
+		// The --for flag is passed with the others but belongs before the resource
+		waitFor = waitForFlag(flags)
+		if waitFor == "" {
+			return "", fmt.Errorf("Wait requires a condition: choose one first")
+		}
+		cmd += "wait " + waitFor + " " + getResourceShortName(resource) + "/" + resourceName
 	case ActionTop:
 		if resource == ResourcePods {
 			cmd += "top pod"
@@ -306,16 +529,97 @@ func buildCommand(resource ResourceType, action Action, resourceName string, fla
 		}
 	}
 
+	var extra string
+	if namespace != "" {
+		extra += " -n " + namespace
+	}
+
 	// Append flags if any
+	for _, flag := range flags {
+		if flag != "" && flag != waitFor {
+			extra += " " + flag
+		}
+	}
+
+	// Arguments after "--" belong to the exec'd program, not to kubectl
+	if i := strings.Index(cmd, " -- "); i >= 0 {
+		return cmd[:i] + extra + cmd[i:], nil
+	}
+	return cmd + extra, nil
+}
+
+// buildGetCommand builds a get for several resource kinds at once, e.g.
+// "kubectl get pods,services -o wide".
+func buildGetCommand(resources []ResourceType, flags []string) string {
+	kinds := make([]string, 0, len(resources))
+	for _, r := range resources {
+		kinds = append(kinds, strings.ToLower(r.String()))
+	}
+
+	cmd := "kubectl get " + strings.Join(kinds, ",")
 	for _, flag := range flags {
 		if flag != "" {
 			cmd += " " + flag
 		}
 	}
-
 	return cmd
 }
 
+// parseResourceType maps a resource list title such as "ConfigMaps" to its
+// ResourceType.
+func parseResourceType(title string) (ResourceType, bool) {
+	switch title {
+	case "Pods":
+		return ResourcePods, true
+	case "Deployments":
+		return ResourceDeployments, true
+	case "Services":
+		return ResourceServices, true
+	case "Nodes":
+		return ResourceNodes, true
+	case "ConfigMaps":
+		return ResourceConfigMaps, true
+	case "Secrets":
+		return ResourceSecrets, true
+	case "Ingress":
+		return ResourceIngress, true
+	}
+	return 0, false
+}
+
+// splitNamespacedName splits a "namespace/name" entry. Plain names are
+// returned with an empty namespace.
+func splitNamespacedName(entry string) (string, string) {
+	if i := strings.Index(entry, "/"); i >= 0 {
+		return entry[:i], entry[i+1:]
+	}
+	return "", entry
+}
+
+// splitNamespacedNames splits space-separated "namespace/name" entries into
+// their shared namespace and the bare names. Entries from different
+// namespaces cannot go into one command.
+func splitNamespacedNames(entries string) (string, string, error) {
+	fields := strings.Fields(entries)
+	if len(fields) == 0 {
+		return "", entries, nil
+	}
+
+	namespace, _ := splitNamespacedName(fields[0])
+	names := make([]string, 0, len(fields))
+	for _, entry := range fields {
+		ns, name := splitNamespacedName(entry)
+		if ns != namespace {
+			return "", "", fmt.Errorf("selected resources are in different namespaces (%s, %s): pick them one namespace at a time", namespace, ns)
+		}
+		names = append(names, name)
+	}
+	if namespace == "" {
+		return "", entries, nil
+	}
+	return namespace, strings.Join(names, " "), nil
+}
+
 func getResourceShortName(r ResourceType) string {
 	switch r {
 	case ResourcePods:
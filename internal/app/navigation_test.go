@@ -0,0 +1,155 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test that actions which operate on a named resource refuse to build a
+// command without a name instead of producing e.g. "kubectl describe pod ".
+func TestBuildCommandRejectsEmptyResourceName(t *testing.T) {
+	tests := []struct {
+		action   Action
+		resource ResourceType
+	}{
+		{ActionDescribe, ResourcePods},
+		{ActionLogs, ResourcePods},
+		{ActionExtractField, ResourceSecrets},
+		{ActionEdit, ResourceDeployments},
+		{ActionDelete, ResourceServices},
+		{ActionExec, ResourcePods},
+		{ActionPortForward, ResourceServices},
+		{ActionRestart, ResourcePods},
+		{ActionCordon, ResourceNodes},
+		{ActionUncordon, ResourceNodes},
+		{ActionDrain, ResourceNodes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action.String(), func(t *testing.T) {
+			for _, name := range []string{"", "   "} {
+				cmd, err := buildCommand(tt.resource, tt.action, name, nil)
+				if err == nil {
+					t.Fatalf("expected error for empty name, got command %q", cmd)
+				}
+				if !strings.Contains(err.Error(), tt.action.String()) {
+					t.Errorf("error %q should mention the action", err)
+				}
+			}
+
+			cmd, err := buildCommand(tt.resource, tt.action, "web", nil)
+			if err != nil {
+				t.Fatalf("unexpected error with a name: %v", err)
+			}
+			if !strings.Contains(cmd, "web") {
+				t.Errorf("command %q should contain the resource name", cmd)
+			}
+		})
+	}
+}
+
+// Test that actions working on the whole resource type do not need a name.
+func TestBuildCommandAllowsNamelessActions(t *testing.T) {
+	tests := []struct {
+		action   Action
+		resource ResourceType
+		want     string
+	}{
+		{ActionGet, ResourcePods, "kubectl get pods --no-headers"},
+		{ActionTop, ResourceNodes, "kubectl top node --no-headers"},
+	}
+
+	for _, tt := range tests {
+		cmd, err := buildCommand(tt.resource, tt.action, "", []string{"--no-headers"})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.action, err)
+		}
+		if cmd != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.action, cmd, tt.want)
+		}
+	}
+}
+
+// Test that node maintenance actions build the matching kubectl verbs.
+func TestBuildCommandNodeMaintenance(t *testing.T) {
+	tests := []struct {
+		action Action
+		flags  []string
+		want   string
+	}{
+		{ActionCordon, nil, "kubectl cordon node-1"},
+		{ActionUncordon, nil, "kubectl uncordon node-1"},
+		{ActionDrain, []string{"--ignore-daemonsets", "--delete-emptydir-data"}, "kubectl drain node-1 --ignore-daemonsets --delete-emptydir-data"},
+	}
+
+	for _, tt := range tests {
+		cmd, err := buildCommand(ResourceNodes, tt.action, "node-1", tt.flags)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.action, err)
+		}
+		if cmd != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.action, cmd, tt.want)
+		}
+	}
+}
+
+func TestBuildCommandSplitsNamespacedNames(t *testing.T) {
+	tests := []struct {
+		resource ResourceType
+		action   Action
+		name     string
+		flags    []string
+		want     string
+	}{
+		{ResourcePods, ActionDescribe, "kube-system/coredns", nil, "kubectl describe pod coredns -n kube-system"},
+		{ResourceDeployments, ActionLogs, "web/frontend", []string{"--tail=100"}, "kubectl logs deployment/frontend -n web --tail=100"},
+		{ResourcePods, ActionDelete, "web/a web/b", nil, "kubectl delete pod a b -n web"},
+		{ResourcePods, ActionDescribe, "coredns", nil, "kubectl describe pod coredns"},
+	}
+
+	for _, tt := range tests {
+		got, err := buildCommand(tt.resource, tt.action, tt.name, tt.flags)
+		if err != nil {
+			t.Errorf("buildCommand(%q) returned error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("buildCommand(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildCommandRejectsMixedNamespaces(t *testing.T) {
+	if _, err := buildCommand(ResourcePods, ActionDelete, "web/a api/b", nil); err == nil {
+		t.Errorf("expected an error for names from different namespaces")
+	}
+}
+
+// Test that the namespace and flags of an exec command go to kubectl rather
+// than to the shell after "--".
+func TestBuildCommandKeepsExecProgramLast(t *testing.T) {
+	cmd, err := buildCommand(ResourcePods, ActionExec, "prod/web", []string{"-c app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "kubectl exec -it web -n prod -c app -- /bin/sh"; cmd != want {
+		t.Errorf("got %q, want %q", cmd, want)
+	}
+}
+
+// Test that 't' flips between the two themes and reports the new one.
+func TestToggleThemeSwitchesBetweenDarkAndLight(t *testing.T) {
+	m := Model{theme: ThemeDark}
+
+	m, _ = m.toggleTheme()
+	if m.theme != ThemeLight || m.err == nil || !strings.Contains(m.err.Error(), "Light") {
+		t.Errorf("after one toggle theme = %s, err = %v; want Light", m.theme, m.err)
+	}
+	m, _ = m.toggleTheme()
+	if m.theme != ThemeDark {
+		t.Errorf("after two toggles theme = %s, want Dark", m.theme)
+	}
+	if Theme(99).String() != "Unknown" {
+		t.Errorf("Theme(99).String() = %q, want Unknown", Theme(99).String())
+	}
+}
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// droppableColumns are hidden, in this order, when a table is wider than the
+// output. NAME and the status columns are never hidden.
+var droppableColumns = []string{
+	"READINESS GATES", "NOMINATED NODE", "KERNEL-VERSION", "CONTAINER-RUNTIME", "OS-IMAGE",
+	"LABELS", "SELECTOR", "IMAGES", "CONTAINERS", "EXTERNAL-IP", "INTERNAL-IP", "CLUSTER-IP",
+	"IP", "NODE", "PORT(S)", "ROLES", "VERSION", "AGE",
+}
+
+// outputTable is one table of "kubectl get" output.
+type outputTable struct {
+	headers []string
+	rows    [][]string
+}
+
+// isTableCommand reports whether cmd is a get whose output is kubectl's
+// default table, i.e. one without -o (or with -o wide) that is not watched.
+func isTableCommand(cmd string) bool {
+	if commandVerb(cmd) != "get" || isStreamingCommand(cmd) {
+		return false
+	}
+	fields := strings.Fields(cmd)
+	for i, f := range fields {
+		switch {
+		case f == "-o" || f == "--output":
+			if i+1 >= len(fields) || fields[i+1] != "wide" {
+				return false
+			}
+		case strings.HasPrefix(f, "-o") || strings.HasPrefix(f, "--output="):
+			if f != "-owide" && f != "-o=wide" && f != "--output=wide" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseTables splits get output into its tables. A get of several kinds
+// prints one table per kind, separated by blank lines. Columns are located
+// from the header, as kubectl aligns every cell under its header; false is
+// returned when the output does not line up that way.
+func parseTables(output string) ([]outputTable, bool) {
+	var tables []outputTable
+	for _, section := range strings.Split(strings.Trim(output, "\n"), "\n\n") {
+		t, ok := parseTable(strings.Split(section, "\n"))
+		if !ok {
+			return nil, false
+		}
+		tables = append(tables, t)
+	}
+	return tables, len(tables) > 0
+}
+
+func parseTable(lines []string) (outputTable, bool) {
+	if len(lines) < 2 || !isHeaderLine(lines[0]) {
+		return outputTable{}, false
+	}
+
+	header := []rune(lines[0])
+	var starts []int
+	for i, r := range header {
+		// A column starts after at least two spaces; single spaces separate
+		// the words of headers such as "NOMINATED NODE"
+		if r != ' ' && (i == 0 || (i >= 2 && header[i-1] == ' ' && header[i-2] == ' ')) {
+			starts = append(starts, i)
+		}
+	}
+
+	t := outputTable{headers: splitColumns(header, starts)}
+	for _, line := range lines[1:] {
+		row := []rune(line)
+		for _, s := range starts[1:] {
+			if s < len(row) && row[s-1] != ' ' {
+				return outputTable{}, false
+			}
+		}
+		t.rows = append(t.rows, splitColumns(row, starts))
+	}
+	return t, true
+}
+
+func splitColumns(line []rune, starts []int) []string {
+	cells := make([]string, len(starts))
+	for i, s := range starts {
+		if s >= len(line) {
+			continue
+		}
+		end := len(line)
+		if i+1 < len(starts) && starts[i+1] < end {
+			end = starts[i+1]
+		}
+		cells[i] = strings.TrimSpace(string(line[s:end]))
+	}
+	return cells
+}
+
+// isHeaderLine reports whether line looks like a kubectl column header,
+// e.g. "NAME   READY   STATUS".
+func isHeaderLine(line string) bool {
+	hasLetter := false
+	for _, r := range line {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+// renderTables draws parsed get output as bordered tables no wider than
+// width, hiding droppableColumns as needed. The hidden columns are listed
+// under the tables.
+func (m Model) renderTables(tables []outputTable, width int) string {
+	colors := GetThemeColors(m.theme)
+
+	var parts []string
+	var hidden []string
+	for _, t := range tables {
+		rendered, dropped := renderTable(t, width, colors)
+		parts = append(parts, rendered)
+		for _, d := range dropped {
+			if !containsString(hidden, d) {
+				hidden = append(hidden, d)
+			}
+		}
+	}
+
+	out := strings.Join(parts, "\n\n")
+	if len(hidden) > 0 {
+		out += "\n" + m.GetHelpStyle().Render("Hidden to fit the window: "+strings.Join(hidden, ", "))
+	}
+	return out
+}
+
+func renderTable(t outputTable, width int, colors ThemeColors) (string, []string) {
+	keep := make([]bool, len(t.headers))
+	for i := range keep {
+		keep[i] = true
+	}
+
+	var dropped []string
+	for {
+		rendered := buildTable(t, keep, colors)
+		if width <= 0 || lipgloss.Width(rendered) <= width {
+			return rendered, dropped
+		}
+		col := nextDroppableColumn(t.headers, keep)
+		if col < 0 {
+			return rendered, dropped
+		}
+		keep[col] = false
+		dropped = append(dropped, t.headers[col])
+	}
+}
+
+// nextDroppableColumn returns the index of the least important column still
+// shown, or -1 when none of the shown columns may be hidden.
+func nextDroppableColumn(headers []string, keep []bool) int {
+	for _, name := range droppableColumns {
+		for i, h := range headers {
+			if keep[i] && h == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func buildTable(t outputTable, keep []bool, colors ThemeColors) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	pick := func(cells []string) []string {
+		var out []string
+		for i, c := range cells {
+			if keep[i] {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	rows := make([][]string, 0, len(t.rows))
+	for _, r := range t.rows {
+		rows = append(rows, pick(r))
+	}
+
+	headers := pick(t.headers)
+	statusCol := -1
+	for i, h := range headers {
+		if h == "STATUS" {
+			statusCol = i
+		}
+	}
+
+	return table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(colors.Border)).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == 0 {
+				return headerStyle
+			}
+			if col == statusCol && row <= len(rows) {
+				if color, ok := statusColor(rows[row-1][col], colors); ok {
+					return cellStyle.Copy().Foreground(color)
+				}
+			}
+			return cellStyle
+		}).
+		String()
+}
+
+// statusColor picks the colour of a STATUS cell: red for failures such as
+// CrashLoopBackOff, yellow for transitional states such as Pending and
+// green for healthy ones such as Running. Other values are not coloured.
+func statusColor(status string, colors ThemeColors) (lipgloss.AdaptiveColor, bool) {
+	for _, bad := range []string{"Error", "BackOff", "Failed", "OOMKilled", "Evicted", "NotReady", "Unknown", "Lost"} {
+		if strings.Contains(status, bad) {
+			return colors.Error, true
+		}
+	}
+	switch {
+	case status == "Pending", status == "ContainerCreating", status == "PodInitializing", status == "Terminating",
+		strings.HasPrefix(status, "Init:"), strings.Contains(status, "SchedulingDisabled"):
+		return colors.Warning, true
+	case status == "Running", status == "Completed", status == "Succeeded", status == "Ready",
+		status == "Active", status == "Bound":
+		return colors.Success, true
+	}
+	return lipgloss.AdaptiveColor{}, false
+}
+
+// podStatusSummary counts the pods of a "kubectl get pods" table by STATUS,
+// e.g. "14 pods: 12 Running, 1 Pending, 1 CrashLoopBackOff", most common
+// first. It returns "" for other commands or output it cannot read.
+func podStatusSummary(cmd, output string) string {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(cmd), "kubectl "))
+	if !isTableCommand(cmd) || len(fields) < 2 || !containsString([]string{"po", "pod", "pods"}, fields[1]) {
+		return ""
+	}
+	tables, ok := parseTables(output)
+	if !ok || len(tables) != 1 {
+		return ""
+	}
+	status := -1
+	for i, h := range tables[0].headers {
+		if h == "STATUS" {
+			status = i
+		}
+	}
+	if status < 0 || len(tables[0].rows) == 0 {
+		return ""
+	}
+
+	counts := map[string]int{}
+	var order []string
+	for _, row := range tables[0].rows {
+		if counts[row[status]] == 0 {
+			order = append(order, row[status])
+		}
+		counts[row[status]]++
+	}
+	sort.SliceStable(order, func(i, j int) bool { return counts[order[i]] > counts[order[j]] })
+
+	parts := make([]string, len(order))
+	for i, s := range order {
+		parts[i] = fmt.Sprintf("%d %s", counts[s], s)
+	}
+	noun := "pods"
+	if len(tables[0].rows) == 1 {
+		noun = "pod"
+	}
+	return fmt.Sprintf("%d %s: %s", len(tables[0].rows), noun, strings.Join(parts, ", "))
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestIsTableCommand(t *testing.T) {
+	tests := map[string]bool{
+		"kubectl get pods":                true,
+		"kubectl get pods -A -o wide":     true,
+		"kubectl get pods --output=wide":  true,
+		"kubectl get pods -o yaml":        false,
+		"kubectl get pods -ojson":         false,
+		"kubectl get pods -w":             false,
+		"kubectl describe pod web":        false,
+		"kubectl get pods,services -n ns": true,
+	}
+	for cmd, want := range tests {
+		if got := isTableCommand(cmd); got != want {
+			t.Errorf("isTableCommand(%q) = %v, want %v", cmd, got, want)
+		}
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	output := "NAME    READY   STATUS    RESTARTS      NOMINATED NODE\n" +
+		"web-1   1/1     Running   2 (5m ago)    <none>\n" +
+		"db-0    0/1     Pending   0\n" +
+		"\n" +
+		"NAME         TYPE        CLUSTER-IP\n" +
+		"service/web  ClusterIP   10.0.0.1\n"
+
+	tables, ok := parseTables(output)
+	if !ok || len(tables) != 2 {
+		t.Fatalf("parseTables() = %v, %v; want two tables", tables, ok)
+	}
+	if want := []string{"NAME", "READY", "STATUS", "RESTARTS", "NOMINATED NODE"}; !reflect.DeepEqual(tables[0].headers, want) {
+		t.Errorf("headers = %q, want %q", tables[0].headers, want)
+	}
+	if want := []string{"web-1", "1/1", "Running", "2 (5m ago)", "<none>"}; !reflect.DeepEqual(tables[0].rows[0], want) {
+		t.Errorf("row 0 = %q, want %q", tables[0].rows[0], want)
+	}
+	if want := []string{"db-0", "0/1", "Pending", "0", ""}; !reflect.DeepEqual(tables[0].rows[1], want) {
+		t.Errorf("row 1 = %q, want %q", tables[0].rows[1], want)
+	}
+	if got := tables[1].rows[0][2]; got != "10.0.0.1" {
+		t.Errorf("second table cell = %q, want 10.0.0.1", got)
+	}
+
+	// Text running across a column boundary is left as raw output
+	if _, ok := parseTables("NAME   STATUS\nvery-long-name Running\n"); ok {
+		t.Error("expected misaligned output not to parse")
+	}
+	if _, ok := parseTables("pod/web-1\npod/db-0\n"); ok {
+		t.Error("expected output without a header not to parse")
+	}
+}
+
+func TestRenderTablesHidesColumnsOnNarrowWidths(t *testing.T) {
+	tables, ok := parseTables("NAME    STATUS    IP           NODE       AGE\n" +
+		"web-1   Running   10.1.2.3     worker-1   5d\n")
+	if !ok {
+		t.Fatal("expected the output to parse")
+	}
+
+	m := Model{}
+	wide := m.renderTables(tables, 120)
+	if !strings.Contains(wide, "worker-1") || strings.Contains(wide, "Hidden") {
+		t.Errorf("expected every column on a wide window, got:\n%s", wide)
+	}
+
+	narrow := m.renderTables(tables, 34)
+	for _, line := range strings.Split(narrow, "\n") {
+		if len([]rune(line)) > 34 {
+			t.Errorf("line wider than 34 columns: %q", line)
+		}
+	}
+	if strings.Contains(narrow, "10.1.2.3") || !strings.Contains(narrow, "web-1") || !strings.Contains(narrow, "Running") {
+		t.Errorf("expected IP to be hidden and NAME/STATUS kept, got:\n%s", narrow)
+	}
+	if !strings.Contains(narrow, "Hidden to fit the window: IP") {
+		t.Errorf("expected the hidden columns to be listed, got:\n%s", narrow)
+	}
+}
+
+func TestStatusColor(t *testing.T) {
+	colors := GetThemeColors(ThemeDark)
+	tests := map[string]lipgloss.AdaptiveColor{
+		"Running":                  colors.Success,
+		"Completed":                colors.Success,
+		"Ready":                    colors.Success,
+		"Pending":                  colors.Warning,
+		"ContainerCreating":        colors.Warning,
+		"Init:0/2":                 colors.Warning,
+		"Ready,SchedulingDisabled": colors.Warning,
+		"CrashLoopBackOff":         colors.Error,
+		"Error":                    colors.Error,
+		"Init:Error":               colors.Error,
+		"ImagePullBackOff":         colors.Error,
+		"NotReady":                 colors.Error,
+		"OOMKilled":                colors.Error,
+	}
+	for status, want := range tests {
+		got, ok := statusColor(status, colors)
+		if !ok || got != want {
+			t.Errorf("statusColor(%q) = %v, %v; want %v", status, got, ok, want)
+		}
+	}
+	if _, ok := statusColor("ClusterIP", colors); ok {
+		t.Error("expected values without a known state not to be coloured")
+	}
+}
+
+func TestPodStatusSummary(t *testing.T) {
+	output := "NAMESPACE   NAME    READY   STATUS             RESTARTS   AGE\n" +
+		"default     web-1   1/1     Running            0          1d\n" +
+		"default     web-2   0/1     CrashLoopBackOff   7          1d\n" +
+		"default     web-3   1/1     Running            0          1d\n" +
+		"kube-sys    dns     0/1     Pending            0          2m\n"
+	want := "4 pods: 2 Running, 1 CrashLoopBackOff, 1 Pending"
+	if got := podStatusSummary("kubectl get pods -A", output); got != want {
+		t.Errorf("podStatusSummary() = %q, want %q", got, want)
+	}
+
+	single := "NAME    READY   STATUS    RESTARTS   AGE\nweb-1   1/1     Running   0          1d\n"
+	if got := podStatusSummary("kubectl get po -n team", single); got != "1 pod: 1 Running" {
+		t.Errorf("podStatusSummary() for one pod = %q", got)
+	}
+
+	for _, cmd := range []string{"kubectl get deployments", "kubectl get pods -o yaml", "kubectl get pods,services"} {
+		if got := podStatusSummary(cmd, output); got != "" {
+			t.Errorf("podStatusSummary(%q) = %q, want no summary", cmd, got)
+		}
+	}
+	if got := podStatusSummary("kubectl get pods", "No resources found in default namespace.\n"); got != "" {
+		t.Errorf("expected no summary without pods, got %q", got)
+	}
+}
@@ -1,8 +1,11 @@
 package app
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/kubectl"
 )
 
 var (
@@ -29,13 +32,101 @@ func ValidateSafeName(name string) bool {
 	return safeNameRegex.MatchString(name)
 }
 
+// shellMetacharacters are common shell injection characters.
+var shellMetacharacters = []string{";", "|", "&", "`", "$", "(", ")", "<", ">", "\\"}
+
 // SanitizeInput removes any suspicious characters from user input strings.
 func SanitizeInput(input string) string {
-	// Remove common shell injection characters
-	badChars := []string{";", "|", "&", "`", "$", "(", ")", "<", ">", "\\"}
 	result := input
-	for _, char := range badChars {
+	for _, char := range shellMetacharacters {
 		result = strings.ReplaceAll(result, char, "")
 	}
 	return strings.TrimSpace(result)
 }
+
+// knownVerbs are the kubectl verbs a saved command is expected to start
+// with, on top of the verbs offered for completion and the dry-run verbs.
+var knownVerbs = map[string]bool{
+	"api-resources": true, "api-versions": true, "attach": true, "auth": true, "certificate": true,
+	"cluster-info": true, "config": true, "cp": true, "debug": true, "diff": true, "events": true,
+	"kustomize": true, "plugin": true, "proxy": true, "version": true, "wait": true,
+	"autoscale": true, "completion": true, "taint": true, "alpha": true,
+}
+
+// knownResourceTypes are resource types beyond those offered for completion.
+var knownResourceTypes = map[string]bool{
+	"all": true, "clusterrolebindings": true, "clusterroles": true, "endpoints": true, "ep": true,
+	"hpa": true, "horizontalpodautoscalers": true, "limitranges": true, "networkpolicies": true, "netpol": true,
+	"pv": true, "persistentvolumes": true, "poddisruptionbudgets": true, "pdb": true, "resourcequotas": true,
+	"quota": true, "rolebindings": true, "roles": true, "sa": true, "serviceaccounts": true,
+	"storageclasses": true, "sc": true, "customresourcedefinitions": true, "crd": true, "crds": true,
+	"leases": true, "lease": true, "certificatesigningrequests": true, "csr": true,
+	"replicationcontrollers": true, "rc": true, "ingressclasses": true, "ingressclass": true,
+	"priorityclasses": true, "pc": true, "runtimeclasses": true, "podtemplates": true,
+	"controllerrevisions": true, "endpointslices": true, "ev": true, "componentstatuses": true, "cs": true,
+	"csidrivers": true, "csinodes": true, "volumeattachments": true, "apiservices": true,
+	"mutatingwebhookconfigurations": true, "validatingwebhookconfigurations": true,
+	"role": true, "rolebinding": true, "clusterrole": true, "clusterrolebinding": true,
+	"serviceaccount": true, "networkpolicy": true, "storageclass": true, "persistentvolume": true,
+}
+
+// commandVocabulary holds the plugins on PATH and the resource types the
+// cluster serves, which commandProblem accepts on top of its own lists.
+type commandVocabulary struct {
+	plugins   []string
+	resources map[string]bool
+}
+
+// knowsVerb reports whether verb runs one of the plugins, e.g. "view-secret"
+// or "cert" for the "cert manager" plugin.
+func (v *commandVocabulary) knowsVerb(verb string) bool {
+	if v == nil {
+		return false
+	}
+	for _, plugin := range v.plugins {
+		if words := strings.Fields(plugin); len(words) > 0 && words[0] == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// knowsResource reports whether the cluster serves resource.
+func (v *commandVocabulary) knowsResource(resource string) bool {
+	return v != nil && v.resources[resource]
+}
+
+// commandProblem looks for obvious mistakes in a kubectl command without
+// running it: unbalanced quotes, an unknown verb, or an unknown resource type
+// after a verb that takes one. Flags before the verb are skipped. Types with a
+// dot, such as certificates.cert-manager.io, and {placeholders} are assumed
+// to be fine, as are the plugins and types in vocab when it is not nil.
+// It returns "" when nothing looks wrong.
+func commandProblem(cmd string, vocab *commandVocabulary) string {
+	if _, err := kubectl.SplitArgs(cmd); err != nil {
+		return err.Error()
+	}
+	args := commandArgs(cmd)
+	if len(args) == 0 {
+		return "the command is empty"
+	}
+
+	verb := args[0]
+	if !knownVerbs[verb] && !dryRunVerbs[verb] && !containsString(completionVerbs, verb) && !strings.Contains(verb, "{") && !vocab.knowsVerb(verb) {
+		return fmt.Sprintf("unknown verb %q", verb)
+	}
+	if !resourceTypeVerbs[verb] || len(args) < 2 || strings.HasPrefix(args[1], "-") {
+		return ""
+	}
+
+	for _, resource := range strings.Split(strings.SplitN(args[1], "/", 2)[0], ",") {
+		resource = strings.ToLower(resource)
+		if resource == "" || strings.Contains(resource, ".") || strings.Contains(resource, "{") {
+			continue
+		}
+		if !containsString(completionResources, canonicalResource(resource)) && !knownResourceTypes[resource] && !vocab.knowsResource(resource) {
+			return fmt.Sprintf("unknown resource type %q", resource)
+		}
+	}
+	return ""
+}
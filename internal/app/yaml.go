@@ -0,0 +1,43 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// yamlKeyRe matches a "key:" at the start of a YAML line, after indentation
+// and an optional list dash.
+var yamlKeyRe = regexp.MustCompile(`^(\s*(?:- )?)([^\s:#'"][^:#]*|"[^"]*"|'[^']*'):(\s|$)`)
+
+// isYAMLCommand reports whether cmd prints YAML, i.e. uses -o yaml.
+func isYAMLCommand(cmd string) bool {
+	fields := strings.Fields(cmd)
+	for i, f := range fields {
+		if f == "-oyaml" || f == "-o=yaml" || f == "--output=yaml" ||
+			((f == "-o" || f == "--output") && i+1 < len(fields) && fields[i+1] == "yaml") {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightYAML colours the keys, comments and document separators of YAML
+// output. Values are left as they are.
+func (m Model) highlightYAML(content string) string {
+	colors := GetThemeColors(m.theme)
+	keyStyle := lipgloss.NewStyle().Foreground(colors.Primary)
+	subtleStyle := lipgloss.NewStyle().Foreground(colors.Subtle)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			lines[i] = subtleStyle.Render(line)
+		} else if loc := yamlKeyRe.FindStringSubmatchIndex(line); loc != nil {
+			lines[i] = line[:loc[3]] + keyStyle.Render(line[loc[4]:loc[5]]) + line[loc[5]:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
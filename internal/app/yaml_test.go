@@ -0,0 +1,58 @@
+package app
+
+import "testing"
+
+func TestIsYAMLCommand(t *testing.T) {
+	tests := map[string]bool{
+		"kubectl get pod web -o yaml":       true,
+		"kubectl get pod web -o=yaml":       true,
+		"kubectl get pod web --output yaml": true,
+		"kubectl get pod web -o json":       false,
+		"kubectl describe pod yaml":         false,
+	}
+	for cmd, want := range tests {
+		if got := isYAMLCommand(cmd); got != want {
+			t.Errorf("isYAMLCommand(%q) = %v, want %v", cmd, got, want)
+		}
+	}
+}
+
+func TestYAMLKeyMatches(t *testing.T) {
+	tests := []struct {
+		line string
+		key  string
+	}{
+		{"apiVersion: v1", "apiVersion"},
+		{"  labels:", "labels"},
+		{"  - name: web", "name"},
+		{`    "app.kubernetes.io/name": web`, `"app.kubernetes.io/name"`},
+		{"    image: nginx:1.25", "image"},
+		{"  - nginx", ""},
+		{"# comment: not a key", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if match := yamlKeyRe.FindStringSubmatch(tt.line); match != nil {
+			got = match[2]
+		}
+		if got != tt.key {
+			t.Errorf("key of %q = %q, want %q", tt.line, got, tt.key)
+		}
+	}
+}
+
+// Test that View YAML runs the get straight away instead of offering flags.
+func TestViewYAMLRunsImmediately(t *testing.T) {
+	m := Model{selectedResource: ResourcePods, selectedAction: ActionViewYAML, defaultNamespace: "team", width: 80, height: 40}
+	updated, _ := m.Update(resourceNamesLoadedMsg{names: []string{"web"}})
+	m = updated.(Model)
+
+	updated, cmd := m.handleResourceNameSelection()
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected the command to run")
+	}
+	if want := "kubectl get pod web -o yaml -n team"; m.currentCommand != want {
+		t.Errorf("command = %q, want %q", m.currentCommand, want)
+	}
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/history"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/paste"
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/storage"
+)
+
+const configFileName = "kube-wizard-config.json"
+
+// DefaultMaxSavedVersions is the number of versions kept per saved output
+// when the config file does not specify a value.
+const DefaultMaxSavedVersions = 10
+
+// DefaultConnectivityRecheckSeconds is how often the main menu rechecks the
+// current context's cluster when the config file does not specify a value.
+const DefaultConnectivityRecheckSeconds = 30
+
+// DefaultHistorySize is how many commands are kept in history when the
+// config file does not specify a value, the history store's own default.
+const DefaultHistorySize = history.DefaultMaxEntries
+
+// Config holds user-configurable settings loaded from a JSON file.
+type Config struct {
+	// MaxSavedVersions caps how many versions of a saved output are kept.
+	// Zero disables pruning.
+	MaxSavedVersions int `json:"max_saved_versions"`
+
+	// Kubeconfig is passed to kubectl as --kubeconfig when set.
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Retries is how many times transient kubectl failures are retried.
+	// Zero keeps the default of no retries.
+	Retries int `json:"retries"`
+
+	// LogLevel is the minimum level written to the log file: debug, info
+	// or error. Empty means info.
+	LogLevel string `json:"log_level"`
+
+	// Keys overrides key bindings by action name, e.g. {"back": "ctrl+["}.
+	// Several keys for one action are separated by commas.
+	Keys map[string]string `json:"keys"`
+
+	// DefaultNamespace is the namespace commands run in at startup, as if
+	// chosen under Contexts & Namespaces. Empty uses the context's namespace.
+	DefaultNamespace string `json:"default_namespace"`
+
+	// ProtectedContexts are regular expressions matched against whole kube
+	// context names, e.g. ".*prod.*". Switching to or running commands in a
+	// matching context asks for its name to be typed first.
+	ProtectedContexts []string `json:"protected_contexts"`
+
+	// ReadOnly hides actions that change the cluster and refuses commands
+	// with a mutating verb, for demos or cautious users.
+	ReadOnly bool `json:"read_only"`
+
+	// CheckPermissions asks "kubectl auth can-i" which actions the current
+	// user may perform and marks the others in the action menu.
+	CheckPermissions bool `json:"check_permissions"`
+
+	// StrictNamespace pins every generated or typed command, except
+	// all-namespaces ones, to the default namespace.
+	StrictNamespace bool `json:"strict_namespace"`
+
+	// ExpandEnv lists the environment variables that $NAME or ${NAME} in
+	// typed commands and favourites are replaced with, e.g. ["NS"]. Other
+	// variables are left as written.
+	ExpandEnv []string `json:"expand_env"`
+
+	// DefaultGetOutput is the output format ticked for Get in the flags
+	// screen: wide, yaml or json. Empty ticks none.
+	DefaultGetOutput string `json:"default_get_output"`
+
+	// ConnectivityRecheckSeconds is how often the current context's cluster
+	// is checked again while the main menu is shown. Zero disables it.
+	ConnectivityRecheckSeconds int `json:"connectivity_recheck_seconds"`
+
+	// Theme is the color scheme at startup: dark or light. Empty means dark.
+	Theme string `json:"theme"`
+
+	// HistorySize is how many commands are kept in history, newest first.
+	HistorySize int `json:"history_size"`
+
+	// SkipConfirmations runs deletes, restarts and drains without asking
+	// first. Protected contexts still ask for their name.
+	SkipConfirmations bool `json:"skip_confirmations"`
+
+	// NoAltScreen draws the wizard in the terminal's normal screen instead
+	// of the alternate one, so the last view stays in the scrollback.
+	NoAltScreen bool `json:"no_alt_screen"`
+
+	// PasteURL is the endpoint outputs are uploaded to for sharing, with
+	// their text as the body of a POST. Empty disables uploads.
+	PasteURL string `json:"paste_url"`
+}
+
+// Default returns the configuration used when no config file is present.
+func Default() Config {
+	return Config{
+		MaxSavedVersions:           DefaultMaxSavedVersions,
+		ConnectivityRecheckSeconds: DefaultConnectivityRecheckSeconds,
+		HistorySize:                DefaultHistorySize,
+	}
+}
+
+// DefaultPath returns the config file location in the user's home directory.
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, configFileName), nil
+}
+
+// Load reads the config file at path, falling back to DefaultPath when path
+// is empty. A missing default config file is not an error; defaults are
+// returned instead. Fields absent from the file keep their default values.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		defaultPath, err := DefaultPath()
+		if err != nil {
+			return cfg, nil
+		}
+		path = defaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config from %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Default(), fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Validate reports the first setting that is out of range.
+func (c Config) Validate() error {
+	if c.MaxSavedVersions < 0 {
+		return fmt.Errorf("max_saved_versions must not be negative")
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+	if c.ConnectivityRecheckSeconds < 0 {
+		return fmt.Errorf("connectivity_recheck_seconds must not be negative")
+	}
+	switch c.DefaultGetOutput {
+	case "", "wide", "yaml", "json":
+	default:
+		return fmt.Errorf("default_get_output must be wide, yaml or json")
+	}
+	switch c.Theme {
+	case "", "dark", "light":
+	default:
+		return fmt.Errorf("theme must be dark or light")
+	}
+	if c.HistorySize < 1 {
+		return fmt.Errorf("history_size must be at least 1")
+	}
+	if c.PasteURL != "" && !paste.IsHTTPURL(c.PasteURL) {
+		return fmt.Errorf("paste_url must be an http or https URL")
+	}
+	for _, p := range c.ProtectedContexts {
+		if _, err := regexp.Compile("^(?:" + strings.TrimSpace(p) + ")$"); err != nil {
+			return fmt.Errorf("invalid protected_contexts pattern %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// Save writes cfg to the config file at path, falling back to DefaultPath
+// when path is empty. The previous file is kept as a backup.
+func Save(path string, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if path == "" {
+		defaultPath, err := DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+
+	// Create backup before saving
+	if err := storage.Backup(path); err != nil {
+		// Log error but continue saving
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := storage.WriteAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save config to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package diff compares text line by line.
+package diff
+
+import "strings"
+
+// Op says how a line differs between the old and the new text.
+type Op int
+
+const (
+	// Equal lines are in both texts.
+	Equal Op = iota
+	// Delete lines are only in the old text.
+	Delete
+	// Insert lines are only in the new text.
+	Insert
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// maxCells bounds the table used to match lines. Larger changes are shown
+// as the old block removed and the new one added.
+const maxCells = 4_000_000
+
+// Lines returns the line diff that turns before into after. Common leading and
+// trailing lines are matched first, the rest by longest common subsequence.
+func Lines(before, after string) []Line {
+	a, b := splitLines(before), splitLines(after)
+
+	var prefix, suffix []Line
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, Line{Equal, a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append([]Line{{Equal, a[len(a)-1]}}, suffix...)
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	lines := append(prefix, middle(a, b)...)
+	return append(lines, suffix...)
+}
+
+// middle diffs the lines between the common prefix and suffix.
+func middle(a, b []string) []Line {
+	var lines []Line
+	if len(a)*len(b) > maxCells {
+		for _, s := range a {
+			lines = append(lines, Line{Delete, s})
+		}
+		for _, s := range b {
+			lines = append(lines, Line{Insert, s})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Delete, a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Insert, b[j]})
+	}
+	return lines
+}
+
+// Stats counts the inserted and deleted lines of a diff.
+func Stats(lines []Line) (inserted, deleted int) {
+	for _, l := range lines {
+		switch l.Op {
+		case Insert:
+			inserted++
+		case Delete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
+
+// splitLines splits text into lines, ignoring a trailing newline.
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,39 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	before := "NAME READY\nweb-1 1/1\nweb-2 1/1\ndb-0 1/1\n"
+	after := "NAME READY\nweb-1 1/1\nweb-3 0/1\ndb-0 1/1\ncache-0 1/1\n"
+
+	want := []Line{
+		{Equal, "NAME READY"},
+		{Equal, "web-1 1/1"},
+		{Delete, "web-2 1/1"},
+		{Insert, "web-3 0/1"},
+		{Equal, "db-0 1/1"},
+		{Insert, "cache-0 1/1"},
+	}
+	got := Lines(before, after)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Lines() = %v, want %v", got, want)
+	}
+
+	if inserted, deleted := Stats(got); inserted != 2 || deleted != 1 {
+		t.Errorf("Stats() = %d, %d; want 2, 1", inserted, deleted)
+	}
+}
+
+func TestLinesIdenticalAndEmpty(t *testing.T) {
+	if inserted, deleted := Stats(Lines("a\nb", "a\nb\n")); inserted != 0 || deleted != 0 {
+		t.Errorf("expected no changes for identical text, got +%d -%d", inserted, deleted)
+	}
+
+	want := []Line{{Insert, "a"}}
+	if got := Lines("", "a"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines(\"\", \"a\") = %v, want %v", got, want)
+	}
+}
@@ -1,9 +1,37 @@
 package favourites
 
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultGroup is the group favourites without one are listed under
+const DefaultGroup = "General"
+
 // Favourite represents a saved kubectl command
 type Favourite struct {
 	Name    string `json:"name"`
 	Command string `json:"command"`
+	Group   string `json:"group,omitempty"` // Empty for favourites saved before groups existed
+}
+
+// GroupName returns the group the favourite is listed under
+func (f Favourite) GroupName() string {
+	if f.Group == "" {
+		return DefaultGroup
+	}
+	return f.Group
+}
+
+// Validate reports why a favourite loaded from disk cannot be used
+func (f Favourite) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return errors.New("name is empty")
+	}
+	if strings.TrimSpace(f.Command) == "" {
+		return errors.New("command is empty")
+	}
+	return nil
 }
 
 // NewFavourite creates a new favourite
@@ -2,6 +2,7 @@ package favourites
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/storage"
@@ -9,10 +10,16 @@ import (
 
 const favouritesFileName = "kube-wizard-favourites.json"
 
+// migrations upgrade older favourites files (see storage.Migration)
+var migrations = []storage.Migration{
+	storage.Unversioned, // 0 -> 1: the list moves into a versioned file
+}
+
 // Store manages persistence of favourites
 type Store struct {
 	filePath   string
 	favourites []Favourite
+	skipped    []string
 }
 
 // NewStore creates a new favourites store
@@ -40,14 +47,47 @@ func NewStore() (*Store, error) {
 	return store, nil
 }
 
-// Load reads favourites from disk
+// Load reads favourites from disk. Invalid entries are left out and listed
+// by Skipped rather than failing the whole load.
 func (s *Store) Load() error {
-	data, err := os.ReadFile(s.filePath)
-	if err != nil {
-		return err
+	return storage.Load(s.filePath, migrations, s.decode, s.Save)
+}
+
+// decode replaces the store's contents with the data of its file
+func (s *Store) decode(raw json.RawMessage) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return fmt.Errorf("%s: expected a list of favourites: %w", s.filePath, err)
 	}
 
-	return json.Unmarshal(data, &s.favourites)
+	s.favourites = []Favourite{}
+	s.skipped = nil
+	for i, raw := range entries {
+		var fav Favourite
+		err := json.Unmarshal(raw, &fav)
+		if err == nil {
+			err = fav.Validate()
+		}
+		if err != nil {
+			s.skipped = append(s.skipped, fmt.Sprintf("%s: favourite %d %s: %v", favouritesFileName, i+1, entryLabel(fav.Name), err))
+			continue
+		}
+		s.favourites = append(s.favourites, fav)
+	}
+	return nil
+}
+
+// Skipped describes the entries the last Load left out as invalid
+func (s *Store) Skipped() []string {
+	return s.skipped
+}
+
+// entryLabel quotes an entry's name for messages, if it has one
+func entryLabel(name string) string {
+	if name == "" {
+		return "(unnamed)"
+	}
+	return fmt.Sprintf("%q", name)
 }
 
 // Save writes favourites to disk atomically
@@ -57,7 +97,7 @@ func (s *Store) Save() error {
 		// Log error but continue saving
 	}
 
-	data, err := json.MarshalIndent(s.favourites, "", "  ")
+	data, err := storage.Encode(s.favourites, migrations)
 	if err != nil {
 		return err
 	}
@@ -94,6 +134,33 @@ func (s *Store) Get(index int) (Favourite, bool) {
 	return s.favourites[index], true
 }
 
+// Groups returns the group names in use, in order of first appearance
+func (s *Store) Groups() []string {
+	var groups []string
+	seen := map[string]bool{}
+	for _, fav := range s.favourites {
+		if g := fav.GroupName(); !seen[g] {
+			seen[g] = true
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// SetGroup moves a favourite by index into a group and saves to disk. An
+// empty group moves it back to the default one.
+func (s *Store) SetGroup(index int, group string) error {
+	if index < 0 || index >= len(s.favourites) {
+		return nil
+	}
+
+	if group == DefaultGroup {
+		group = ""
+	}
+	s.favourites[index].Group = group
+	return s.Save()
+}
+
 // Rename renames a favourite by index and saves to disk
 func (s *Store) Rename(index int, newName string) error {
 	if index < 0 || index >= len(s.favourites) {
@@ -0,0 +1,88 @@
+package favourites
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that malformed entries are skipped with a message naming them while
+// the valid ones still load.
+func TestLoadSkipsInvalidFavourites(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	data := `[
+  {"name": "pods", "command": "kubectl get pods"},
+  {"name": "empty", "command": ""},
+  {"name": 42, "command": "kubectl get nodes"},
+  {"name": "", "command": "kubectl get svc"},
+  {"name": "deploys", "command": "kubectl get deployments", "group": "Apps"}
+]`
+	if err := os.WriteFile(filepath.Join(home, favouritesFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := store.List(); len(list) != 2 || list[0].Name != "pods" || list[1].Group != "Apps" {
+		t.Errorf("unexpected favourites %+v", list)
+	}
+
+	skipped := store.Skipped()
+	if len(skipped) != 3 {
+		t.Fatalf("expected 3 skipped entries, got %q", skipped)
+	}
+	for i, want := range []string{`favourite 2 "empty": command is empty`, "favourite 3 (unnamed): json:", "favourite 4 (unnamed): name is empty"} {
+		if !strings.Contains(skipped[i], want) {
+			t.Errorf("skipped[%d] = %q, want it to contain %q", i, skipped[i], want)
+		}
+	}
+}
+
+// Test that a file that is not a list fails with a message naming it.
+func TestLoadRejectsNonListFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, favouritesFileName), []byte(`{"name": "pods"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewStore()
+	if err == nil || !strings.Contains(err.Error(), "expected a list of favourites") {
+		t.Errorf("expected a list error, got %v", err)
+	}
+}
+
+// Test that a file written before versions existed is loaded and rewritten
+// at the current version, with the original kept as a backup.
+func TestLoadUpgradesUnversionedFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, favouritesFileName)
+	legacy := `[{"name": "pods", "command": "kubectl get pods"}]`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := store.List(); len(list) != 1 || list[0].Name != "pods" {
+		t.Errorf("unexpected favourites %+v", list)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) || !strings.Contains(string(data), `kubectl get pods`) {
+		t.Errorf("expected the file to be rewritten at version 1, got:\n%s", data)
+	}
+	if backup, err := os.ReadFile(path + ".bak"); err != nil || string(backup) != legacy {
+		t.Errorf("expected the original as a backup, got %q (%v)", backup, err)
+	}
+}
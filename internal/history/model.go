@@ -1,6 +1,10 @@
 package history
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+)
 
 // Entry represents a command in history.
 type Entry struct {
@@ -8,6 +12,14 @@ type Entry struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Validate reports why an entry loaded from disk cannot be used.
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.Command) == "" {
+		return errors.New("command is empty")
+	}
+	return nil
+}
+
 // NewEntry creates a new history entry.
 func NewEntry(command string) Entry {
 	return Entry{
@@ -2,6 +2,7 @@ package history
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -9,17 +10,28 @@ import (
 )
 
 const historyFileName = "kube-wizard-history.json"
-const maxHistoryEntries = 50
+
+// DefaultMaxEntries is how many commands are kept when NewStore is given
+// no limit.
+const DefaultMaxEntries = 50
+
+// migrations upgrade older history files (see storage.Migration)
+var migrations = []storage.Migration{
+	storage.Unversioned, // 0 -> 1: the list moves into a versioned file
+}
 
 // Store manages persistence of command history.
 type Store struct {
-	filePath string
-	entries  []Entry
+	filePath   string
+	entries    []Entry
+	skipped    []string
+	maxEntries int
 }
 
-// NewStore creates a new history store.
+// NewStore creates a new history store keeping at most maxEntries commands,
+// or DefaultMaxEntries when it is not positive.
 // History is stored in the user's home directory.
-func NewStore() (*Store, error) {
+func NewStore(maxEntries int) (*Store, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
@@ -27,8 +39,12 @@ func NewStore() (*Store, error) {
 
 	filePath := filepath.Join(homeDir, historyFileName)
 	store := &Store{
-		filePath: filePath,
-		entries:  []Entry{},
+		filePath:   filePath,
+		entries:    []Entry{},
+		maxEntries: maxEntries,
+	}
+	if store.maxEntries <= 0 {
+		store.maxEntries = DefaultMaxEntries
 	}
 
 	if err := store.Load(); err != nil {
@@ -40,25 +56,46 @@ func NewStore() (*Store, error) {
 	return store, nil
 }
 
-// Load reads history from disk.
+// Load reads history from disk. Invalid entries are left out and listed by
+// Skipped rather than failing the whole load.
 func (s *Store) Load() error {
-	data, err := os.ReadFile(s.filePath)
-	if err != nil {
-		return err
+	return storage.Load(s.filePath, migrations, s.decode, s.Save)
+}
+
+// decode replaces the store's contents with the data of its file
+func (s *Store) decode(raw json.RawMessage) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return fmt.Errorf("%s: expected a list of history entries: %w", s.filePath, err)
 	}
 
-	if err := json.Unmarshal(data, &s.entries); err != nil {
-		return err
+	s.entries = []Entry{}
+	s.skipped = nil
+	for i, raw := range entries {
+		var entry Entry
+		err := json.Unmarshal(raw, &entry)
+		if err == nil {
+			err = entry.Validate()
+		}
+		if err != nil {
+			s.skipped = append(s.skipped, fmt.Sprintf("%s: entry %d: %v", historyFileName, i+1, err))
+			continue
+		}
+		s.entries = append(s.entries, entry)
 	}
 
 	// Ensure we don't exceed max entries
-	if len(s.entries) > maxHistoryEntries {
-		s.entries = s.entries[:maxHistoryEntries]
+	if len(s.entries) > s.maxEntries {
+		s.entries = s.entries[:s.maxEntries]
 	}
-
 	return nil
 }
 
+// Skipped describes the entries the last Load left out as invalid.
+func (s *Store) Skipped() []string {
+	return s.skipped
+}
+
 // Save writes history to disk atomically.
 func (s *Store) Save() error {
 	// Create backup before saving
@@ -66,7 +103,7 @@ func (s *Store) Save() error {
 		// Log error but continue saving
 	}
 
-	data, err := json.MarshalIndent(s.entries, "", "  ")
+	data, err := storage.Encode(s.entries, migrations)
 	if err != nil {
 		return err
 	}
@@ -78,9 +115,24 @@ func (s *Store) Save() error {
 func (s *Store) Add(command string) error {
 	entry := NewEntry(command)
 	s.entries = append([]Entry{entry}, s.entries...)
-	if len(s.entries) > maxHistoryEntries {
-		s.entries = s.entries[:maxHistoryEntries]
+	if len(s.entries) > s.maxEntries {
+		s.entries = s.entries[:s.maxEntries]
+	}
+	return s.Save()
+}
+
+// SetMaxEntries changes how many commands are kept. Commands beyond the new
+// limit are dropped, oldest first, and the history is saved.
+func (s *Store) SetMaxEntries(maxEntries int) error {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	s.maxEntries = maxEntries
+	entries := s.List()
+	if len(entries) <= maxEntries {
+		return nil
 	}
+	s.entries = entries[:maxEntries]
 	return s.Save()
 }
 
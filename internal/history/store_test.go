@@ -0,0 +1,37 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that entries with a wrong type or no command are skipped with a
+// message naming them while the valid ones still load.
+func TestLoadSkipsInvalidEntries(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	data := `[
+  {"command": "kubectl get pods", "timestamp": "2024-05-01T10:00:00Z"},
+  {"command": "kubectl get nodes", "timestamp": "yesterday"},
+  {"command": "", "timestamp": "2024-05-01T09:00:00Z"},
+  {"command": "kubectl get svc", "timestamp": "2024-05-01T08:00:00Z"}
+]`
+	if err := os.WriteFile(filepath.Join(home, historyFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := store.List(); len(list) != 2 || list[0].Command != "kubectl get pods" || list[1].Command != "kubectl get svc" {
+		t.Errorf("unexpected entries %+v", list)
+	}
+
+	skipped := store.Skipped()
+	if len(skipped) != 2 || !strings.Contains(skipped[0], "entry 2: parsing time") || !strings.Contains(skipped[1], "entry 3: command is empty") {
+		t.Errorf("unexpected skipped entries %q", skipped)
+	}
+}
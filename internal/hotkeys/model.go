@@ -1,8 +1,39 @@
 package hotkeys
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keys are the keys a command can be bound to.
+var Keys = []string{"F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12"}
+
+// IsValidKey reports whether key, in any case, is one of Keys.
+func IsValidKey(key string) bool {
+	key = strings.TrimSpace(strings.ToUpper(key))
+	for _, k := range Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // Binding represents a hotkey binding to a command.
 type Binding struct {
 	Key     string `json:"key"`
 	Name    string `json:"name"`
 	Command string `json:"command"`
 }
+
+// Validate reports why a binding loaded from disk cannot be used.
+func (b Binding) Validate() error {
+	if !IsValidKey(b.Key) {
+		return fmt.Errorf("key %q is not one of F1-F12", b.Key)
+	}
+	if strings.TrimSpace(b.Command) == "" {
+		return errors.New("command is empty")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package hotkeys
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,10 +11,16 @@ import (
 
 const hotkeysFileName = "kube-wizard-hotkeys.json"
 
+// migrations upgrade older hotkeys files (see storage.Migration)
+var migrations = []storage.Migration{
+	storage.Unversioned, // 0 -> 1: the list moves into a versioned file
+}
+
 // Store manages persistence of hotkey bindings.
 type Store struct {
 	filePath string
 	bindings map[string]Binding
+	skipped  []string
 }
 
 // NewStore creates a new hotkeys store.
@@ -39,31 +46,42 @@ func NewStore() (*Store, error) {
 	return store, nil
 }
 
-// Load reads bindings from disk.
+// Load reads bindings from disk. Invalid entries are left out and listed by
+// Skipped rather than failing the whole load.
 func (s *Store) Load() error {
-	data, err := os.ReadFile(s.filePath)
-	if err != nil {
-		return err
-	}
+	return storage.Load(s.filePath, migrations, s.decode, s.Save)
+}
 
-	var bindings []Binding
-	if err := json.Unmarshal(data, &bindings); err != nil {
-		return err
+// decode replaces the store's contents with the data of its file
+func (s *Store) decode(raw json.RawMessage) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return fmt.Errorf("%s: expected a list of hotkeys: %w", s.filePath, err)
 	}
 
 	s.bindings = map[string]Binding{}
-	for _, b := range bindings {
-		key := strings.TrimSpace(strings.ToUpper(b.Key))
-		if key == "" {
+	s.skipped = nil
+	for i, raw := range entries {
+		var b Binding
+		err := json.Unmarshal(raw, &b)
+		if err == nil {
+			err = b.Validate()
+		}
+		if err != nil {
+			s.skipped = append(s.skipped, fmt.Sprintf("%s: hotkey %d: %v", hotkeysFileName, i+1, err))
 			continue
 		}
-		b.Key = key
-		s.bindings[key] = b
+		b.Key = strings.TrimSpace(strings.ToUpper(b.Key))
+		s.bindings[b.Key] = b
 	}
-
 	return nil
 }
 
+// Skipped describes the entries the last Load left out as invalid.
+func (s *Store) Skipped() []string {
+	return s.skipped
+}
+
 // Save writes bindings to disk atomically.
 func (s *Store) Save() error {
 	// Create backup before saving
@@ -76,7 +94,7 @@ func (s *Store) Save() error {
 		bindings = append(bindings, b)
 	}
 
-	data, err := json.MarshalIndent(bindings, "", "  ")
+	data, err := storage.Encode(bindings, migrations)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,46 @@
+package hotkeys
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that bindings to keys other than F1-F12, or without a command, are
+// skipped with a message naming them.
+func TestLoadSkipsInvalidBindings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	data := `[
+  {"key": "f2", "name": "pods", "command": "kubectl get pods"},
+  {"key": "F13", "name": "nodes", "command": "kubectl get nodes"},
+  {"key": "ctrl+x", "name": "svc", "command": "kubectl get svc"},
+  {"key": "F3", "name": "none", "command": " "},
+  {"key": ["F4"], "name": "list", "command": "kubectl get ns"}
+]`
+	if err := os.WriteFile(filepath.Join(home, hotkeysFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := store.Get("F2"); !ok || b.Command != "kubectl get pods" {
+		t.Errorf("expected F2 to be bound, got %+v", store.List())
+	}
+	if len(store.List()) != 1 {
+		t.Errorf("expected only F2, got %+v", store.List())
+	}
+
+	skipped := store.Skipped()
+	if len(skipped) != 4 {
+		t.Fatalf("expected 4 skipped entries, got %q", skipped)
+	}
+	for i, want := range []string{`hotkey 2: key "F13" is not one of F1-F12`, `hotkey 3: key "ctrl+x"`, "hotkey 4: command is empty", "hotkey 5: json:"} {
+		if !strings.Contains(skipped[i], want) {
+			t.Errorf("skipped[%d] = %q, want it to contain %q", i, skipped[i], want)
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package kubectl
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
@@ -16,15 +22,72 @@ import (
 // Client wraps kubectl command execution
 type Client struct {
 	Timeout time.Duration
+
+	// Retries is how many times a command is retried after a transient
+	// failure (connection refused, timeouts, TLS handshake). 0 disables retries.
+	Retries int
+	// RetryBackoff is the delay before the first retry; it doubles each attempt.
+	RetryBackoff time.Duration
+
+	// AllowedEnv lists the environment variables expanded in the arguments
+	// of raw commands; see ExpandEnvArgs.
+	AllowedEnv []string
+
+	// kubeconfig is passed as --kubeconfig to every command when set
+	kubeconfig string
+
+	// binary is the kubectl executable to run; tests point it at a fake
+	binary string
 }
 
 // NewClient creates a new kubectl client with default timeout
 func NewClient() *Client {
 	return &Client{
-		Timeout: 30 * time.Second,
+		Timeout:      30 * time.Second,
+		RetryBackoff: 500 * time.Millisecond,
+		binary:       "kubectl",
 	}
 }
 
+// SetKubeconfig points the client at a specific kubeconfig file. An empty
+// path restores kubectl's default lookup (KUBECONFIG or ~/.kube/config).
+func (c *Client) SetKubeconfig(path string) error {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		c.kubeconfig = ""
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("kubeconfig file %s does not exist", path)
+		}
+		return fmt.Errorf("failed to access kubeconfig %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("kubeconfig path %s is a directory", path)
+	}
+
+	c.kubeconfig = path
+	return nil
+}
+
+// Kubeconfig returns the kubeconfig file in use, or an empty string when
+// kubectl's default lookup applies.
+func (c *Client) Kubeconfig() string {
+	return c.kubeconfig
+}
+
+// BuildArgs prepends the client's global flags (such as --kubeconfig) to args.
+// Use it when running kubectl outside of the client, e.g. interactive commands.
+func (c *Client) BuildArgs(args ...string) []string {
+	if c.kubeconfig == "" {
+		return args
+	}
+	return append([]string{"--kubeconfig", c.kubeconfig}, args...)
+}
+
 // CheckKubectlInstalled verifies if kubectl is available in the PATH
 func (c *Client) CheckKubectlInstalled() error {
 	_, err := exec.LookPath("kubectl")
@@ -118,6 +181,60 @@ type ClusterInfo struct {
 	Version           string
 }
 
+// ClusterSummary is a lightweight subset of ClusterInfo used for quick health checks
+type ClusterSummary struct {
+	TotalNodes int
+	ReadyNodes int
+	Version    string
+}
+
+// NamespaceInfo represents a namespace with its phase and pod count
+type NamespaceInfo struct {
+	Name     string
+	Status   string // Active or Terminating
+	PodCount int    // -1 when pod counts could not be retrieved
+}
+
+// OwnerReference is an entry of a resource's metadata.ownerReferences
+type OwnerReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller"`
+}
+
+// Resource returns the resource type to pass to kubectl for the owner,
+// qualified with its API group so e.g. "replicaset.apps" is unambiguous.
+func (o OwnerReference) Resource() string {
+	resource := strings.ToLower(o.Kind)
+	if i := strings.Index(o.APIVersion, "/"); i >= 0 {
+		resource += "." + o.APIVersion[:i]
+	}
+	return resource
+}
+
+// PodInfo holds the details of a pod that are most often looked up by hand
+type PodInfo struct {
+	IP         string
+	Node       string
+	Containers []ContainerImage
+}
+
+// ContainerImage is the image a pod container runs
+type ContainerImage struct {
+	Name  string
+	Image string
+}
+
+// ContextStatus describes whether a kube context's API server responded
+type ContextStatus string
+
+const (
+	ContextReachable   ContextStatus = "reachable"
+	ContextUnreachable ContextStatus = "unreachable"
+	ContextTimeout     ContextStatus = "timeout"
+)
+
 // GetPods retrieves all pods in the current namespace
 func (c *Client) GetPods() (CommandResult, error) {
 	return c.execute("get", "pods")
@@ -138,6 +255,38 @@ func (c *Client) GetPodLogs(podName string) (CommandResult, error) {
 	return c.execute("logs", podName)
 }
 
+// GetPodRestartCount returns the number of restarts of a pod's container, or
+// the total of all its containers when container is empty. An empty namespace
+// uses the current namespace.
+func (c *Client) GetPodRestartCount(podName, namespace, container string) (int, error) {
+	statuses := "*"
+	if container != "" {
+		statuses = fmt.Sprintf("?(@.name==%q)", container)
+	}
+	args := []string{"get", "pod", podName, "-o", "jsonpath={.status.containerStatuses[" + statuses + "].restartCount}"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	result, err := c.execute(args...)
+	if err != nil {
+		return 0, err
+	}
+	if result.Error != "" {
+		return 0, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	total := 0
+	for _, field := range strings.Fields(result.Output) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected restart count %q", field)
+		}
+		total += n
+	}
+	return total, nil
+}
+
 // ListPodNames returns a list of pod names in the current namespace
 func (c *Client) ListPodNames() ([]string, error) {
 	return c.listResourceNames("pods")
@@ -178,6 +327,78 @@ func (c *Client) ListNamespaceNames() ([]string, error) {
 	return c.listResourceNames("namespaces")
 }
 
+// ListNamespaces returns all namespaces with their status and pod counts.
+// Pod counts are best effort: if pods cannot be listed cluster-wide (e.g. due
+// to RBAC), each PodCount is set to -1 and the namespaces are still returned.
+func (c *Client) ListNamespaces() ([]NamespaceInfo, error) {
+	result, err := c.execute("get", "namespaces", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	var nsData struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Status struct {
+				Phase string `json:"phase"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &nsData); err != nil {
+		return nil, fmt.Errorf("failed to parse namespaces JSON: %w", err)
+	}
+
+	podCounts, countErr := c.getPodCountsByNamespace()
+
+	namespaces := make([]NamespaceInfo, 0, len(nsData.Items))
+	for _, item := range nsData.Items {
+		ns := NamespaceInfo{
+			Name:     item.Metadata.Name,
+			Status:   item.Status.Phase,
+			PodCount: -1,
+		}
+		if countErr == nil {
+			ns.PodCount = podCounts[ns.Name]
+		}
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces, nil
+}
+
+// getPodCountsByNamespace returns the number of pods in each namespace
+func (c *Client) getPodCountsByNamespace() (map[string]int, error) {
+	result, err := c.execute("get", "pods", "--all-namespaces", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	var podsData struct {
+		Items []struct {
+			Metadata struct {
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &podsData); err != nil {
+		return nil, fmt.Errorf("failed to parse pods JSON: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, item := range podsData.Items {
+		counts[item.Metadata.Namespace]++
+	}
+	return counts, nil
+}
+
 // ListContexts returns the available kube contexts
 func (c *Client) ListContexts() ([]string, error) {
 	result, err := c.execute("config", "get-contexts", "-o", "name")
@@ -192,6 +413,70 @@ func (c *Client) ListContexts() ([]string, error) {
 	return names, nil
 }
 
+// ProbeContext checks whether the API server behind a context responds
+// within a short request timeout, without switching the current context.
+func (c *Client) ProbeContext(name string) ContextStatus {
+	result, err := c.execute("cluster-info", "--context", name, "--request-timeout=2s")
+	if err == context.DeadlineExceeded {
+		return ContextTimeout
+	}
+	if err != nil || result.Error != "" {
+		stderr := strings.ToLower(result.Error)
+		if strings.Contains(stderr, "timeout") || strings.Contains(stderr, "deadline exceeded") {
+			return ContextTimeout
+		}
+		return ContextUnreachable
+	}
+	return ContextReachable
+}
+
+// ProbeContexts runs ProbeContext for each name concurrently.
+func (c *Client) ProbeContexts(names []string) map[string]ContextStatus {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]ContextStatus, len(names))
+	)
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			status := c.ProbeContext(name)
+			mu.Lock()
+			results[name] = status
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return results
+}
+
+// CanI asks the API server whether the current user may perform verb on
+// resource (e.g. "delete", "pods" or "create", "pods/exec"), using
+// "kubectl auth can-i". An empty namespace uses the current namespace.
+func (c *Client) CanI(verb, resource, namespace string) (bool, error) {
+	args := []string{"auth", "can-i", verb, resource}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	// A "no" answer also exits non-zero, so the output decides
+	result, err := c.execute(args...)
+	switch strings.TrimSpace(result.Output) {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	if result.Error != "" {
+		return false, fmt.Errorf("kubectl error: %s", strings.TrimSpace(result.Error))
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, fmt.Errorf("unexpected auth can-i output: %q", result.Output)
+}
+
 // UseContext switches the current kube context
 func (c *Client) UseContext(name string) error {
 	result, err := c.execute("config", "use-context", name)
@@ -204,6 +489,32 @@ func (c *Client) UseContext(name string) error {
 	return nil
 }
 
+// CreateNamespace creates a namespace with the given name
+func (c *Client) CreateNamespace(name string) error {
+	result, err := c.execute("create", "namespace", name)
+	if err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return nil
+}
+
+// DeleteNamespace deletes a namespace and everything in it. It does not wait
+// for the namespace's finalizers, so the namespace may still be listed as
+// Terminating for a while afterwards.
+func (c *Client) DeleteNamespace(name string) error {
+	result, err := c.execute("delete", "namespace", name, "--wait=false")
+	if err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return nil
+}
+
 // GetCurrentContext checks if a Kubernetes cluster context is configured
 func (c *Client) GetCurrentContext() (string, error) {
 	result, err := c.execute("config", "current-context")
@@ -222,6 +533,257 @@ func (c *Client) GetCurrentContext() (string, error) {
 	return context, nil
 }
 
+// GetCurrentNamespace returns the namespace of the current kube context,
+// falling back to "default" when the context does not set one.
+func (c *Client) GetCurrentNamespace() (string, error) {
+	result, err := c.execute("config", "view", "--minify", "-o", "jsonpath={..namespace}")
+	if err != nil {
+		return "", err
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	namespace := strings.TrimSpace(result.Output)
+	if namespace == "" {
+		namespace = "default"
+	}
+	return namespace, nil
+}
+
+// ListNamesAllNamespaces lists resources of the given type across all
+// namespaces as "namespace/name" entries.
+func (c *Client) ListNamesAllNamespaces(resource string) ([]string, error) {
+	result, err := c.execute("get", resource, "-A", "-o", `jsonpath={range .items[*]}{.metadata.namespace}/{.metadata.name}{" "}{end}`)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	return strings.Fields(result.Output), nil
+}
+
+// ListResourceNames lists the names of resources of any type, e.g.
+// "statefulsets", in the current namespace.
+func (c *Client) ListResourceNames(resource string) ([]string, error) {
+	return c.listResourceNames(resource)
+}
+
+// GetOwnerReferences returns the owner references of one resource, e.g. the
+// ReplicaSet that owns a pod. namespace may be empty for the current one.
+func (c *Client) GetOwnerReferences(resource, name, namespace string) ([]OwnerReference, error) {
+	args := []string{"get", resource, name, "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	result, err := c.execute(args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return parseOwnerReferences(result.Output)
+}
+
+// parseOwnerReferences extracts metadata.ownerReferences from a resource's JSON.
+func parseOwnerReferences(output string) ([]OwnerReference, error) {
+	var obj struct {
+		Metadata struct {
+			OwnerReferences []OwnerReference `json:"ownerReferences"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(output), &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse resource JSON: %w", err)
+	}
+	return obj.Metadata.OwnerReferences, nil
+}
+
+// GetPodInfo fetches a pod's JSON once and returns its IP, node and container
+// images. namespace may be empty for the current one.
+func (c *Client) GetPodInfo(name, namespace string) (PodInfo, error) {
+	args := []string{"get", "pod", name, "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	result, err := c.execute(args...)
+	if err != nil {
+		return PodInfo{}, err
+	}
+	if result.Error != "" {
+		return PodInfo{}, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return parsePodInfo(result.Output)
+}
+
+// parsePodInfo extracts status.podIP, spec.nodeName and
+// spec.containers[].image from a pod's JSON.
+func parsePodInfo(output string) (PodInfo, error) {
+	var pod struct {
+		Spec struct {
+			NodeName   string           `json:"nodeName"`
+			Containers []ContainerImage `json:"containers"`
+		} `json:"spec"`
+		Status struct {
+			PodIP string `json:"podIP"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(output), &pod); err != nil {
+		return PodInfo{}, fmt.Errorf("failed to parse pod JSON: %w", err)
+	}
+	return PodInfo{IP: pod.Status.PodIP, Node: pod.Spec.NodeName, Containers: pod.Spec.Containers}, nil
+}
+
+// GetDeploymentSelector returns the label selector of a deployment, i.e. the
+// pods it manages, in kubectl's -l syntax, e.g. "app=web,tier in (front)".
+// namespace may be empty for the current one.
+func (c *Client) GetDeploymentSelector(name, namespace string) (string, error) {
+	args := []string{"get", "deployment", name, "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	result, err := c.execute(args...)
+	if err != nil {
+		return "", err
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return parseLabelSelector(result.Output)
+}
+
+// parseLabelSelector builds a -l selector from spec.selector of a resource's
+// JSON, matchLabels first and sorted by key, then matchExpressions.
+func parseLabelSelector(output string) (string, error) {
+	var obj struct {
+		Spec struct {
+			Selector struct {
+				MatchLabels      map[string]string `json:"matchLabels"`
+				MatchExpressions []struct {
+					Key      string   `json:"key"`
+					Operator string   `json:"operator"`
+					Values   []string `json:"values"`
+				} `json:"matchExpressions"`
+			} `json:"selector"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(output), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse resource JSON: %w", err)
+	}
+
+	var parts []string
+	for key, value := range obj.Spec.Selector.MatchLabels {
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	for _, e := range obj.Spec.Selector.MatchExpressions {
+		switch e.Operator {
+		case "In":
+			parts = append(parts, fmt.Sprintf("%s in (%s)", e.Key, strings.Join(e.Values, ",")))
+		case "NotIn":
+			parts = append(parts, fmt.Sprintf("%s notin (%s)", e.Key, strings.Join(e.Values, ",")))
+		case "Exists":
+			parts = append(parts, e.Key)
+		case "DoesNotExist":
+			parts = append(parts, "!"+e.Key)
+		default:
+			return "", fmt.Errorf("unsupported selector operator %q", e.Operator)
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("resource has no label selector")
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// ListPodNamesBySelector returns the names of the pods matching a label
+// selector. namespace may be empty for the current one.
+func (c *Client) ListPodNamesBySelector(selector, namespace string) ([]string, error) {
+	args := []string{"get", "pods", "-l", selector, "-o", "jsonpath={.items[*].metadata.name}"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	result, err := c.execute(args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return strings.Fields(result.Output), nil
+}
+
+// ListPlugins returns the kubectl plugins found on PATH as the commands that
+// run them, e.g. "neat" or "view-secret". Having no plugins is not an error.
+// kubectl exits non-zero when a plugin is shadowed or not executable but
+// still lists the plugins it found, which are returned with its warnings.
+func (c *Client) ListPlugins() (plugins []string, warnings string, err error) {
+	result, err := c.execute("plugin", "list", "--name-only")
+	if err != nil {
+		if strings.TrimSpace(result.Output) == "" {
+			if strings.Contains(result.Error, "unable to find any kubectl plugins") {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("failed to list plugins: %s", strings.TrimSpace(result.Error))
+		}
+		warnings = strings.TrimSpace(result.Error)
+	}
+	return parsePluginList(result.Output), warnings, nil
+}
+
+// parsePluginList turns "kubectl plugin list --name-only" output into plugin
+// commands. Dashes in a plugin file name separate subcommands and
+// underscores stand for dashes, so "kubectl-view_secret" is "view-secret".
+func parsePluginList(output string) []string {
+	var plugins []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		name := filepath.Base(strings.TrimSpace(line))
+		if !strings.HasPrefix(name, "kubectl-") {
+			continue
+		}
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "kubectl-"), ".exe")
+		name = strings.ReplaceAll(strings.ReplaceAll(name, "-", " "), "_", "-")
+		// A plugin shadowed by an earlier one on PATH is listed twice
+		if name != "" && !seen[name] {
+			seen[name] = true
+			plugins = append(plugins, name)
+		}
+	}
+	return plugins
+}
+
+// ListAPIResources returns the resource types the cluster serves, each under
+// its plural name, its short names and its lower-case kind, e.g. "pods",
+// "po" and "pod".
+func (c *Client) ListAPIResources() ([]string, error) {
+	result, err := c.execute("api-resources", "--no-headers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API resources: %s", strings.TrimSpace(result.Error))
+	}
+	return parseAPIResources(result.Output), nil
+}
+
+// parseAPIResources turns "kubectl api-resources --no-headers" output into
+// resource type names. The SHORTNAMES column is left blank for types without
+// short names, so a line has four or five columns.
+func parseAPIResources(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		names = append(names, fields[0], strings.ToLower(fields[len(fields)-1]))
+		if len(fields) == 5 {
+			names = append(names, strings.Split(fields[1], ",")...)
+		}
+	}
+	return names
+}
+
 // listResourceNames is a helper that lists resource names using a common jsonpath
 func (c *Client) listResourceNames(resource string) ([]string, error) {
 	result, err := c.execute("get", resource, "-o", "jsonpath={.items[*].metadata.name}")
@@ -239,6 +801,24 @@ func (c *Client) listResourceNames(resource string) ([]string, error) {
 
 // ExecuteRaw executes a raw kubectl command string with cluster validation
 func (c *Client) ExecuteRaw(commandStr string) (CommandResult, error) {
+	return c.ExecuteRawContext(context.Background(), commandStr)
+}
+
+// ExecuteRawContext is like ExecuteRaw but stops the command when ctx is cancelled.
+func (c *Client) ExecuteRawContext(ctx context.Context, commandStr string) (CommandResult, error) {
+	return c.executeRaw(ctx, commandStr, nil)
+}
+
+// ExecuteRawWithStdin is like ExecuteRawContext but pipes stdin to the
+// command, e.g. a manifest for "kubectl apply -f -". It is not retried, as
+// the input has been consumed by the first attempt.
+func (c *Client) ExecuteRawWithStdin(ctx context.Context, commandStr, stdin string) (CommandResult, error) {
+	return c.executeRaw(ctx, commandStr, strings.NewReader(stdin))
+}
+
+// executeRaw validates the cluster context and runs a raw command string,
+// with stdin when it is not nil.
+func (c *Client) executeRaw(ctx context.Context, commandStr string, stdin io.Reader) (CommandResult, error) {
 	// First check if a cluster context is configured
 	if _, err := c.GetCurrentContext(); err != nil {
 		return CommandResult{
@@ -254,8 +834,15 @@ func (c *Client) ExecuteRaw(commandStr string) (CommandResult, error) {
 		commandStr = strings.TrimPrefix(commandStr, "kubectl ")
 	}
 
-	// Split the command into arguments
-	args := strings.Fields(commandStr)
+	// Split the command into arguments, keeping quoted values such as
+	// -o jsonpath='{.items[*].metadata.name}' together
+	args, err := SplitArgs(commandStr)
+	if err != nil {
+		return CommandResult{
+			Command: commandStr,
+			Error:   err.Error(),
+		}, err
+	}
 	if len(args) == 0 {
 		return CommandResult{
 			Command: commandStr,
@@ -263,39 +850,406 @@ func (c *Client) ExecuteRaw(commandStr string) (CommandResult, error) {
 		}, fmt.Errorf("invalid command")
 	}
 
-	return c.execute(args...)
+	if stdin != nil {
+		return c.executeOnce(ctx, stdin, c.ExpandEnvArgs(args)...)
+	}
+	return c.executeContext(ctx, c.ExpandEnvArgs(args)...)
+}
+
+// SplitArgs splits a command line into arguments the way a POSIX shell
+// would, without expanding anything: single quotes keep their contents as
+// written, and a backslash escapes a quote, a backslash or, outside quotes,
+// a space. Other backslashes are kept, so Windows paths pass through. An
+// unterminated quote is an error.
+func SplitArgs(cmd string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			if !strings.ContainsRune(`"\`, r) && (quote == '"' || !strings.ContainsRune(" \t'", r)) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in command", quote)
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// QuoteArg quotes arg for a command line read by SplitArgs or a shell. It is
+// returned unchanged when it needs no quoting.
+func QuoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`*?[]{}()<>|&;#~!") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// ExpandEnvArgs expands allowed environment variables in each argument of a
+// tokenized command. Use it when running kubectl outside of the client.
+func (c *Client) ExpandEnvArgs(args []string) []string {
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = c.expandEnv(arg)
+	}
+	return expanded
+}
+
+// ExpandEnvCommand is ExpandEnvArgs for a whole command line, giving the
+// command as it will run, e.g. to check its flags before running it. A nil
+// client, or a command that can't be split, leaves cmd unchanged.
+func (c *Client) ExpandEnvCommand(cmd string) string {
+	if c == nil || len(c.AllowedEnv) == 0 {
+		return cmd
+	}
+	args, err := SplitArgs(cmd)
+	if err != nil {
+		return cmd
+	}
+	for i, arg := range c.ExpandEnvArgs(args) {
+		args[i] = QuoteArg(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// expandEnv replaces $NAME and ${NAME} in cmd with the value of the
+// environment variable NAME when it is listed in AllowedEnv. References to
+// other names, such as the $c of a go-template, and malformed ones, such as
+// an unterminated ${, are left as written; a value is never split into
+// further arguments.
+func (c *Client) expandEnv(cmd string) string {
+	if len(c.AllowedEnv) == 0 || !strings.Contains(cmd, "$") {
+		return cmd
+	}
+	allowed := func(name string) bool {
+		for _, a := range c.AllowedEnv {
+			if name == a {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(cmd); {
+		if cmd[i] != '$' {
+			sb.WriteByte(cmd[i])
+			i++
+			continue
+		}
+		// A reference runs from i to end, naming name
+		var name string
+		end := i + 1
+		if end < len(cmd) && cmd[end] == '{' {
+			closing := strings.IndexByte(cmd[end:], '}')
+			if closing < 0 {
+				sb.WriteString(cmd[i:])
+				break
+			}
+			name = cmd[end+1 : end+closing]
+			end += closing + 1
+		} else {
+			for end < len(cmd) && isEnvNameByte(cmd[end], end == i+1) {
+				end++
+			}
+			name = cmd[i+1 : end]
+		}
+		if name != "" && validEnvName(name) && allowed(name) {
+			sb.WriteString(os.Getenv(name))
+		} else {
+			sb.WriteString(cmd[i:end])
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// isEnvNameByte reports whether b can be part of an environment variable
+// name, or start one when first is set.
+func isEnvNameByte(b byte, first bool) bool {
+	return b == '_' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || !first && '0' <= b && b <= '9'
+}
+
+// validEnvName reports whether name is a whole environment variable name.
+func validEnvName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !isEnvNameByte(name[i], i == 0) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// Stream starts a long-running kubectl command, such as a watch, and sends
+// each line it writes to stdout or stderr on the returned channel. The
+// channel is closed once the process exits; the exit error, nil when it was
+// stopped by cancelling ctx, is then sent on the error channel.
+func (c *Client) Stream(ctx context.Context, args ...string) (<-chan string, <-chan error, error) {
+	binary := c.binary
+	if binary == "" {
+		binary = "kubectl"
+	}
+	cmd := exec.CommandContext(ctx, binary, c.BuildArgs(args...)...)
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	logStr := "kubectl " + strings.Join(RedactArgs(args), " ")
+	logger.Info("Starting stream: %s", logStr)
+	if err := cmd.Start(); err != nil {
+		pw.Close()
+		return nil, nil, fmt.Errorf("failed to start %s: %w", logStr, err)
+	}
+
+	lines := make(chan string, 64)
+	errc := make(chan error, 1)
+
+	go func() {
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			err = nil
+		}
+		pw.CloseWithError(err)
+	}()
+
+	go func() {
+		defer close(errc)
+		defer close(lines)
+		defer logger.Info("Stream ended: %s", logStr)
+
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				// Nobody is reading any more; unblock the process output copy
+				pr.Close()
+				errc <- nil
+				return
+			}
+		}
+		errc <- scanner.Err()
+	}()
+
+	return lines, errc, nil
+}
+
+// RedactArgs returns a copy of kubectl args that is safe to write to logs.
+// Secret extraction templates (go-template/jsonpath output on secrets) are
+// replaced, along with everything after them, and literal values passed via
+// --from-literal are hidden.
+func RedactArgs(args []string) []string {
+	isSecret := false
+	for _, arg := range args {
+		lower := strings.ToLower(arg)
+		if lower == "secret" || lower == "secrets" || strings.HasPrefix(lower, "secret/") || strings.HasPrefix(lower, "secrets/") {
+			isSecret = true
+			break
+		}
+	}
+
+	redacted := make([]string, 0, len(args))
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--from-literal=") {
+			redacted = append(redacted, "--from-literal=<redacted>")
+			continue
+		}
+		if isSecret && isTemplateOutputArg(args, i) {
+			return append(redacted, "<redacted>")
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted
+}
+
+// isTemplateOutputArg reports whether args[i] selects a go-template or
+// jsonpath output, either inline (-o=jsonpath=...) or as the flag's value.
+func isTemplateOutputArg(args []string, i int) bool {
+	value := args[i]
+	switch {
+	case value == "-o" || value == "--output":
+		if i+1 >= len(args) {
+			return false
+		}
+		value = args[i+1]
+	case strings.HasPrefix(value, "-o="):
+		value = strings.TrimPrefix(value, "-o=")
+	case strings.HasPrefix(value, "--output="):
+		value = strings.TrimPrefix(value, "--output=")
+	default:
+		return false
+	}
+	return strings.Contains(value, "template") || strings.HasPrefix(value, "jsonpath")
+}
+
+// transientErrorPatterns are stderr fragments that indicate a failure worth retrying
+var transientErrorPatterns = []string{
+	"connection refused",
+	"i/o timeout",
+	"tls handshake timeout",
+	"client.timeout exceeded",
+	"connection reset by peer",
+}
+
+// isTransientError reports whether kubectl's stderr describes a transient
+// network failure rather than a problem with the command itself.
+func isTransientError(stderr string) bool {
+	stderr = strings.ToLower(stderr)
+	for _, pattern := range transientErrorPatterns {
+		if strings.Contains(stderr, pattern) {
+			return true
+		}
+	}
+	return false
 }
 
-// execute runs a kubectl command and captures output with timeout
+// execute runs a kubectl command, retrying transient failures up to
+// c.Retries times with exponential backoff.
 func (c *Client) execute(args ...string) (CommandResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	return c.executeContext(context.Background(), args...)
+}
+
+// executeContext is like execute but gives up as soon as parent is cancelled.
+func (c *Client) executeContext(parent context.Context, args ...string) (CommandResult, error) {
+	result, err := c.executeOnce(parent, nil, args...)
+
+	backoff := c.RetryBackoff
+	for attempt := 1; attempt <= c.Retries && err != nil && isTransientError(result.Error); attempt++ {
+		logger.Info("Retrying command after transient error (attempt %d/%d): kubectl %s", attempt, c.Retries, strings.Join(RedactArgs(args), " "))
+		select {
+		case <-parent.Done():
+			return result, parent.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		result, err = c.executeOnce(parent, nil, args...)
+	}
+
+	return result, err
+}
+
+// timeoutSlack is added to a command's own --timeout, so kubectl reports
+// its timeout before the client gives up on the command.
+const timeoutSlack = 10 * time.Second
+
+// commandTimeout returns how long a command may run: c.Timeout, or longer
+// when it has a --timeout of its own, as waits and drains do.
+func (c *Client) commandTimeout(args []string) time.Duration {
+	timeout := c.Timeout
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		value, ok := strings.CutPrefix(arg, "--timeout=")
+		if !ok && arg == "--timeout" && i+1 < len(args) {
+			value, ok = args[i+1], true
+		}
+		if !ok {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err == nil && d+timeoutSlack > timeout {
+			timeout = d + timeoutSlack
+		}
+	}
+	return timeout
+}
+
+// executeOnce runs a kubectl command and captures output with timeout. stdin
+// is piped to the command when it is not nil.
+func (c *Client) executeOnce(parent context.Context, stdin io.Reader, args ...string) (CommandResult, error) {
+	timeout := c.commandTimeout(args)
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	binary := c.binary
+	if binary == "" {
+		binary = "kubectl"
+	}
+	cmd := exec.CommandContext(ctx, binary, c.BuildArgs(args...)...)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
 
-	// Build command string for display
+	// Build command string for display; the log gets a redacted variant
 	cmdStr := "kubectl " + strings.Join(args, " ")
-	logger.Info("Executing command: %s", cmdStr)
+	logStr := "kubectl " + strings.Join(RedactArgs(args), " ")
+	logger.Info("Executing command: %s", logStr)
 
+	start := time.Now()
 	err := cmd.Run()
+	duration := time.Since(start).Round(time.Millisecond)
+
+	// Check if the command was cancelled by the caller
+	if parent.Err() == context.Canceled {
+		logger.Info("Command cancelled after %v: %s", duration, logStr)
+		return CommandResult{
+			Command: cmdStr,
+			Error:   "command cancelled",
+		}, parent.Err()
+	}
 
 	// Check if the command was cancelled due to timeout
 	if ctx.Err() == context.DeadlineExceeded {
-		logger.Error("Command timed out: %s", cmdStr)
+		logger.Error("Command timed out after %v: %s", duration, logStr)
 		return CommandResult{
 			Command: cmdStr,
-			Error:   fmt.Sprintf("command timed out after %v", c.Timeout),
+			Error:   fmt.Sprintf("command timed out after %v", timeout),
 		}, ctx.Err()
 	}
 
+	logger.Info("Command finished: %s (duration: %v, error: %t)", logStr, duration, err != nil)
 	if err != nil {
-		logger.Error("Command failed: %s, error: %v, stderr: %s", cmdStr, err, stderr.String())
-	} else {
-		logger.Info("Command succeeded: %s", cmdStr)
+		logger.Error("Command failed: %s, error: %v", logStr, err)
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		logger.Error("Command stderr: %s: %s", logStr, msg)
 	}
 
 	result := CommandResult{
@@ -385,6 +1339,48 @@ func (c *Client) GetClusterInfo() (*ClusterInfo, error) {
 	return info, nil
 }
 
+// GetClusterSummary retrieves node counts and the server version without the
+// per-node metrics and pod lookups performed by GetClusterInfo.
+func (c *Client) GetClusterSummary() (*ClusterSummary, error) {
+	result, err := c.execute("get", "nodes", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+
+	var nodesData struct {
+		Items []struct {
+			Status struct {
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &nodesData); err != nil {
+		return nil, fmt.Errorf("failed to parse nodes JSON: %w", err)
+	}
+
+	summary := &ClusterSummary{TotalNodes: len(nodesData.Items)}
+	for _, item := range nodesData.Items {
+		for _, condition := range item.Status.Conditions {
+			if condition.Type == "Ready" && condition.Status == "True" {
+				summary.ReadyNodes++
+				break
+			}
+		}
+	}
+
+	if version, err := c.getClusterVersion(); err == nil {
+		summary.Version = version
+	}
+
+	return summary, nil
+}
+
 // getNodesInfo retrieves detailed information about all nodes
 func (c *Client) getNodesInfo() ([]NodeInfo, error) {
 	// Get nodes with custom columns for basic info
@@ -521,6 +1517,45 @@ func (c *Client) getNodeMetrics(nodeName string) (map[string]string, error) {
 	}, nil
 }
 
+// NodeUsage is a node's CPU and memory use as a percentage of allocatable,
+// as reported by "kubectl top nodes".
+type NodeUsage struct {
+	Name          string
+	CPUPercent    int
+	MemoryPercent int
+}
+
+// GetNodeUsage samples the CPU and memory use of every node in one call.
+func (c *Client) GetNodeUsage() ([]NodeUsage, error) {
+	result, err := c.execute("top", "nodes", "--no-headers")
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("kubectl error: %s", result.Error)
+	}
+	return parseNodeUsage(result.Output), nil
+}
+
+// parseNodeUsage reads "NAME CPU(cores) CPU% MEMORY(bytes) MEMORY%" lines.
+// Nodes without metrics yet, shown as <unknown>, are left out.
+func parseNodeUsage(output string) []NodeUsage {
+	var usage []NodeUsage
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		cpu, cpuErr := strconv.Atoi(strings.TrimSuffix(fields[2], "%"))
+		memory, memErr := strconv.Atoi(strings.TrimSuffix(fields[4], "%"))
+		if cpuErr != nil || memErr != nil {
+			continue
+		}
+		usage = append(usage, NodeUsage{Name: fields[0], CPUPercent: cpu, MemoryPercent: memory})
+	}
+	return usage
+}
+
 // getNodePodCount returns the number of pods running on a specific node
 func (c *Client) getNodePodCount(nodeName string) (int, error) {
 	result, err := c.execute("get", "pods", "--all-namespaces", "--field-selector", "spec.nodeName="+nodeName, "-o", "json")
@@ -0,0 +1,542 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeKubectl writes a shell script that fails with stderrMsg for the first
+// failures invocations and prints "ok" afterwards. It returns the script path
+// and a function reporting how many times the script was run.
+func newFakeKubectl(t *testing.T, failures int, stderrMsg string) (string, func() int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	script := filepath.Join(dir, "kubectl")
+	body := fmt.Sprintf(`#!/bin/sh
+n=$(cat %q 2>/dev/null || echo 0)
+n=$((n+1))
+echo $n > %q
+if [ "$n" -le %d ]; then
+  echo %q >&2
+  exit 1
+fi
+echo ok
+`, countFile, countFile, failures, stderrMsg)
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+
+	attempts := func() int {
+		data, err := os.ReadFile(countFile)
+		if err != nil {
+			return 0
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+		return n
+	}
+	return script, attempts
+}
+
+func TestExecuteRetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		retries      int
+		stderr       string
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "succeeds after one transient failure",
+			failures:     1,
+			retries:      2,
+			stderr:       "dial tcp 127.0.0.1:6443: connect: connection refused",
+			wantErr:      false,
+			wantAttempts: 2,
+		},
+		{
+			name:         "gives up after exhausting retries",
+			failures:     3,
+			retries:      2,
+			stderr:       "net/http: TLS handshake timeout",
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "no retries by default",
+			failures:     1,
+			retries:      0,
+			stderr:       "dial tcp 127.0.0.1:6443: i/o timeout",
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "does not retry non-transient errors",
+			failures:     1,
+			retries:      2,
+			stderr:       `Error from server (NotFound): pods "web" not found`,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, attempts := newFakeKubectl(t, tt.failures, tt.stderr)
+			c := &Client{
+				Timeout:      5 * time.Second,
+				Retries:      tt.retries,
+				RetryBackoff: time.Millisecond,
+				binary:       script,
+			}
+
+			result, err := c.execute("get", "pods")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("execute() error = %v, wantErr %v (stderr: %q)", err, tt.wantErr, result.Error)
+			}
+			if !tt.wantErr && strings.TrimSpace(result.Output) != "ok" {
+				t.Errorf("execute() output = %q, want %q", result.Output, "ok")
+			}
+			if got := attempts(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestExecuteContextCancellation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "kubectl")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nexec sleep 5\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	c := &Client{Timeout: 10 * time.Second, binary: script}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	result, err := c.executeContext(ctx, "get", "pods", "-A")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("executeContext() error = %v, want context.Canceled", err)
+	}
+	if result.Error != "command cancelled" {
+		t.Errorf("result.Error = %q, want %q", result.Error, "command cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("command was not stopped promptly (took %v)", elapsed)
+	}
+}
+
+func TestCommandTimeout(t *testing.T) {
+	c := &Client{Timeout: 30 * time.Second}
+	tests := []struct {
+		args []string
+		want time.Duration
+	}{
+		{[]string{"get", "pods"}, 30 * time.Second},
+		{[]string{"wait", "--for=condition=Ready", "pod/web", "--timeout=10s"}, 30 * time.Second},
+		{[]string{"wait", "--for=condition=Ready", "pod/web", "--timeout=2m"}, 2*time.Minute + timeoutSlack},
+		{[]string{"drain", "node-1", "--timeout", "5m"}, 5*time.Minute + timeoutSlack},
+		{[]string{"get", "pods", "--request-timeout=5m"}, 30 * time.Second},
+		{[]string{"exec", "web", "--", "sh", "--timeout=5m"}, 30 * time.Second},
+		{[]string{"wait", "pod/web", "--timeout=soon"}, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := c.commandTimeout(tt.args); got != tt.want {
+			t.Errorf("commandTimeout(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "plain get is unchanged",
+			args: []string{"get", "pods", "-n", "default"},
+			want: "get pods -n default",
+		},
+		{
+			name: "secret go-template is redacted",
+			args: strings.Fields(`get secret db -o go-template='{{index .data "password" | base64decode}}' -n prod`),
+			want: "get secret db <redacted>",
+		},
+		{
+			name: "secret jsonpath inline is redacted",
+			args: []string{"get", "secrets/db", "-o=jsonpath={.data.password}"},
+			want: "get secrets/db <redacted>",
+		},
+		{
+			name: "yaml output on secrets is kept",
+			args: []string{"get", "secret", "db", "-o", "yaml"},
+			want: "get secret db -o yaml",
+		},
+		{
+			name: "jsonpath on non-secrets is kept",
+			args: []string{"get", "pods", "-o", "jsonpath={.items[*].metadata.name}"},
+			want: "get pods -o jsonpath={.items[*].metadata.name}",
+		},
+		{
+			name: "from-literal values are hidden",
+			args: []string{"create", "secret", "generic", "db", "--from-literal=password=hunter2"},
+			want: "create secret generic db --from-literal=<redacted>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(RedactArgs(tt.args), " ")
+			if got != tt.want {
+				t.Errorf("RedactArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamSendsLinesUntilCancelled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "kubectl")
+	body := "#!/bin/sh\necho first\necho second >&2\nexec sleep 30\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+
+	c := NewClient()
+	c.binary = script
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines, errc, err := c.Stream(ctx, "get", "events", "--watch")
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case line := <-lines:
+			got = append(got, line)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for lines, got %v", got)
+		}
+	}
+	if strings.Join(got, ",") != "first,second" {
+		t.Errorf("expected lines [first second], got %v", got)
+	}
+
+	cancel()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("expected no error after cancelling, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream did not stop after cancelling")
+	}
+	if _, ok := <-lines; ok {
+		t.Errorf("expected lines channel to be closed")
+	}
+}
+
+func TestParsePluginList(t *testing.T) {
+	output := "kubectl-neat\n/usr/local/bin/kubectl-view_secret\nkubectl-cert-manager\nkubectl-neat\nnot-a-plugin\n"
+	got := parsePluginList(output)
+	want := []string{"neat", "view-secret", "cert manager"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("parsePluginList() = %q, want %q", got, want)
+	}
+}
+
+// Test that plugins are still listed when kubectl warns about one it cannot
+// run, and that having no plugins is not an error.
+func TestListPluginsWithWarnings(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "kubectl")
+	body := `#!/bin/sh
+if [ -e ` + filepath.Join(dir, "none") + ` ]; then
+  echo "error: unable to find any kubectl plugins in your PATH" >&2
+  exit 1
+fi
+echo kubectl-neat
+echo kubectl-view_secret
+echo "warning: /usr/local/bin/kubectl-broken identified as a kubectl plugin, but it is not executable" >&2
+echo "error: one plugin warning was found" >&2
+exit 1
+`
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	c := &Client{Timeout: 5 * time.Second, binary: script}
+
+	plugins, warnings, err := c.ListPlugins()
+	if err != nil || strings.Join(plugins, ",") != "neat,view-secret" {
+		t.Fatalf("ListPlugins() = %q, %v; want the listed plugins", plugins, err)
+	}
+	if !strings.HasPrefix(warnings, "warning: /usr/local/bin/kubectl-broken") {
+		t.Errorf("warnings = %q, want kubectl's warning", warnings)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "none"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if plugins, warnings, err = c.ListPlugins(); plugins != nil || warnings != "" || err != nil {
+		t.Errorf("ListPlugins() = %q, %q, %v; want no plugins and no error", plugins, warnings, err)
+	}
+}
+
+func TestParseAPIResources(t *testing.T) {
+	output := "pods                 po       v1                     true    Pod\n" +
+		"leases                        coordination.k8s.io/v1 true    Lease\n" +
+		"certificates         cert,certs cert-manager.io/v1   true    Certificate\n"
+	got := parseAPIResources(output)
+	want := []string{"pods", "pod", "po", "leases", "lease", "certificates", "certificate", "cert", "certs"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("parseAPIResources() = %q, want %q", got, want)
+	}
+}
+
+func TestParseOwnerReferences(t *testing.T) {
+	output := `{"kind":"Pod","metadata":{"name":"web-5d9c7-abcde","ownerReferences":[
+		{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-5d9c7","controller":true,"uid":"1"}]}}`
+	refs, err := parseOwnerReferences(output)
+	if err != nil {
+		t.Fatalf("parseOwnerReferences() error = %v", err)
+	}
+	if len(refs) != 1 || refs[0].Kind != "ReplicaSet" || refs[0].Name != "web-5d9c7" || !refs[0].Controller {
+		t.Fatalf("parseOwnerReferences() = %+v", refs)
+	}
+	if got := refs[0].Resource(); got != "replicaset.apps" {
+		t.Errorf("Resource() = %q, want replicaset.apps", got)
+	}
+	if got := (OwnerReference{APIVersion: "v1", Kind: "Node"}).Resource(); got != "node" {
+		t.Errorf("Resource() for a core kind = %q, want node", got)
+	}
+
+	refs, err = parseOwnerReferences(`{"metadata":{"name":"bare"}}`)
+	if err != nil || len(refs) != 0 {
+		t.Errorf("expected no owners for a bare pod, got %+v (%v)", refs, err)
+	}
+}
+
+func TestParsePodInfo(t *testing.T) {
+	output := `{"kind":"Pod","spec":{"nodeName":"node-1","containers":[
+		{"name":"web","image":"nginx:1.25"},{"name":"proxy","image":"envoy:v1.29"}]},
+		"status":{"podIP":"10.0.0.7"}}`
+	info, err := parsePodInfo(output)
+	if err != nil {
+		t.Fatalf("parsePodInfo() error = %v", err)
+	}
+	if info.IP != "10.0.0.7" || info.Node != "node-1" {
+		t.Errorf("parsePodInfo() = %+v", info)
+	}
+	want := []ContainerImage{{Name: "web", Image: "nginx:1.25"}, {Name: "proxy", Image: "envoy:v1.29"}}
+	if len(info.Containers) != len(want) || info.Containers[0] != want[0] || info.Containers[1] != want[1] {
+		t.Errorf("Containers = %+v, want %+v", info.Containers, want)
+	}
+
+	// A pending pod has no IP or node yet
+	info, err = parsePodInfo(`{"spec":{"containers":[{"name":"web","image":"nginx"}]},"status":{}}`)
+	if err != nil || info.IP != "" || info.Node != "" {
+		t.Errorf("expected an empty IP and node for a pending pod, got %+v (%v)", info, err)
+	}
+
+	if _, err := parsePodInfo("not json"); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	output := `{"kind":"Deployment","spec":{"selector":{
+		"matchLabels":{"tier":"front","app":"web"},
+		"matchExpressions":[{"key":"env","operator":"In","values":["prod","staging"]},{"key":"canary","operator":"DoesNotExist"}]}}}`
+	got, err := parseLabelSelector(output)
+	if want := "app=web,tier=front,env in (prod,staging),!canary"; err != nil || got != want {
+		t.Errorf("parseLabelSelector() = %q, %v; want %q", got, err, want)
+	}
+
+	if _, err := parseLabelSelector(`{"spec":{"selector":{}}}`); err == nil {
+		t.Error("expected an error for an empty selector")
+	}
+	if _, err := parseLabelSelector("not json"); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestParseNodeUsage(t *testing.T) {
+	output := "node-1   250m   12%   1024Mi   40%\n" +
+		"node-2   <unknown>   <unknown>   <unknown>   <unknown>\n" +
+		"node-3   1200m   60%   3000Mi   95%\n"
+	got := parseNodeUsage(output)
+	want := []NodeUsage{{"node-1", 12, 40}, {"node-3", 60, 95}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("parseNodeUsage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("NS", "team-a")
+	t.Setenv("CONTEXT", "prod")
+	t.Setenv("SECRET", "hunter2")
+	c := &Client{AllowedEnv: []string{"NS", "CONTEXT"}}
+
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"$NS", "team-a"},
+		{"--context=${CONTEXT}", "--context=prod"},
+		{"$NS/$CONTEXT", "team-a/prod"},
+		{"$SECRET", "$SECRET"},
+		{"--namespace=$UNSET", "--namespace=$UNSET"},
+		{"-o=go-template={{range $i, $c := .items}}{{$c.metadata.name}}{{end}}", "-o=go-template={{range $i, $c := .items}}{{$c.metadata.name}}{{end}}"},
+		{"-o=jsonpath={$.items[*].metadata.name}", "-o=jsonpath={$.items[*].metadata.name}"},
+		{"price$", "price$"},
+		{"${HOME}", "${HOME}"},
+		{"${NS}-$HOME", "team-a-$HOME"},
+		{"a$$b", "a$$b"},
+		{"$$NS", "$team-a"},
+		{"a${", "a${"},
+		{"a${NS", "a${NS"},
+		{"${}", "${}"},
+		{"${NS:-x}", "${NS:-x}"},
+		{"$NS_2x", "$NS_2x"},
+	}
+	for _, tt := range tests {
+		if got := c.expandEnv(tt.arg); got != tt.want {
+			t.Errorf("expandEnv(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+
+	// Nothing is expanded unless variables are allowed
+	if got := (&Client{}).expandEnv("$NS"); got != "$NS" {
+		t.Errorf("expandEnv without allowed variables = %q, want $NS", got)
+	}
+
+	cmd := "kubectl delete pod web --context=${CONTEXT} -o jsonpath='{.metadata.name}'"
+	if got, want := c.ExpandEnvCommand(cmd), "kubectl delete pod web --context=prod -o 'jsonpath={.metadata.name}'"; got != want {
+		t.Errorf("ExpandEnvCommand() = %q, want %q", got, want)
+	}
+}
+
+func TestCanI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+	script := filepath.Join(t.TempDir(), "kubectl")
+	body := `#!/bin/sh
+case "$3" in
+  list) echo yes ;;
+  delete) echo no; exit 1 ;;
+  *) echo "error: the server could not be reached" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	c := &Client{Timeout: 5 * time.Second, binary: script}
+
+	if ok, err := c.CanI("list", "pods", "team-a"); !ok || err != nil {
+		t.Errorf("CanI(list) = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := c.CanI("delete", "pods", ""); ok || err != nil {
+		t.Errorf("CanI(delete) = %v, %v; want false, nil", ok, err)
+	}
+	if _, err := c.CanI("patch", "nodes", ""); err == nil || !strings.Contains(err.Error(), "could not be reached") {
+		t.Errorf("CanI(patch) error = %v, want kubectl's error", err)
+	}
+}
+
+func TestExecuteRawWithStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kubectl script requires a POSIX shell")
+	}
+	script := filepath.Join(t.TempDir(), "kubectl")
+	body := `#!/bin/sh
+case "$*" in
+  *current-context*) echo dev ;;
+  "apply -f -"|"get pods") cat ;;
+  *) echo "unexpected: $*" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("failed to write fake kubectl: %v", err)
+	}
+	c := &Client{Timeout: 5 * time.Second, binary: script}
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"
+	result, err := c.ExecuteRawWithStdin(context.Background(), "kubectl apply -f -", manifest)
+	if err != nil {
+		t.Fatalf("ExecuteRawWithStdin() error = %v (%s)", err, result.Error)
+	}
+	if result.Output != manifest {
+		t.Errorf("expected the manifest to reach kubectl's stdin, got %q", result.Output)
+	}
+
+	// Without stdin the command reads nothing rather than the terminal
+	result, err = c.execute("get", "pods")
+	if err != nil || result.Output != "" {
+		t.Errorf("execute() = %q, %v; want no input", result.Output, err)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want []string
+	}{
+		{"get pods  -n default", []string{"get", "pods", "-n", "default"}},
+		{`get pods -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}'`, []string{"get", "pods", "-o", `jsonpath={range .items[*]}{.metadata.name}{"\n"}{end}`}},
+		{`get secret db -o go-template='{{range $k, $v := .data}}{{$k}}{{end}}'`, []string{"get", "secret", "db", "-o", "go-template={{range $k, $v := .data}}{{$k}}{{end}}"}},
+		{`label pod web "team=a b" note=it\'s`, []string{"label", "pod", "web", "team=a b", "note=it's"}},
+		{`exec web -- sh -c "echo \"hi\""`, []string{"exec", "web", "--", "sh", "-c", `echo "hi"`}},
+		{`apply -f C:\manifests\web.yaml`, []string{"apply", "-f", `C:\manifests\web.yaml`}},
+		{`annotate pod web note=''`, []string{"annotate", "pod", "web", "note="}},
+	}
+	for _, tt := range tests {
+		got, err := SplitArgs(tt.cmd)
+		if err != nil || strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("SplitArgs(%q) = %q, %v; want %q", tt.cmd, got, err, tt.want)
+		}
+	}
+
+	if _, err := SplitArgs(`get pods -o jsonpath='{.items}`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestQuoteArgRoundTrips(t *testing.T) {
+	for _, arg := range []string{"pods", "jsonpath={.items[*].metadata.name}", "it's", `a "b" c`, ""} {
+		got, err := SplitArgs("get " + QuoteArg(arg))
+		if err != nil || len(got) != 2 || got[1] != arg {
+			t.Errorf("SplitArgs(QuoteArg(%q)) = %q, %v", arg, got, err)
+		}
+	}
+	if got := QuoteArg("pods"); got != "pods" {
+		t.Errorf("QuoteArg(pods) = %q, want it unquoted", got)
+	}
+}
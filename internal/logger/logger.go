@@ -5,16 +5,67 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+// Level controls which messages are written to the log file.
+type Level int
+
+const (
+	// LevelDebug writes all messages, including debug output
+	LevelDebug Level = iota
+	// LevelInfo writes informational and error messages
+	LevelInfo
+	// LevelError writes only error messages
+	LevelError
+)
+
+// String returns the string representation of a Level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel converts a level name (debug, info, error) into a Level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q (expected debug, info or error)", name)
+	}
+}
+
 var (
 	logFile *os.File
+	level   = LevelInfo
 )
 
+// SetLevel sets the minimum level of messages that are logged.
+func SetLevel(l Level) {
+	level = l
+}
+
+// DefaultPath returns the location of the log file written by Init.
+func DefaultPath() string {
+	return filepath.Join(os.TempDir(), "k8s-wizard.log")
+}
+
 // Init initializes the logger to write to a temporary file.
 func Init() (string, error) {
-	tempDir := os.TempDir()
-	path := filepath.Join(tempDir, "k8s-wizard.log")
+	path := DefaultPath()
 	
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -39,6 +90,9 @@ func Close() {
 
 // Info logs an informational message.
 func Info(format string, v ...interface{}) {
+	if level > LevelInfo {
+		return
+	}
 	log.SetPrefix("INFO: ")
 	log.Printf(format, v...)
 }
@@ -51,6 +105,9 @@ func Error(format string, v ...interface{}) {
 
 // Debug logs a debug message.
 func Debug(format string, v ...interface{}) {
+	if level > LevelDebug {
+		return
+	}
 	log.SetPrefix("DEBUG: ")
 	log.Printf(format, v...)
 }
@@ -0,0 +1,84 @@
+// Package paste uploads text to a paste service over HTTP and returns the
+// URL it is shared at.
+package paste
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds an upload, so an unreachable service fails quickly.
+const DefaultTimeout = 15 * time.Second
+
+// maxResponseSize caps how much of the service's response is read.
+const maxResponseSize = 64 * 1024
+
+// Client uploads to a paste endpoint: a URL that accepts the text as the body
+// of a POST and answers with the paste's URL, either as plain text or as a
+// JSON object with a "url" field.
+type Client struct {
+	Endpoint string
+	HTTP     *http.Client
+}
+
+// NewClient returns a client for endpoint with DefaultTimeout.
+func NewClient(endpoint string) *Client {
+	return &Client{Endpoint: endpoint, HTTP: &http.Client{Timeout: DefaultTimeout}}
+}
+
+// Upload posts content as text/plain, with name in the X-Paste-Name header
+// for services that title pastes, and returns the paste's URL.
+func (c *Client) Upload(name, content string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, c.Endpoint, strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("invalid paste endpoint: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if name != "" {
+		req.Header.Set("X-Paste-Name", name)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach paste service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read paste service response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("paste service returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return parseURL(body)
+}
+
+// parseURL returns the paste URL from a response body.
+func parseURL(body []byte) (string, error) {
+	text := strings.TrimSpace(string(body))
+	if strings.HasPrefix(text, "{") {
+		var obj struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal([]byte(text), &obj); err != nil {
+			return "", fmt.Errorf("failed to parse paste service response: %w", err)
+		}
+		text = strings.TrimSpace(obj.URL)
+	}
+	if !IsHTTPURL(text) {
+		return "", fmt.Errorf("paste service did not return a URL")
+	}
+	return text, nil
+}
+
+// IsHTTPURL reports whether s is an absolute http or https URL.
+func IsHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,63 @@
+package paste
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test that Upload posts the content and reads the URL from plain text and
+// JSON responses, and reports failures.
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr string
+	}{
+		{"plain", http.StatusCreated, "https://paste.example.com/abc\n", "https://paste.example.com/abc", ""},
+		{"json", http.StatusOK, `{"url": "https://paste.example.com/def", "id": "def"}`, "https://paste.example.com/def", ""},
+		{"no url", http.StatusOK, "saved!", "", "did not return a URL"},
+		{"error status", http.StatusRequestEntityTooLarge, "too large", "", "413 Request Entity Too Large: too large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody, gotName string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				gotBody, gotName = string(b), r.Header.Get("X-Paste-Name")
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			got, err := NewClient(server.URL).Upload("get-pods", "NAME\nweb\n")
+			if gotBody != "NAME\nweb\n" || gotName != "get-pods" {
+				t.Errorf("expected the content and name to be posted, got %q and %q", gotBody, gotName)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Upload() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// Test that an unreachable service is reported rather than hanging.
+func TestUploadUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL
+	server.Close()
+
+	if _, err := NewClient(endpoint).Upload("", "x"); err == nil || !strings.Contains(err.Error(), "failed to reach") {
+		t.Errorf("expected an unreachable error, got %v", err)
+	}
+}
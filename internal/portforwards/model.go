@@ -0,0 +1,29 @@
+package portforwards
+
+import "strings"
+
+// Forward is a saved port-forward that can be relaunched in the background.
+type Forward struct {
+	Name      string `json:"name"`
+	Resource  string `json:"resource"` // e.g. "svc/web" or "pod/web-5d9c7-abcde"
+	Ports     string `json:"ports"`    // e.g. "8080:80", several separated by spaces
+	Namespace string `json:"namespace,omitempty"`
+	Context   string `json:"context,omitempty"` // the kube context, when not the current one
+}
+
+// Args returns the kubectl arguments that start the forward.
+func (f Forward) Args() []string {
+	args := append([]string{"port-forward", f.Resource}, strings.Fields(f.Ports)...)
+	if f.Namespace != "" {
+		args = append(args, "-n", f.Namespace)
+	}
+	if f.Context != "" {
+		args = append(args, "--context", f.Context)
+	}
+	return args
+}
+
+// Command returns the forward as a kubectl command line.
+func (f Forward) Command() string {
+	return "kubectl " + strings.Join(f.Args(), " ")
+}
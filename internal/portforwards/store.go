@@ -0,0 +1,106 @@
+package portforwards
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/storage"
+)
+
+const portForwardsFileName = "kube-wizard-portforwards.json"
+
+// migrations upgrade older port-forwards files (see storage.Migration)
+var migrations = []storage.Migration{
+	storage.Unversioned, // 0 -> 1: the list moves into a versioned file
+}
+
+// Store manages persistence of saved port-forwards.
+type Store struct {
+	filePath string
+	forwards []Forward
+}
+
+// NewStore creates a new port-forwards store.
+// Port-forwards are stored in the user's home directory.
+func NewStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		filePath: filepath.Join(homeDir, portForwardsFileName),
+		forwards: []Forward{},
+	}
+
+	if err := store.Load(); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// Load reads port-forwards from disk.
+func (s *Store) Load() error {
+	return storage.Load(s.filePath, migrations, s.decode, s.Save)
+}
+
+// decode replaces the store's contents with the data of its file
+func (s *Store) decode(raw json.RawMessage) error {
+	return json.Unmarshal(raw, &s.forwards)
+}
+
+// Save writes port-forwards to disk atomically.
+func (s *Store) Save() error {
+	// Create backup before saving
+	if err := storage.Backup(s.filePath); err != nil {
+		// Log error but continue saving
+	}
+
+	data, err := storage.Encode(s.forwards, migrations)
+	if err != nil {
+		return err
+	}
+
+	return storage.WriteAtomic(s.filePath, data)
+}
+
+// Add saves a port-forward, replacing a saved one with the same name.
+func (s *Store) Add(f Forward) error {
+	for i, existing := range s.forwards {
+		if existing.Name == f.Name {
+			s.forwards[i] = f
+			return s.Save()
+		}
+	}
+	s.forwards = append(s.forwards, f)
+	return s.Save()
+}
+
+// Delete removes a port-forward by index.
+func (s *Store) Delete(index int) error {
+	if index < 0 || index >= len(s.forwards) {
+		return nil
+	}
+
+	s.forwards = append(s.forwards[:index], s.forwards[index+1:]...)
+	return s.Save()
+}
+
+// Get returns a port-forward by index.
+func (s *Store) Get(index int) (Forward, bool) {
+	if index < 0 || index >= len(s.forwards) {
+		return Forward{}, false
+	}
+	return s.forwards[index], true
+}
+
+// List returns all saved port-forwards.
+func (s *Store) List() []Forward {
+	out := make([]Forward, len(s.forwards))
+	copy(out, s.forwards)
+	return out
+}
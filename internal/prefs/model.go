@@ -0,0 +1,17 @@
+package prefs
+
+// Prefs holds user preferences that are changed from within the UI.
+type Prefs struct {
+	PinnedNamespaces []string `json:"pinned_namespaces"`
+
+	// ResourceUsage counts how often each resource type was picked in the
+	// command wizard, keyed by its menu title.
+	ResourceUsage map[string]int `json:"resource_usage"`
+
+	// DefaultFlags lists the flags pre-ticked in the flags selection, keyed
+	// by resource type and action, e.g. "Pods/Get".
+	DefaultFlags map[string][]string `json:"default_flags"`
+
+	// RecentContexts lists the kube contexts switched to, most recent first.
+	RecentContexts []string `json:"recent_contexts"`
+}
@@ -0,0 +1,179 @@
+package prefs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/storage"
+)
+
+const prefsFileName = "kube-wizard-prefs.json"
+
+// migrations upgrade older prefs files (see storage.Migration)
+var migrations = []storage.Migration{
+	storage.Unversioned, // 0 -> 1: the prefs move into a versioned file
+}
+
+// Store manages persistence of user preferences.
+type Store struct {
+	filePath string
+	prefs    Prefs
+}
+
+// NewStore creates a new prefs store.
+// Preferences are stored in the user's home directory.
+func NewStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		filePath: filepath.Join(homeDir, prefsFileName),
+	}
+
+	if err := store.Load(); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// Load reads preferences from disk.
+func (s *Store) Load() error {
+	return storage.Load(s.filePath, migrations, s.decode, s.Save)
+}
+
+// decode replaces the store's contents with the data of its file
+func (s *Store) decode(raw json.RawMessage) error {
+	var prefs Prefs
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return err
+	}
+
+	s.prefs = prefs
+	return nil
+}
+
+// Save writes preferences to disk atomically.
+func (s *Store) Save() error {
+	// Create backup before saving
+	if err := storage.Backup(s.filePath); err != nil {
+		// Log error but continue saving
+	}
+
+	data, err := storage.Encode(s.prefs, migrations)
+	if err != nil {
+		return err
+	}
+
+	return storage.WriteAtomic(s.filePath, data)
+}
+
+// IsNamespacePinned reports whether a namespace is pinned.
+func (s *Store) IsNamespacePinned(name string) bool {
+	for _, ns := range s.prefs.PinnedNamespaces {
+		if ns == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TogglePinnedNamespace pins or unpins a namespace and returns whether it is
+// pinned afterwards.
+func (s *Store) TogglePinnedNamespace(name string) (bool, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, nil
+	}
+
+	pinned := !s.IsNamespacePinned(name)
+	if pinned {
+		s.prefs.PinnedNamespaces = append(s.prefs.PinnedNamespaces, name)
+	} else {
+		kept := make([]string, 0, len(s.prefs.PinnedNamespaces))
+		for _, ns := range s.prefs.PinnedNamespaces {
+			if ns != name {
+				kept = append(kept, ns)
+			}
+		}
+		s.prefs.PinnedNamespaces = kept
+	}
+
+	return pinned, s.Save()
+}
+
+// ResourceUseCount returns how many times a resource type has been picked.
+func (s *Store) ResourceUseCount(name string) int {
+	return s.prefs.ResourceUsage[name]
+}
+
+// RecordResourceUse increments the usage count of a resource type.
+func (s *Store) RecordResourceUse(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	if s.prefs.ResourceUsage == nil {
+		s.prefs.ResourceUsage = make(map[string]int)
+	}
+	s.prefs.ResourceUsage[name]++
+
+	return s.Save()
+}
+
+// DefaultFlags returns the flags saved as defaults for a resource type and
+// action key such as "Pods/Get".
+func (s *Store) DefaultFlags(key string) []string {
+	return s.prefs.DefaultFlags[key]
+}
+
+// SetDefaultFlags saves the default flags for a resource type and action
+// key. An empty list removes the defaults.
+func (s *Store) SetDefaultFlags(key string, flags []string) error {
+	if len(flags) == 0 {
+		delete(s.prefs.DefaultFlags, key)
+		return s.Save()
+	}
+
+	if s.prefs.DefaultFlags == nil {
+		s.prefs.DefaultFlags = make(map[string][]string)
+	}
+	s.prefs.DefaultFlags[key] = append([]string(nil), flags...)
+
+	return s.Save()
+}
+
+// MaxRecentContexts is how many recently used contexts are remembered.
+const MaxRecentContexts = 5
+
+// RecentContexts returns the contexts switched to, most recent first.
+func (s *Store) RecentContexts() []string {
+	return s.prefs.RecentContexts
+}
+
+// RecordContext moves a context to the front of the recent contexts,
+// dropping the oldest beyond MaxRecentContexts. Nothing is saved when it is
+// already the most recent.
+func (s *Store) RecordContext(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || (len(s.prefs.RecentContexts) > 0 && s.prefs.RecentContexts[0] == name) {
+		return nil
+	}
+
+	recent := []string{name}
+	for _, c := range s.prefs.RecentContexts {
+		if c != name && len(recent) < MaxRecentContexts {
+			recent = append(recent, c)
+		}
+	}
+	s.prefs.RecentContexts = recent
+
+	return s.Save()
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/SzymonSkrzypczyk/k8s-wizard/internal/logger"
+)
+
+// Migration upgrades a store file's data by one version. Each store lists its
+// migrations in order, so migrations[v] upgrades version v and the current
+// version is len(migrations).
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// Unversioned is the first migration of every store: files written before
+// versions existed hold their data as is, and only gain the envelope.
+func Unversioned(data json.RawMessage) (json.RawMessage, error) {
+	return data, nil
+}
+
+// versionedFile is the on-disk layout of a store file.
+type versionedFile struct {
+	Version *int            `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Decode returns the data of a store file, upgraded to the current version
+// by running migrations[v] for each version v from the file's onwards. The
+// current version is len(migrations); files without a version are version 0.
+// migrated reports whether any migration ran, so the file should be saved.
+func Decode(raw []byte, migrations []Migration) (data json.RawMessage, migrated bool, err error) {
+	version := 0
+	data = json.RawMessage(raw)
+
+	// Unversioned files may be lists or objects; only an object with a
+	// version is an envelope
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var file versionedFile
+		if err := json.Unmarshal(trimmed, &file); err != nil {
+			return nil, false, err
+		}
+		if file.Version != nil {
+			version = *file.Version
+			data = file.Data
+		}
+	}
+
+	if version < 0 || version > len(migrations) {
+		return nil, false, fmt.Errorf("unsupported version %d (this build reads up to %d)", version, len(migrations))
+	}
+	for v := version; v < len(migrations); v++ {
+		data, err = migrations[v](data)
+		if err != nil {
+			return nil, false, fmt.Errorf("upgrading from version %d: %w", v, err)
+		}
+	}
+	return data, version < len(migrations), nil
+}
+
+// Encode marshals v into a store file at the current version, i.e.
+// len(migrations).
+func Encode(v interface{}, migrations []Migration) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	version := len(migrations)
+	return json.MarshalIndent(versionedFile{Version: &version, Data: data}, "", "  ")
+}
+
+// Load reads the store file at path and passes its data, upgraded by
+// migrations, to decode. A file written by an older release is then
+// rewritten with save, which keeps the original as a backup. The data was
+// read fine, so a failed rewrite, e.g. in a read-only home directory, is
+// only logged and the file is upgraded again next time. A missing file is
+// returned as os.ReadFile reports it.
+func Load(path string, migrations []Migration, decode func(data json.RawMessage) error, save func() error) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	data, migrated, err := Decode(raw, migrations)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := decode(data); err != nil {
+		return err
+	}
+
+	if migrated {
+		if err := save(); err != nil {
+			logger.Error("Failed to rewrite %s at the current version: %v", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that unversioned files are upgraded through every migration while
+// current ones are read as they are.
+func TestDecodeRunsMigrationsFromFileVersion(t *testing.T) {
+	var ran []int
+	step := func(v int) Migration {
+		return func(data json.RawMessage) (json.RawMessage, error) {
+			ran = append(ran, v)
+			return data, nil
+		}
+	}
+	migrations := []Migration{step(0), step(1)}
+
+	tests := []struct {
+		raw      string
+		wantRan  []int
+		wantData string
+		migrated bool
+	}{
+		{`[{"name": "pods"}]`, []int{0, 1}, `[{"name": "pods"}]`, true},
+		{`{"pinned_namespaces": ["dev"]}`, []int{0, 1}, `{"pinned_namespaces": ["dev"]}`, true},
+		{`{"version": 1, "data": [1]}`, []int{1}, `[1]`, true},
+		{`{"version": 2, "data": [1]}`, nil, `[1]`, false},
+	}
+	for _, tt := range tests {
+		ran = nil
+		data, migrated, err := Decode([]byte(tt.raw), migrations)
+		if err != nil {
+			t.Fatalf("Decode(%s): %v", tt.raw, err)
+		}
+		if string(data) != tt.wantData || migrated != tt.migrated || len(ran) != len(tt.wantRan) {
+			t.Errorf("Decode(%s) = %s, %t after %v", tt.raw, data, migrated, ran)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, _, err := Decode([]byte(`{"version": 3, "data": []}`), []Migration{Unversioned}); err == nil || !strings.Contains(err.Error(), "unsupported version 3") {
+		t.Errorf("expected a newer version to be refused, got %v", err)
+	}
+	failing := func(json.RawMessage) (json.RawMessage, error) { return nil, errors.New("bad group") }
+	if _, _, err := Decode([]byte(`[]`), []Migration{Unversioned, failing}); err == nil || !strings.Contains(err.Error(), "upgrading from version 1: bad group") {
+		t.Errorf("expected the failing migration to be named, got %v", err)
+	}
+}
+
+func TestEncodeWritesCurrentVersion(t *testing.T) {
+	data, err := Encode([]string{"a"}, []Migration{Unversioned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, migrated, err := Decode(data, []Migration{Unversioned})
+	var values []string
+	if err == nil {
+		err = json.Unmarshal(decoded, &values)
+	}
+	if err != nil || migrated || len(values) != 1 || values[0] != "a" {
+		t.Errorf("round trip gave %s, %t, %v from:\n%s", decoded, migrated, err, data)
+	}
+}
+
+// Test that Load decodes a file's upgraded data and saves only files written
+// at an older version.
+func TestLoadRewritesOlderFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	saves := 0
+	var values []string
+	decode := func(data json.RawMessage) error { return json.Unmarshal(data, &values) }
+	save := func() error {
+		saves++
+		data, err := Encode(values, []Migration{Unversioned})
+		if err != nil {
+			return err
+		}
+		return WriteAtomic(path, data)
+	}
+
+	if err := Load(path, []Migration{Unversioned}, decode, save); !os.IsNotExist(err) {
+		t.Fatalf("expected a missing file to be reported, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`["a"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := Load(path, []Migration{Unversioned}, decode, save); err != nil || len(values) != 1 || values[0] != "a" {
+			t.Fatalf("Load() = %v with %v", err, values)
+		}
+	}
+	if saves != 1 {
+		t.Errorf("expected the unversioned file to be rewritten once, got %d saves", saves)
+	}
+
+	failing := func(json.RawMessage) error { return errors.New("not a list") }
+	if err := Load(path, []Migration{Unversioned}, failing, save); err == nil || err.Error() != "not a list" {
+		t.Errorf("expected the decode error, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // SimpleItem implements list.Item for simple string lists
@@ -20,9 +21,13 @@ func NewSimpleItem(title, desc string) SimpleItem {
 	return SimpleItem{title: title, desc: desc}
 }
 
+// listDelegate renders the items of every list made by NewList; ListIndexAt
+// takes its row metrics from it.
+var listDelegate = list.NewDefaultDelegate()
+
 // NewList creates a new list with the given items and title
 func NewList(items []list.Item, title string, width, height int) list.Model {
-	l := list.New(items, list.NewDefaultDelegate(), width, height)
+	l := list.New(items, listDelegate, width, height)
 	l.Title = title
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
@@ -43,3 +48,27 @@ func UpdateList(l list.Model, msg tea.Msg) (list.Model, tea.Cmd) {
 	newList, cmd := l.Update(msg)
 	return newList, cmd
 }
+
+// ListIndexAt returns the index of the item rendered at row, counted from the
+// first line of a list made by NewList. It reports false for the title, the
+// gaps between items and rows below the last item on the current page.
+func ListIndexAt(l list.Model, row int) (int, bool) {
+	if l.ShowTitle() {
+		row -= lipgloss.Height(l.Styles.TitleBar.Render(l.Title))
+	}
+	if row < 0 {
+		return 0, false
+	}
+
+	itemRows := listDelegate.Height() + listDelegate.Spacing()
+	if row%itemRows >= listDelegate.Height() {
+		return 0, false
+	}
+
+	start, end := l.Paginator.GetSliceBounds(len(l.VisibleItems()))
+	idx := start + row/itemRows
+	if idx >= end {
+		return 0, false
+	}
+	return idx, true
+}
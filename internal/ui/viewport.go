@@ -3,6 +3,8 @@ package ui
 import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
 )
 
 // NewViewport creates a new viewport for displaying scrollable content
@@ -21,3 +23,12 @@ func UpdateViewport(vp viewport.Model, msg tea.Msg) (viewport.Model, tea.Cmd) {
 func SetViewportContent(vp *viewport.Model, content string) {
 	vp.SetContent(content)
 }
+
+// WrapContent wraps content so no line is wider than width. Words are kept
+// together where possible; longer words are broken.
+func WrapContent(content string, width int) string {
+	if width <= 0 {
+		return content
+	}
+	return wrap.String(wordwrap.String(content, width), width)
+}